@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config loads the indexer's repository configuration.
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/osv/docker/indexer/shared"
+)
+
+// Load reads every .yaml file under dir and returns the configured
+// repository entries.
+func Load(dir string) ([]shared.RepoEntry, error) {
+	var entries []shared.RepoEntry
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".yaml") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var fileEntries []shared.RepoEntry
+		if err := yaml.Unmarshal(data, &fileEntries); err != nil {
+			return fmt.Errorf("invalid config %s: %w", path, err)
+		}
+		for i := range fileEntries {
+			if err := validate(&fileEntries[i]); err != nil {
+				return fmt.Errorf("invalid config %s: %w", path, err)
+			}
+		}
+		entries = append(entries, fileEntries...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func validate(entry *shared.RepoEntry) error {
+	if entry.Name == "" {
+		return fmt.Errorf("entry with repo_url %q has no name", entry.RepoURL)
+	}
+	switch entry.RepoType() {
+	case shared.TypeGit, shared.TypeHg, shared.TypeSvn:
+		if entry.RepoURL == "" {
+			return fmt.Errorf("entry %q has no repo_url", entry.Name)
+		}
+	case shared.TypeTarball:
+		if len(entry.Archives) == 0 {
+			return fmt.Errorf("tarball entry %q has no archives", entry.Name)
+		}
+		for _, archive := range entry.Archives {
+			if archive.Version == "" || archive.URL == "" {
+				return fmt.Errorf("entry %q has an archive without a version or url", entry.Name)
+			}
+		}
+	default:
+		return fmt.Errorf("entry %q has unknown type %q", entry.Name, entry.Type)
+	}
+	for _, alias := range entry.Aliases {
+		if alias.Name == "" {
+			return fmt.Errorf("entry %q has an alias without a name", entry.Name)
+		}
+	}
+	if entry.Profile != "" {
+		if _, ok := shared.ExtensionProfiles[entry.Profile]; !ok {
+			return fmt.Errorf("entry %q has unknown profile %q", entry.Name, entry.Profile)
+		}
+	}
+
+	return nil
+}
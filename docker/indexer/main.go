@@ -0,0 +1,340 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The indexer clones configured repositories, hashes the source files of
+// every version, and stores the resulting index documents for the
+// determineversion API. It either processes all configured repositories
+// in bulk, or — with -subscription — consumes individual new-tag tasks
+// from a Pub/Sub subscription for low-latency incremental indexing.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+
+	"github.com/google/osv/docker/indexer/config"
+	"github.com/google/osv/docker/indexer/metrics"
+	"github.com/google/osv/docker/indexer/shared"
+	"github.com/google/osv/docker/indexer/stages/export"
+	"github.com/google/osv/docker/indexer/stages/preparation"
+	"github.com/google/osv/docker/indexer/stages/processing"
+	"github.com/google/osv/docker/indexer/storage"
+)
+
+// task is one incremental indexing request, published by the tag poller
+// or a webhook bridge.
+type task struct {
+	// Name is the config entry name of the repository.
+	Name string `json:"name"`
+	// Tag is the tag to index.
+	Tag string `json:"tag"`
+}
+
+func main() {
+	configDir := flag.String("config", "", "directory holding repository config yamls")
+	projectID := flag.String("project", "", "GCP project ID of the Datastore to write to")
+	storageSpec := flag.String("storage", "", "storage backend: datastore://PROJECT, postgres://... or sqlite://PATH (defaults to the -project Datastore)")
+	subscription := flag.String("subscription", "", "consume new-tag tasks from this Pub/Sub subscription instead of bulk-processing all repositories")
+	metricsAddr := flag.String("metrics-addr", "", "expose Prometheus metrics on this address (e.g. :9090)")
+	repoRetries := flag.Int("repo-retries", 2, "retries per repository before writing a dead-letter record")
+	gc := flag.Bool("gc", false, "remove documents of deleted or force-pushed tags instead of indexing")
+	bigqueryDataset := flag.String("bigquery-dataset", "", "also export stored documents to this BigQuery dataset in -project")
+	dryRun := flag.String("dry-run", "", "write documents as JSON under this directory instead of storing them, and report the diff against stored entities")
+	flag.Parse()
+
+	if *configDir == "" {
+		log.Fatal("-config is required")
+	}
+	spec := *storageSpec
+	if spec == "" {
+		if *projectID == "" {
+			log.Fatal("either -storage or -project is required")
+		}
+		spec = "datastore://" + *projectID
+	}
+	if *subscription != "" && *projectID == "" {
+		log.Fatal("-subscription requires -project")
+	}
+
+	if *metricsAddr != "" {
+		metrics.Serve(*metricsAddr)
+	}
+
+	ctx := context.Background()
+	entries, err := config.Load(*configDir)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	store, err := storage.New(ctx, spec)
+	if err != nil {
+		log.Fatalf("Failed to create storage client: %v", err)
+	}
+	var dry *storage.DryRun
+	switch {
+	case *dryRun != "":
+		dry, err = storage.NewDryRun(store, *dryRun)
+		if err != nil {
+			log.Fatalf("Failed to create dry-run store: %v", err)
+		}
+		store = dry
+	case *bigqueryDataset != "":
+		if *projectID == "" {
+			log.Fatal("-bigquery-dataset requires -project")
+		}
+		store, err = export.Wrap(ctx, store, *projectID, *bigqueryDataset)
+		if err != nil {
+			log.Fatalf("Failed to create BigQuery exporter: %v", err)
+		}
+	}
+	defer store.Close()
+
+	if *subscription != "" {
+		if err := subscribe(ctx, store, entries, *projectID, *subscription); err != nil {
+			log.Fatalf("Subscriber failed: %v", err)
+		}
+
+		return
+	}
+
+	workDir, err := os.MkdirTemp("", "indexer-*")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if *gc {
+		for _, entry := range entries {
+			collectGarbage(ctx, store, entry, workDir)
+		}
+
+		return
+	}
+
+	for _, entry := range entries {
+		indexWithRetries(ctx, store, entry, workDir, *repoRetries)
+	}
+	if dry != nil {
+		log.Print(dry.Report())
+	}
+}
+
+// collectGarbage removes entry's documents whose tag no longer exists
+// upstream, keeping storage size bounded after force-pushes and tag
+// deletions.
+func collectGarbage(ctx context.Context, store storage.Store, entry shared.RepoEntry, workDir string) {
+	live, err := preparation.LiveKeys(ctx, entry, workDir)
+	if err != nil {
+		log.Printf("Listing live versions of %s failed: %v", entry.Name, err)
+		return
+	}
+	names := make([]string, 0, len(entry.Aliases)+1)
+	for _, alias := range entry.AllAliases() {
+		names = append(names, alias.Name)
+	}
+	removed, err := store.CollectGarbage(ctx, names, live)
+	if err != nil {
+		log.Printf("Garbage collection of %s failed: %v", entry.Name, err)
+		return
+	}
+	if removed > 0 {
+		log.Printf("Removed %d superseded documents of %s", removed, entry.Name)
+	}
+}
+
+// indexWithRetries runs indexEntry within the repository's retry budget.
+// A repository that keeps failing gets a dead-letter record and the loop
+// moves on; one bad repository never stops the others from being
+// indexed.
+func indexWithRetries(ctx context.Context, store storage.Store, entry shared.RepoEntry, workDir string, retries int) {
+	var err error
+	attempts := 0
+	for attempts <= retries {
+		attempts++
+		if err = indexEntry(ctx, store, entry, workDir); err == nil {
+			return
+		}
+		log.Printf("Indexing %s failed (attempt %d): %v", entry.Name, attempts, err)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	dl := storage.DeadLetter{
+		Name:     entry.Name,
+		Reason:   err.Error(),
+		Attempts: attempts,
+		When:     time.Now(),
+	}
+	if err := store.SaveDeadLetter(ctx, dl); err != nil {
+		log.Printf("Saving dead letter for %s failed: %v", entry.Name, err)
+	}
+}
+
+// indexEntry runs the full pipeline for one configured repository,
+// resuming from its checkpoint if a previous run was interrupted. It
+// returns the first failure, with panics in the pipeline captured as
+// errors so one broken repository can't take the worker down.
+func indexEntry(ctx context.Context, store storage.Store, entry shared.RepoEntry, workDir string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while indexing %s: %v", entry.Name, r)
+		}
+	}()
+
+	resumeAfter := ""
+	cp, ok, err := store.LoadCheckpoint(ctx, entry.Name)
+	if err != nil {
+		log.Printf("Loading checkpoint for %s failed: %v", entry.Name, err)
+	} else if ok {
+		resumeAfter = cp.Tag
+		log.Printf("Resuming %s after tag %s", entry.Name, cp.Tag)
+	}
+
+	results := make(chan preparation.Result)
+	go func() {
+		defer close(results)
+		// Recover here too: indexEntry's recover can't see panics on
+		// this goroutine.
+		defer func() {
+			if r := recover(); r != nil {
+				results <- preparation.Result{Err: fmt.Errorf("panic in preparation: %v", r)}
+			}
+		}()
+		defer metrics.ObserveStage(metrics.StagePreparation, time.Now())
+		if entry.RepoType() == shared.TypeTarball {
+			preparation.PrepareArchives(ctx, entry, workDir, store.ExistsMulti, results)
+			return
+		}
+		preparation.Prepare(ctx, entry, workDir, store.ExistsMulti, resumeAfter, results)
+	}()
+	var firstErr error
+	for result := range results {
+		if result.Err != nil {
+			log.Printf("Preparation of %s failed: %v", entry.Name, result.Err)
+			metrics.Errors.WithLabelValues(metrics.StagePreparation).Inc()
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+			continue
+		}
+		if err := processAndStore(ctx, store, result.Checkout); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		metrics.TagsIndexed.Inc()
+		err := store.SaveCheckpoint(ctx, storage.Checkpoint{
+			Name:   entry.Name,
+			Tag:    result.Checkout.Version,
+			Commit: result.Checkout.CommitTag,
+			Stage:  storage.CheckpointStored,
+			When:   time.Now(),
+		})
+		if err != nil {
+			log.Printf("Saving checkpoint for %s failed: %v", entry.Name, err)
+		}
+	}
+	// Only a clean run clears the checkpoint; failed tags stay covered by
+	// the next run's Exists filter.
+	if firstErr == nil {
+		if err := store.ClearCheckpoint(ctx, entry.Name); err != nil {
+			log.Printf("Clearing checkpoint for %s failed: %v", entry.Name, err)
+		}
+	}
+	metrics.ReposProcessed.Inc()
+
+	return firstErr
+}
+
+func processAndStore(ctx context.Context, store storage.Store, checkout shared.RepoCheckout) error {
+	start := time.Now()
+	docs, err := processing.Process(ctx, checkout)
+	metrics.ObserveStage(metrics.StageProcessing, start)
+	if err != nil {
+		log.Printf("Processing %s@%s failed: %v", checkout.Entry.Name, checkout.Version, err)
+		metrics.Errors.WithLabelValues(metrics.StageProcessing).Inc()
+		return err
+	}
+	start = time.Now()
+	defer metrics.ObserveStage(metrics.StageStorage, start)
+	var firstErr error
+	for _, doc := range docs {
+		if err := store.Put(ctx, doc); err != nil {
+			log.Printf("Storing %s@%s failed: %v", doc.Name, doc.Version, err)
+			metrics.Errors.WithLabelValues(metrics.StageStorage).Inc()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		metrics.StoreWrites.Inc()
+	}
+
+	return firstErr
+}
+
+// subscribe consumes indexing tasks until ctx is cancelled. Tasks for
+// unknown repositories are acked (a config change will republish them);
+// transient failures are nacked for redelivery.
+func subscribe(ctx context.Context, store storage.Store, entries []shared.RepoEntry, projectID, subscription string) error {
+	byName := make(map[string]shared.RepoEntry, len(entries))
+	for _, entry := range entries {
+		byName[entry.Name] = entry
+	}
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return client.Subscription(subscription).Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		var t task
+		if err := json.Unmarshal(msg.Data, &t); err != nil {
+			log.Printf("Dropping malformed task: %v", err)
+			msg.Ack()
+			return
+		}
+		entry, ok := byName[t.Name]
+		if !ok {
+			log.Printf("Dropping task for unknown repository %q", t.Name)
+			msg.Ack()
+			return
+		}
+		workDir, err := os.MkdirTemp("", "indexer-*")
+		if err != nil {
+			log.Printf("Failed to create work dir: %v", err)
+			msg.Nack()
+			return
+		}
+		defer os.RemoveAll(workDir)
+		checkout, err := preparation.PrepareTag(ctx, entry, workDir, t.Tag)
+		if err != nil {
+			log.Printf("Preparation of %s@%s failed: %v", t.Name, t.Tag, err)
+			msg.Nack()
+			return
+		}
+		if err := processAndStore(ctx, store, checkout); err != nil {
+			msg.Nack()
+			return
+		}
+		msg.Ack()
+	})
+}
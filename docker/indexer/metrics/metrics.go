@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exposes the indexer's Prometheus metrics so operators
+// can alert on a stalled or failing pipeline.
+package metrics
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// The pipeline stages used as metric labels.
+const (
+	StagePreparation = "preparation"
+	StageProcessing  = "processing"
+	StageStorage     = "storage"
+)
+
+var (
+	// ReposProcessed counts repositories the bulk loop has finished.
+	ReposProcessed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "indexer_repos_processed_total",
+		Help: "Repositories fully processed.",
+	})
+	// TagsIndexed counts tags whose documents were stored.
+	TagsIndexed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "indexer_tags_indexed_total",
+		Help: "Tags processed and stored.",
+	})
+	// StoreWrites counts stored index documents.
+	StoreWrites = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "indexer_store_writes_total",
+		Help: "Index documents written to storage.",
+	})
+	// Errors counts failures per pipeline stage.
+	Errors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "indexer_errors_total",
+		Help: "Failures by pipeline stage.",
+	}, []string{"stage"})
+	// StageDuration tracks per-stage latency.
+	StageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "indexer_stage_duration_seconds",
+		Help:    "Stage latency by pipeline stage.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"stage"})
+)
+
+// ObserveStage records one stage execution that started at start.
+func ObserveStage(stage string, start time.Time) {
+	StageDuration.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+}
+
+// Serve exposes /metrics on addr in the background.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Metrics server failed: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shared holds the types passed between the indexer's stages.
+package shared
+
+import (
+	"time"
+)
+
+// The supported file hash types. MD5 is the default and what existing
+// documents were indexed with; SHA-1 and SHA-256 serve clients that
+// can't produce MD5.
+const (
+	MD5    = "MD5"
+	SHA1   = "SHA-1"
+	SHA256 = "SHA-256"
+)
+
+// ExtensionProfiles maps a language profile name to the file extensions
+// it hashes, restricting matching to the language's source surface and
+// keeping docs, tests data and build scripts out of the index.
+var ExtensionProfiles = map[string][]string{
+	"c-cpp":      {".c", ".cc", ".cpp", ".cxx", ".h", ".hh", ".hpp", ".hxx"},
+	"java":       {".java"},
+	"python":     {".py"},
+	"javascript": {".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx"},
+}
+
+// NameAlias is one name/CPE mapping a repository is indexed under. Repos
+// that ship several libraries (e.g. a monorepo producing multiple
+// packages) declare one alias per product so determineversion can return
+// the correct product name.
+type NameAlias struct {
+	// Name is the product name emitted for this alias.
+	Name string `yaml:"name"`
+	// BaseCPE is the CPE prefix emitted for this alias.
+	BaseCPE string `yaml:"base_cpe"`
+	// VersionPrefix, when set, restricts this alias to tags starting with
+	// the prefix, which is stripped when deriving the version (e.g.
+	// "submodule-v" turns tag "submodule-v1.2.3" into version "1.2.3").
+	VersionPrefix string `yaml:"version_prefix"`
+}
+
+// Repository entry types. Git, Mercurial and Subversion entries are
+// cloned and indexed per tag; tarball entries index released source
+// archives, whose contents often differ from the git tags (e.g.
+// autoconf-generated files).
+const (
+	TypeGit     = "git"
+	TypeHg      = "hg"
+	TypeSvn     = "svn"
+	TypeTarball = "tarball"
+)
+
+// Archive is one release archive of a tarball-type entry.
+type Archive struct {
+	// Version is the release version the archive contains.
+	Version string `yaml:"version"`
+	// URL is where to download the archive. .tar.gz, .tgz and .zip are
+	// supported.
+	URL string `yaml:"url"`
+}
+
+// RepoEntry is the configuration for one repository to index.
+type RepoEntry struct {
+	Name           string   `yaml:"name"`
+	BaseCPE        string   `yaml:"base_cpe"`
+	RepoURL        string   `yaml:"repo_url"`
+	Type           string   `yaml:"type"`
+	FileExts       []string `yaml:"file_exts"`
+	BranchVersions bool     `yaml:"branch_versions"`
+	// Aliases lists additional name/CPE mappings to index the repository
+	// under. The top-level Name/BaseCPE pair always forms the first,
+	// implicit alias with no version prefix.
+	Aliases []NameAlias `yaml:"aliases"`
+	// VersionOverrides forces the version scheme for tags with a given
+	// prefix, for repositories whose tags the shared versionscheme
+	// detection would misclassify.
+	VersionOverrides []VersionOverride `yaml:"version_overrides"`
+	// HashTypes lists the file hash types to index the repository with.
+	// Empty means MD5 only.
+	HashTypes []string `yaml:"hash_types"`
+	// Profile names an ExtensionProfiles entry to hash with. An explicit
+	// FileExts list takes precedence.
+	Profile string `yaml:"profile"`
+	// Archives lists the release archives of a tarball-type entry.
+	Archives []Archive `yaml:"archives"`
+}
+
+// RepoType returns the entry's type, defaulting to git.
+func (e *RepoEntry) RepoType() string {
+	if e.Type == "" {
+		return TypeGit
+	}
+
+	return e.Type
+}
+
+// VersionOverride forces a version scheme for tags with a given prefix.
+type VersionOverride struct {
+	Prefix string `yaml:"prefix"`
+	Scheme string `yaml:"scheme"`
+}
+
+// AllAliases returns the repository's implicit primary alias followed by
+// any configured additional aliases.
+func (e *RepoEntry) AllAliases() []NameAlias {
+	return append([]NameAlias{{Name: e.Name, BaseCPE: e.BaseCPE}}, e.Aliases...)
+}
+
+// FileExtensions returns the extensions to hash: the explicit file_exts
+// list if set, otherwise the configured profile's extensions. Empty
+// means every file is hashed.
+func (e *RepoEntry) FileExtensions() []string {
+	if len(e.FileExts) > 0 {
+		return e.FileExts
+	}
+
+	return ExtensionProfiles[e.Profile]
+}
+
+// AllHashTypes returns the configured hash types, defaulting to MD5.
+func (e *RepoEntry) AllHashTypes() []string {
+	if len(e.HashTypes) == 0 {
+		return []string{MD5}
+	}
+
+	return e.HashTypes
+}
+
+// RepoCheckout identifies one version of a repository to process.
+type RepoCheckout struct {
+	Entry RepoEntry
+	// Version is the tag or branch name the checkout came from.
+	Version string
+	// CommitTag is the commit the version resolves to.
+	CommitTag string
+	// When is the commit time of the checkout.
+	When time.Time
+	// Dir is the working tree to hash.
+	Dir string
+}
+
+// FileResult is the hash of one source file of a checkout.
+type FileResult struct {
+	Path string `datastore:"path"`
+	Hash []byte `datastore:"hash"`
+}
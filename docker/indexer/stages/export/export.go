@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export streams stored index documents to BigQuery, enabling
+// analytical queries — coverage by repository, hash collision rates —
+// that are impractical against the primary store.
+package export
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+
+	"github.com/google/osv/docker/indexer/stages/processing"
+	"github.com/google/osv/docker/indexer/storage"
+)
+
+const (
+	documentsTable = "repo_index"
+	statsTable     = "file_stats"
+)
+
+// documentRow is one exported index document.
+type documentRow struct {
+	Name         string    `bigquery:"name"`
+	BaseCPE      string    `bigquery:"base_cpe"`
+	Version      string    `bigquery:"version"`
+	Commit       string    `bigquery:"commit"`
+	When         time.Time `bigquery:"when"`
+	RepoType     string    `bigquery:"repo_type"`
+	Profile      string    `bigquery:"profile"`
+	FileHashType string    `bigquery:"file_hash_type"`
+	FileCount    int       `bigquery:"file_count"`
+}
+
+// statsRow is the per-extension file count of one document, the grain
+// analytical queries group by.
+type statsRow struct {
+	Name      string `bigquery:"name"`
+	Version   string `bigquery:"version"`
+	Commit    string `bigquery:"commit"`
+	Extension string `bigquery:"extension"`
+	Files     int    `bigquery:"files"`
+}
+
+// Store decorates a storage.Store, streaming every stored document and
+// its file stats to BigQuery as well.
+type Store struct {
+	storage.Store
+
+	client *bigquery.Client
+	docs   *bigquery.Inserter
+	stats  *bigquery.Inserter
+}
+
+// Wrap returns a Store writing through to inner and exporting to the
+// given BigQuery dataset, creating the tables if needed.
+func Wrap(ctx context.Context, inner storage.Store, projectID, dataset string) (*Store, error) {
+	client, err := bigquery.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	ds := client.Dataset(dataset)
+	if err := ensureTable(ctx, ds.Table(documentsTable), documentRow{}); err != nil {
+		client.Close()
+		return nil, err
+	}
+	if err := ensureTable(ctx, ds.Table(statsTable), statsRow{}); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &Store{
+		Store:  inner,
+		client: client,
+		docs:   ds.Table(documentsTable).Inserter(),
+		stats:  ds.Table(statsTable).Inserter(),
+	}, nil
+}
+
+// ensureTable creates the table with a schema inferred from row,
+// tolerating it already existing.
+func ensureTable(ctx context.Context, table *bigquery.Table, row interface{}) error {
+	schema, err := bigquery.InferSchema(row)
+	if err != nil {
+		return err
+	}
+	err = table.Create(ctx, &bigquery.TableMetadata{Schema: schema})
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == 409 {
+		return nil
+	}
+
+	return err
+}
+
+// Put stores the document and exports it. Export failures are returned
+// after the primary write so the document is never lost to an
+// analytics-side outage.
+func (s *Store) Put(ctx context.Context, doc processing.Document) error {
+	if err := s.Store.Put(ctx, doc); err != nil {
+		return err
+	}
+	row := &documentRow{
+		Name:         doc.Name,
+		BaseCPE:      doc.BaseCPE,
+		Version:      doc.Version,
+		Commit:       doc.CommitTag,
+		When:         doc.When,
+		RepoType:     doc.RepoType,
+		Profile:      doc.Profile,
+		FileHashType: doc.FileHashType,
+		FileCount:    doc.FileCount,
+	}
+	if err := s.docs.Put(ctx, row); err != nil {
+		return err
+	}
+
+	byExt := make(map[string]int)
+	for _, result := range doc.FileResults {
+		byExt[filepath.Ext(result.Path)]++
+	}
+	rows := make([]*statsRow, 0, len(byExt))
+	for ext, files := range byExt {
+		rows = append(rows, &statsRow{
+			Name:      doc.Name,
+			Version:   doc.Version,
+			Commit:    doc.CommitTag,
+			Extension: ext,
+			Files:     files,
+		})
+	}
+
+	return s.stats.Put(ctx, rows)
+}
+
+// Close releases the BigQuery client and the wrapped store.
+func (s *Store) Close() error {
+	err := s.client.Close()
+	if inner := s.Store.Close(); inner != nil && err == nil {
+		err = inner
+	}
+
+	return err
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preparation clones the configured repositories and produces one
+// checkout per version for the processing stage.
+package preparation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/google/osv/docker/indexer/shared"
+	"github.com/google/osv/docker/indexer/stages/processing"
+	"github.com/google/osv/docker/indexer/storage"
+)
+
+// Result is the stream of checkouts produced by Prepare.
+type Result struct {
+	Checkout shared.RepoCheckout
+	Err      error
+}
+
+// ExistsFunc reports, per key, whether an index document is already
+// stored. It is typically storage.Store.ExistsMulti; Prepare skips the
+// checkouts it reports as stored.
+type ExistsFunc func(ctx context.Context, keys []storage.Key) ([]bool, error)
+
+// Prepare fetches entry's repository into workDir and emits one checkout
+// per tag to out, skipping tags exists reports as already indexed (a nil
+// exists indexes everything). Tags are emitted in sorted order, and a
+// non-empty resumeAfter — the tag a previous run's checkpoint recorded —
+// drops every tag up to and including it. The same working tree
+// directory is reused; the processing stage must consume each checkout
+// before the next is emitted.
+func Prepare(ctx context.Context, entry shared.RepoEntry, workDir string, exists ExistsFunc, resumeAfter string, out chan<- Result) {
+	repoDir := filepath.Join(workDir, entry.Name)
+	upstream, err := newVCS(entry, repoDir)
+	if err != nil {
+		out <- Result{Err: err}
+		return
+	}
+	if err := upstream.fetch(ctx); err != nil {
+		out <- Result{Err: fmt.Errorf("failed to fetch %s: %w", entry.RepoURL, err)}
+		return
+	}
+	defer os.RemoveAll(repoDir)
+
+	tags, err := upstream.versions(ctx)
+	if err != nil {
+		out <- Result{Err: err}
+		return
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].version < tags[j].version })
+	tags = resumeFrom(tags, resumeAfter)
+	tags, err = filterIndexed(ctx, entry, tags, exists)
+	if err != nil {
+		// A failed lookup only costs re-indexing work, so fall through
+		// with the full tag list.
+		out <- Result{Err: fmt.Errorf("existence check for %s failed: %w", entry.Name, err)}
+	}
+
+	for _, tag := range tags {
+		if err := ctx.Err(); err != nil {
+			out <- Result{Err: err}
+			return
+		}
+		dir, err := upstream.checkout(ctx, tag)
+		if err != nil {
+			out <- Result{Err: fmt.Errorf("checkout of %s@%s failed: %w", entry.Name, tag.version, err)}
+			continue
+		}
+		out <- Result{Checkout: shared.RepoCheckout{
+			Entry:     entry,
+			Version:   tag.version,
+			CommitTag: tag.revision,
+			When:      tag.when,
+			Dir:       dir,
+		}}
+	}
+}
+
+// resumeFrom drops the tags a previous run already processed: everything
+// up to and including resumeAfter in the sorted tag list. If resumeAfter
+// no longer exists (e.g. the tag was deleted upstream), its sort position
+// is still a valid resume point.
+func resumeFrom(tags []tagged, resumeAfter string) []tagged {
+	if resumeAfter == "" {
+		return tags
+	}
+	i := sort.Search(len(tags), func(i int) bool {
+		return tags[i].version > resumeAfter
+	})
+
+	return tags[i:]
+}
+
+// filterIndexed returns the tags exists doesn't already have all
+// documents for, in one batched lookup. On lookup failure it returns the
+// full tag list alongside the error.
+func filterIndexed(ctx context.Context, entry shared.RepoEntry, tags []tagged, exists ExistsFunc) ([]tagged, error) {
+	if exists == nil || len(tags) == 0 {
+		return tags, nil
+	}
+	// A tag can produce several documents (one per matching alias and
+	// hash type); it is only skipped when all of them are already stored.
+	keys, spans := documentKeys(entry, tags)
+	found, err := exists(ctx, keys)
+	if err != nil {
+		return tags, err
+	}
+	remaining := tags[:0]
+	for i, tag := range tags {
+		start, end := spans[i][0], spans[i][1]
+		indexed := start < end
+		for _, ok := range found[start:end] {
+			indexed = indexed && ok
+		}
+		if !indexed {
+			remaining = append(remaining, tag)
+		}
+	}
+
+	return remaining, nil
+}
+
+// documentKeys returns the storage keys of every document the given tags
+// produce, plus each tag's [start, end) span in the key slice.
+func documentKeys(entry shared.RepoEntry, tags []tagged) ([]storage.Key, [][2]int) {
+	var keys []storage.Key
+	spans := make([][2]int, len(tags))
+	for i, tag := range tags {
+		start := len(keys)
+		for _, id := range processing.DocumentIDs(entry, tag.version) {
+			for _, hashType := range entry.AllHashTypes() {
+				keys = append(keys, storage.Key{
+					Name:     id.Alias.Name,
+					Version:  id.Version,
+					Commit:   tag.revision,
+					HashType: hashType,
+				})
+			}
+		}
+		spans[i] = [2]int{start, len(keys)}
+	}
+
+	return keys, spans
+}
+
+// LiveKeys lists the storage keys of every document entry's current
+// upstream versions produce, without checking anything out. Garbage
+// collection uses it to find documents whose tag was deleted or
+// force-pushed away.
+func LiveKeys(ctx context.Context, entry shared.RepoEntry, workDir string) ([]storage.Key, error) {
+	if entry.RepoType() == shared.TypeTarball {
+		tags := make([]tagged, len(entry.Archives))
+		for i, archive := range entry.Archives {
+			tags[i] = tagged{version: archive.Version}
+		}
+		keys, _ := documentKeys(entry, tags)
+
+		return keys, nil
+	}
+
+	repoDir := filepath.Join(workDir, entry.Name)
+	upstream, err := newVCS(entry, repoDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := upstream.fetch(ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", entry.RepoURL, err)
+	}
+	defer os.RemoveAll(repoDir)
+	tags, err := upstream.versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, _ := documentKeys(entry, tags)
+
+	return keys, nil
+}
+
+// PrepareTag clones entry's repository and produces the checkout of a
+// single tag, for incremental indexing of new-tag events. Only git
+// entries publish tag events. The caller owns the returned checkout's
+// directory and must remove it.
+func PrepareTag(ctx context.Context, entry shared.RepoEntry, workDir, tag string) (shared.RepoCheckout, error) {
+	if entry.RepoType() != shared.TypeGit {
+		return shared.RepoCheckout{}, fmt.Errorf("entry %q is not a git repository", entry.Name)
+	}
+	repoDir := filepath.Join(workDir, entry.Name)
+	repo, err := git.PlainCloneContext(ctx, repoDir, false, &git.CloneOptions{
+		URL:        entry.RepoURL,
+		NoCheckout: true,
+	})
+	if err != nil {
+		return shared.RepoCheckout{}, fmt.Errorf("failed to clone %s: %w", entry.RepoURL, err)
+	}
+	ref, err := repo.Tag(tag)
+	if err != nil {
+		os.RemoveAll(repoDir)
+		return shared.RepoCheckout{}, fmt.Errorf("tag %s not found in %s: %w", tag, entry.RepoURL, err)
+	}
+	commit, err := resolveCommit(repo, ref)
+	if err != nil {
+		os.RemoveAll(repoDir)
+		return shared.RepoCheckout{}, err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		os.RemoveAll(repoDir)
+		return shared.RepoCheckout{}, err
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{Hash: commit.Hash, Force: true}); err != nil {
+		os.RemoveAll(repoDir)
+		return shared.RepoCheckout{}, err
+	}
+
+	return shared.RepoCheckout{
+		Entry:     entry,
+		Version:   tag,
+		CommitTag: commit.Hash.String(),
+		When:      commit.Committer.When,
+		Dir:       repoDir,
+	}, nil
+}
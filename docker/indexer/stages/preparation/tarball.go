@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparation
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/osv/docker/indexer/shared"
+)
+
+// PrepareArchives downloads and unpacks entry's release archives and
+// emits one checkout per archive to out, skipping archives exists
+// reports as already indexed. Release tarballs often contain generated
+// files absent from the git tags, so tarball-type entries index the
+// shipped sources instead of a clone.
+func PrepareArchives(ctx context.Context, entry shared.RepoEntry, workDir string, exists ExistsFunc, out chan<- Result) {
+	archives, err := filterIndexedArchives(ctx, entry, entry.Archives, exists)
+	if err != nil {
+		// As in Prepare, a failed lookup only costs re-indexing work.
+		out <- Result{Err: fmt.Errorf("existence check for %s failed: %w", entry.Name, err)}
+	}
+
+	for _, archive := range archives {
+		if err := ctx.Err(); err != nil {
+			out <- Result{Err: err}
+			return
+		}
+		dir := filepath.Join(workDir, entry.Name+"-"+archive.Version)
+		root, when, err := fetchArchive(ctx, archive.URL, dir)
+		if err != nil {
+			out <- Result{Err: fmt.Errorf("failed to fetch %s: %w", archive.URL, err)}
+			continue
+		}
+		out <- Result{Checkout: shared.RepoCheckout{
+			Entry:   entry,
+			Version: archive.Version,
+			When:    when,
+			Dir:     root,
+		}}
+	}
+}
+
+// filterIndexedArchives returns the archives exists doesn't already have
+// all documents for, mirroring filterIndexed for git tags. Archive
+// documents have no commit.
+func filterIndexedArchives(ctx context.Context, entry shared.RepoEntry, archives []shared.Archive, exists ExistsFunc) ([]shared.Archive, error) {
+	tags := make([]tagged, len(archives))
+	for i, archive := range archives {
+		tags[i] = tagged{version: archive.Version}
+	}
+	tags, err := filterIndexed(ctx, entry, tags, exists)
+	if err != nil {
+		return archives, err
+	}
+	byVersion := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		byVersion[tag.version] = true
+	}
+	remaining := archives[:0]
+	for _, archive := range archives {
+		if byVersion[archive.Version] {
+			remaining = append(remaining, archive)
+		}
+	}
+
+	return remaining, nil
+}
+
+// fetchArchive downloads and unpacks the archive at url into dir,
+// returning the directory to hash and the archive's modification time
+// (zero if the server doesn't report one).
+func fetchArchive(ctx context.Context, url, dir string) (string, time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("server returned %s", resp.Status)
+	}
+	var when time.Time
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		when = modified
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", time.Time{}, err
+	}
+	switch {
+	case strings.HasSuffix(url, ".tar.gz"), strings.HasSuffix(url, ".tgz"):
+		err = untar(resp.Body, dir)
+	case strings.HasSuffix(url, ".zip"):
+		err = unzip(resp.Body, dir)
+	default:
+		err = fmt.Errorf("unsupported archive format in %s", url)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", time.Time{}, err
+	}
+
+	return archiveRoot(dir), when, nil
+}
+
+// archiveRoot descends into dir's single top-level directory if the
+// archive wraps its contents in one, as most release tarballs do, so
+// hashed paths are relative to the source root.
+func archiveRoot(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// sanitizePath resolves name inside dir, rejecting absolute names and
+// path traversal.
+func sanitizePath(dir, name string) (string, error) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %q escapes extraction directory", name)
+	}
+
+	return path, nil
+}
+
+// untar extracts a gzipped tarball into dir.
+func untar(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path, err := sanitizePath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(path, tr); err != nil {
+				return err
+			}
+		default:
+			// Skip links and special files; they carry no hashable
+			// content.
+		}
+	}
+}
+
+// unzip extracts a zip archive into dir. The zip format needs random
+// access, so the archive is spooled to a temporary file first.
+func unzip(r io.Reader, dir string) error {
+	tmp, err := os.CreateTemp("", "indexer-zip-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return err
+	}
+	for _, file := range zr.File {
+		path, err := sanitizePath(dir, file.Name)
+		if err != nil {
+			return err
+		}
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		src, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(path, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes one extracted file, creating parent directories.
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
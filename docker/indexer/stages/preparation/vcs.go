@@ -0,0 +1,296 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/google/osv/docker/indexer/shared"
+)
+
+// tagged is one upstream version: its name, the revision it points at,
+// and when that revision was created (zero if the VCS doesn't report
+// it).
+type tagged struct {
+	version  string
+	revision string
+	when     time.Time
+}
+
+// vcs abstracts access to an upstream repository so preparation can
+// handle Mercurial and Subversion upstreams alongside git.
+type vcs interface {
+	// fetch makes the repository available locally.
+	fetch(ctx context.Context) error
+	// versions lists the released versions.
+	versions(ctx context.Context) ([]tagged, error)
+	// checkout makes tag's tree available and returns its directory.
+	checkout(ctx context.Context, tag tagged) (string, error)
+}
+
+// newVCS returns the VCS implementation for entry, rooted at dir.
+func newVCS(entry shared.RepoEntry, dir string) (vcs, error) {
+	switch entry.RepoType() {
+	case shared.TypeGit:
+		return &gitVCS{url: entry.RepoURL, dir: dir, exts: entry.FileExtensions()}, nil
+	case shared.TypeHg:
+		return &hgVCS{url: entry.RepoURL, dir: dir}, nil
+	case shared.TypeSvn:
+		return &svnVCS{url: entry.RepoURL, dir: dir}, nil
+	default:
+		return nil, fmt.Errorf("unsupported repository type %q", entry.Type)
+	}
+}
+
+// gitVCS accesses git upstreams through go-git. The clone is bare and
+// checkouts are tree-only: each tag's files are materialized straight
+// from the object store, restricted to the entry's hashed extensions, so
+// large repositories never pay for a full worktree per tag.
+type gitVCS struct {
+	url  string
+	dir  string
+	exts []string
+
+	repo *git.Repository
+}
+
+func (g *gitVCS) fetch(ctx context.Context) error {
+	repo, err := git.PlainCloneContext(ctx, filepath.Join(g.dir, "repo.git"), true, &git.CloneOptions{
+		URL: g.url,
+	})
+	if err != nil {
+		return err
+	}
+	g.repo = repo
+
+	return nil
+}
+
+// versions resolves all tags to commits, skipping tags that don't
+// resolve.
+func (g *gitVCS) versions(ctx context.Context) ([]tagged, error) {
+	iter, err := g.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var tags []tagged
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		commit, err := resolveCommit(g.repo, ref)
+		if err != nil {
+			return nil // Skip unresolvable tags.
+		}
+		tags = append(tags, tagged{
+			version:  ref.Name().Short(),
+			revision: commit.Hash.String(),
+			when:     commit.Committer.When,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// checkout writes tag's tree into a scratch directory, skipping files
+// processing wouldn't hash. The scratch directory is reused across tags.
+func (g *gitVCS) checkout(ctx context.Context, tag tagged) (string, error) {
+	commit, err := g.repo.CommitObject(plumbing.NewHash(tag.revision))
+	if err != nil {
+		return "", err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", err
+	}
+
+	scratch := filepath.Join(g.dir, "tree")
+	if err := os.RemoveAll(scratch); err != nil {
+		return "", err
+	}
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(g.exts) > 0 && !hasExt(f.Name, g.exts) {
+			return nil
+		}
+		path, err := sanitizePath(scratch, f.Name)
+		if err != nil {
+			return err
+		}
+		src, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		err = writeFile(path, src)
+		src.Close()
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	// A tag whose tree has no hashable files still needs a directory to
+	// walk.
+	if err := os.MkdirAll(scratch, 0755); err != nil {
+		return "", err
+	}
+
+	return scratch, nil
+}
+
+// hasExt reports whether path has one of the given extensions.
+func hasExt(path string, exts []string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveCommit resolves a (possibly annotated) tag ref to its commit.
+func resolveCommit(repo *git.Repository, ref *plumbing.Reference) (*object.Commit, error) {
+	if tag, err := repo.TagObject(ref.Hash()); err == nil {
+		return tag.Commit()
+	}
+
+	return repo.CommitObject(ref.Hash())
+}
+
+// hgVCS accesses Mercurial upstreams through the hg command-line client.
+type hgVCS struct {
+	url string
+	dir string
+}
+
+func (h *hgVCS) fetch(ctx context.Context) error {
+	_, err := runVCS(ctx, "", "hg", "clone", "--noupdate", h.url, h.dir)
+
+	return err
+}
+
+func (h *hgVCS) versions(ctx context.Context) ([]tagged, error) {
+	// One line per tagged revision: node, unix timestamp and the tags
+	// pointing at it.
+	out, err := runVCS(ctx, h.dir, "hg", "log", "--rev", "tag()",
+		"--template", `{node} {date|hgdate} {tags}\n`)
+	if err != nil {
+		return nil, err
+	}
+	var tags []tagged
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		// hgdate is "unixtime offset", so fields are: node, unixtime,
+		// offset, tag...
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		var when time.Time
+		if secs, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			when = time.Unix(secs, 0).UTC()
+		}
+		for _, tag := range fields[3:] {
+			if tag == "tip" {
+				continue
+			}
+			tags = append(tags, tagged{version: tag, revision: fields[0], when: when})
+		}
+	}
+
+	return tags, nil
+}
+
+func (h *hgVCS) checkout(ctx context.Context, tag tagged) (string, error) {
+	if _, err := runVCS(ctx, h.dir, "hg", "update", "--clean", "--rev", tag.revision); err != nil {
+		return "", err
+	}
+
+	return h.dir, nil
+}
+
+// svnVCS accesses Subversion upstreams through the svn command-line
+// client, using the conventional tags/ directory.
+type svnVCS struct {
+	url string
+	dir string
+}
+
+// fetch is a no-op: Subversion has no local clone, tags are listed and
+// exported straight from the server.
+func (s *svnVCS) fetch(context.Context) error {
+	return nil
+}
+
+func (s *svnVCS) versions(ctx context.Context) ([]tagged, error) {
+	out, err := runVCS(ctx, "", "svn", "list", s.url+"/tags")
+	if err != nil {
+		return nil, err
+	}
+	var tags []tagged
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		name := strings.TrimSuffix(strings.TrimSpace(line), "/")
+		if name == "" {
+			continue
+		}
+		tags = append(tags, tagged{version: name})
+	}
+
+	return tags, nil
+}
+
+func (s *svnVCS) checkout(ctx context.Context, tag tagged) (string, error) {
+	_, err := runVCS(ctx, "", "svn", "export", "--force", s.url+"/tags/"+tag.version, s.dir)
+	if err != nil {
+		return "", err
+	}
+
+	return s.dir, nil
+}
+
+// runVCS runs a VCS command-line client, returning its stdout and a
+// stderr-carrying error on failure.
+func runVCS(ctx context.Context, dir, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package processing hashes the source files of a checkout and produces
+// the index documents to store, one per matching repository alias.
+package processing
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/osv/go/versionscheme"
+
+	"github.com/google/osv/docker/indexer/shared"
+)
+
+// Document is one index document for a (checkout, alias) pair.
+type Document struct {
+	// Name is the product name of the alias that matched.
+	Name string
+	// BaseCPE is the CPE prefix of the alias that matched.
+	BaseCPE string
+	// Version is the checkout's version with the alias's version prefix
+	// stripped.
+	Version   string
+	CommitTag string
+	When      time.Time
+	// Profile is the extension profile the checkout was hashed with, if
+	// any, so matching can be restricted to the same language surface.
+	Profile string
+	// RepoType records whether the document came from a git checkout or
+	// a release tarball.
+	RepoType     string
+	FileCount    int
+	FileHashType string
+	FileResults  []shared.FileResult
+}
+
+// DocumentID identifies the document Process emits for one alias: the
+// alias itself and the normalized version the document is stored under.
+type DocumentID struct {
+	Alias   shared.NameAlias
+	Version string
+}
+
+// DocumentIDs returns the identities of the documents Process would emit
+// for a checkout of version, letting callers check for existing documents
+// before paying for a checkout and hashing pass.
+func DocumentIDs(entry shared.RepoEntry, version string) []DocumentID {
+	var overrides []versionscheme.Override
+	for _, o := range entry.VersionOverrides {
+		overrides = append(overrides, versionscheme.Override{
+			Prefix: o.Prefix,
+			Scheme: versionscheme.ParseScheme(o.Scheme),
+		})
+	}
+
+	var ids []DocumentID
+	for _, alias := range entry.AllAliases() {
+		aliased, ok := aliasVersion(alias, version)
+		if !ok {
+			continue
+		}
+		// Store the normalized form so determineversion results are
+		// consistent across tagging styles.
+		aliased, _ = versionscheme.NormalizeWith(aliased, overrides)
+		ids = append(ids, DocumentID{Alias: alias, Version: aliased})
+	}
+
+	return ids
+}
+
+// Process hashes the checkout's files once and emits one document per
+// (matching alias, configured hash type) pair. Repositories shipping
+// several products therefore get separate, correctly named documents
+// from a single hashing pass.
+func Process(ctx context.Context, checkout shared.RepoCheckout) ([]Document, error) {
+	var docs []Document
+	var results map[string][]shared.FileResult
+	hashed := false
+
+	hashTypes := checkout.Entry.AllHashTypes()
+	for _, id := range DocumentIDs(checkout.Entry, checkout.Version) {
+		if !hashed {
+			var err error
+			results, err = hashDir(ctx, checkout.Dir, checkout.Entry.FileExtensions(), hashTypes)
+			if err != nil {
+				return nil, err
+			}
+			hashed = true
+		}
+		for _, hashType := range hashTypes {
+			docs = append(docs, Document{
+				Name:         id.Alias.Name,
+				BaseCPE:      id.Alias.BaseCPE,
+				Version:      id.Version,
+				CommitTag:    checkout.CommitTag,
+				When:         checkout.When,
+				Profile:      checkout.Entry.Profile,
+				RepoType:     checkout.Entry.RepoType(),
+				FileCount:    len(results[hashType]),
+				FileHashType: hashType,
+				FileResults:  results[hashType],
+			})
+		}
+	}
+
+	return docs, nil
+}
+
+// aliasVersion reports whether version belongs to alias and returns it
+// with the alias's prefix stripped. Aliases without a version prefix match
+// every version.
+func aliasVersion(alias shared.NameAlias, version string) (string, bool) {
+	if alias.VersionPrefix == "" {
+		return version, true
+	}
+	if !strings.HasPrefix(version, alias.VersionPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(version, alias.VersionPrefix), true
+}
+
+// hashFile computes one hash of data.
+func hashFile(hashType string, data []byte) ([]byte, error) {
+	switch hashType {
+	case shared.MD5:
+		h := md5.Sum(data)
+		return h[:], nil
+	case shared.SHA1:
+		h := sha1.Sum(data)
+		return h[:], nil
+	case shared.SHA256:
+		h := sha256.Sum256(data)
+		return h[:], nil
+	default:
+		return nil, fmt.Errorf("unsupported hash type %q", hashType)
+	}
+}
+
+// hashDir hashes every file under dir with one of the given extensions,
+// computing all requested hash types in a single read pass. Passing no
+// extensions hashes every file.
+func hashDir(ctx context.Context, dir string, exts []string, hashTypes []string) (map[string][]shared.FileResult, error) {
+	results := make(map[string][]shared.FileResult, len(hashTypes))
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if len(exts) > 0 && !matchesExt(path, exts) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		for _, hashType := range hashTypes {
+			hash, err := hashFile(hashType, data)
+			if err != nil {
+				return err
+			}
+			results[hashType] = append(results[hashType], shared.FileResult{Path: rel, Hash: hash})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func matchesExt(path string, exts []string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processing
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/osv/docker/indexer/shared"
+)
+
+func TestAliasVersion(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		version string
+		want    string
+		wantOK  bool
+	}{
+		// Aliases without a prefix match every version unchanged.
+		{"", "v1.2.3", "v1.2.3", true},
+		{"core-", "core-1.0", "1.0", true},
+		{"core-", "other-1.0", "", false},
+		{"core-", "core-", "", true},
+	}
+	for _, tt := range tests {
+		alias := shared.NameAlias{Name: "core", VersionPrefix: tt.prefix}
+		got, ok := aliasVersion(alias, tt.version)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("aliasVersion(prefix %q, %q) = (%q, %t), want (%q, %t)",
+				tt.prefix, tt.version, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDocumentIDs(t *testing.T) {
+	entry := shared.RepoEntry{
+		Name:    "protobuf",
+		BaseCPE: "cpe:/a:google:protobuf",
+		Aliases: []shared.NameAlias{{
+			Name:          "protobuf-java",
+			BaseCPE:       "cpe:/a:google:protobuf-java",
+			VersionPrefix: "java-v",
+		}},
+	}
+
+	// A plain tag matches only the implicit primary alias, and the
+	// version is stored in normalized (un-prefixed) semver form.
+	ids := DocumentIDs(entry, "v3.21.0")
+	if len(ids) != 1 {
+		t.Fatalf("DocumentIDs(v3.21.0) = %+v, want one ID", ids)
+	}
+	if ids[0].Alias.Name != "protobuf" || ids[0].Version != "3.21.0" {
+		t.Errorf("DocumentIDs(v3.21.0) = %+v, want protobuf 3.21.0", ids[0])
+	}
+
+	// A prefixed tag matches the prefixed alias with the prefix
+	// stripped; the primary alias has no prefix and matches too.
+	ids = DocumentIDs(entry, "java-v3.21.0")
+	if len(ids) != 2 {
+		t.Fatalf("DocumentIDs(java-v3.21.0) = %+v, want two IDs", ids)
+	}
+	if ids[1].Alias.Name != "protobuf-java" || ids[1].Version != "3.21.0" {
+		t.Errorf("DocumentIDs(java-v3.21.0)[1] = %+v, want protobuf-java 3.21.0", ids[1])
+	}
+}
+
+func TestDocumentIDsVersionOverride(t *testing.T) {
+	entry := shared.RepoEntry{
+		Name: "libfoo",
+		VersionOverrides: []shared.VersionOverride{
+			{Prefix: "REL_", Scheme: "release-string"},
+		},
+	}
+	ids := DocumentIDs(entry, "REL_1_2_3")
+	if len(ids) != 1 || ids[0].Version != "1.2.3" {
+		t.Fatalf("DocumentIDs(REL_1_2_3) = %+v, want version 1.2.3", ids)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.c":         "int main() {}\n",
+		"sub/b.h":     "#define B 1\n",
+		"README.md":   "docs\n",
+		".git/config": "[core]\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	when := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	checkout := shared.RepoCheckout{
+		Entry: shared.RepoEntry{
+			Name:      "libfoo",
+			BaseCPE:   "cpe:/a:foo:libfoo",
+			FileExts:  []string{".c", ".h"},
+			HashTypes: []string{shared.MD5, shared.SHA256},
+		},
+		Version:   "v1.0.0",
+		CommitTag: "abc123",
+		When:      when,
+		Dir:       dir,
+	}
+	docs, err := Process(context.Background(), checkout)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	// One alias and two hash types: two documents from one hashing pass.
+	if len(docs) != 2 {
+		t.Fatalf("Process produced %d documents, want 2", len(docs))
+	}
+	for _, doc := range docs {
+		if doc.Name != "libfoo" || doc.Version != "1.0.0" || doc.CommitTag != "abc123" {
+			t.Errorf("document identity = %s %s %s, want libfoo 1.0.0 abc123",
+				doc.Name, doc.Version, doc.CommitTag)
+		}
+		if doc.RepoType != shared.TypeGit {
+			t.Errorf("RepoType = %q, want git", doc.RepoType)
+		}
+		// README.md and .git/config are excluded by extension and by the
+		// .git skip respectively.
+		if doc.FileCount != 2 || len(doc.FileResults) != 2 {
+			t.Errorf("%s document hashed %d files, want 2: %+v",
+				doc.FileHashType, doc.FileCount, doc.FileResults)
+		}
+	}
+	if docs[0].FileHashType != shared.MD5 || docs[1].FileHashType != shared.SHA256 {
+		t.Fatalf("hash types = %s, %s, want MD5 then SHA-256",
+			docs[0].FileHashType, docs[1].FileHashType)
+	}
+
+	// Paths are relative to the checkout and walked in lexical order.
+	md5Results := docs[0].FileResults
+	if md5Results[0].Path != "a.c" || md5Results[1].Path != filepath.Join("sub", "b.h") {
+		t.Errorf("paths = %s, %s, want a.c and sub/b.h", md5Results[0].Path, md5Results[1].Path)
+	}
+	want := md5.Sum([]byte(files["a.c"]))
+	if !bytes.Equal(md5Results[0].Hash, want[:]) {
+		t.Errorf("a.c MD5 = %x, want %x", md5Results[0].Hash, want)
+	}
+}
@@ -0,0 +1,521 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+
+	"github.com/google/osv/docker/indexer/shared"
+	"github.com/google/osv/docker/indexer/stages/processing"
+)
+
+const (
+	// repoIndexKind is the entity kind of one (repo alias, version) index
+	// document.
+	repoIndexKind = "RepoIndex"
+	// fileResultsKind is the entity kind of a batch of file hashes
+	// belonging to a RepoIndex entity.
+	fileResultsKind = "RepoIndexFileResults"
+	// checkpointKind is the entity kind of one repository's indexing
+	// checkpoint.
+	checkpointKind = "RepoCheckpoint"
+	// deadLetterKind is the entity kind of one repository's dead-letter
+	// record.
+	deadLetterKind = "RepoDeadLetter"
+
+	// resultBatchSize is the maximum number of file hashes stored per
+	// RepoIndexFileResults entity.
+	resultBatchSize = 1000
+	// maxBatchBytes bounds a batch's payload; the Datastore entity limit
+	// is 1MiB.
+	maxBatchBytes = 900 << 10
+
+	// Datastore allows 500 entities and 10MiB per transaction; stay
+	// comfortably below both.
+	maxTxEntities = 400
+	maxTxBytes    = 8 << 20
+	// putParallelism bounds the concurrent batch transactions per Put.
+	putParallelism = 8
+)
+
+// repoIndex is the Datastore representation of a processing.Document.
+type repoIndex struct {
+	Name         string    `datastore:"name"`
+	BaseCPE      string    `datastore:"base_cpe"`
+	Version      string    `datastore:"version"`
+	Commit       string    `datastore:"commit"`
+	When         time.Time `datastore:"when"`
+	Profile      string    `datastore:"profile"`
+	RepoType     string    `datastore:"repo_type"`
+	FileCount    int       `datastore:"file_count"`
+	FileHashType string    `datastore:"file_hash_type"`
+	// Batches lists the content-addressed fileResults entities holding
+	// the document's file hashes, in order.
+	Batches []string `datastore:"batches,noindex"`
+}
+
+// fileResults is one stored batch of file hashes. Batches are
+// content-addressed — keyed by the SHA-256 of their contents — so
+// consecutive tags, which share most of their files, share the stored
+// batches instead of re-writing them per commit.
+type fileResults struct {
+	FileResults []shared.FileResult `datastore:"file_results,noindex"`
+}
+
+// batchName returns the content address of one batch.
+func batchName(results []shared.FileResult) string {
+	h := sha256.New()
+	for _, r := range results {
+		io.WriteString(h, r.Path)
+		h.Write([]byte{0})
+		h.Write(r.Hash)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkpoint is the Datastore representation of a storage.Checkpoint.
+type checkpoint struct {
+	Name   string    `datastore:"name"`
+	Tag    string    `datastore:"tag"`
+	Commit string    `datastore:"commit"`
+	Stage  string    `datastore:"stage"`
+	When   time.Time `datastore:"when"`
+}
+
+// deadLetter is the Datastore representation of a storage.DeadLetter.
+type deadLetter struct {
+	Name     string    `datastore:"name"`
+	Reason   string    `datastore:"reason,noindex"`
+	Attempts int       `datastore:"attempts"`
+	When     time.Time `datastore:"when"`
+}
+
+// datastoreStore writes index documents and their file hashes to
+// Datastore.
+type datastoreStore struct {
+	client *datastore.Client
+
+	// mu guards exists and batches, which cache lookup results —
+	// including negative ones, which stay valid because this process is
+	// the only writer and Put updates the caches.
+	mu     sync.Mutex
+	exists map[string]bool
+	// batches caches which content-addressed batch entities are known to
+	// be stored.
+	batches map[string]bool
+}
+
+// newDatastore returns a store backed by the given project's Datastore.
+func newDatastore(ctx context.Context, projectID string) (*datastoreStore, error) {
+	client, err := datastore.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastoreStore{
+		client:  client,
+		exists:  make(map[string]bool),
+		batches: make(map[string]bool),
+	}, nil
+}
+
+func (s *datastoreStore) Close() error {
+	return s.client.Close()
+}
+
+// getMultiBatchSize is the maximum number of keys per GetMulti call.
+const getMultiBatchSize = 1000
+
+// Exists reports whether key's document is already stored.
+func (s *datastoreStore) Exists(ctx context.Context, key Key) (bool, error) {
+	found, err := s.ExistsMulti(ctx, []Key{key})
+	if err != nil {
+		return false, err
+	}
+
+	return found[0], nil
+}
+
+// ExistsMulti reports, per key, whether a document is already stored. It
+// issues one GetMulti per getMultiBatchSize keys instead of one Get per
+// key, and serves repeated lookups from the cache.
+func (s *datastoreStore) ExistsMulti(ctx context.Context, keys []Key) ([]bool, error) {
+	found := make([]bool, len(keys))
+
+	// Collect the keys the cache can't answer.
+	s.mu.Lock()
+	var lookup []*datastore.Key
+	var lookupIdx []int
+	for i, key := range keys {
+		if cached, ok := s.exists[key.name()]; ok {
+			found[i] = cached
+			continue
+		}
+		lookup = append(lookup, datastore.NameKey(repoIndexKind, key.name(), nil))
+		lookupIdx = append(lookupIdx, i)
+	}
+	s.mu.Unlock()
+
+	for start := 0; start < len(lookup); start += getMultiBatchSize {
+		end := start + getMultiBatchSize
+		if end > len(lookup) {
+			end = len(lookup)
+		}
+		batch := lookup[start:end]
+		dst := make([]repoIndex, len(batch))
+		err := s.client.GetMulti(ctx, batch, dst)
+		var multi datastore.MultiError
+		switch {
+		case err == nil:
+			for i := range batch {
+				found[lookupIdx[start+i]] = true
+			}
+		case errors.As(err, &multi):
+			for i, err := range multi {
+				switch {
+				case err == nil:
+					found[lookupIdx[start+i]] = true
+				case errors.Is(err, datastore.ErrNoSuchEntity):
+					// Not stored yet.
+				default:
+					return nil, err
+				}
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	for i, key := range keys {
+		s.exists[key.name()] = found[i]
+	}
+	s.mu.Unlock()
+
+	return found, nil
+}
+
+// SaveCheckpoint records cp, replacing the repository's previous
+// checkpoint.
+func (s *datastoreStore) SaveCheckpoint(ctx context.Context, cp Checkpoint) error {
+	key := datastore.NameKey(checkpointKind, cp.Name, nil)
+	_, err := s.client.Put(ctx, key, &checkpoint{
+		Name:   cp.Name,
+		Tag:    cp.Tag,
+		Commit: cp.Commit,
+		Stage:  cp.Stage,
+		When:   cp.When,
+	})
+
+	return err
+}
+
+// LoadCheckpoint returns the repository's checkpoint, reporting whether
+// one exists.
+func (s *datastoreStore) LoadCheckpoint(ctx context.Context, name string) (Checkpoint, bool, error) {
+	var cp checkpoint
+	err := s.client.Get(ctx, datastore.NameKey(checkpointKind, name, nil), &cp)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return Checkpoint{}, false, nil
+	}
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+
+	return Checkpoint{
+		Name:   cp.Name,
+		Tag:    cp.Tag,
+		Commit: cp.Commit,
+		Stage:  cp.Stage,
+		When:   cp.When,
+	}, true, nil
+}
+
+// ClearCheckpoint removes the repository's checkpoint.
+func (s *datastoreStore) ClearCheckpoint(ctx context.Context, name string) error {
+	err := s.client.Delete(ctx, datastore.NameKey(checkpointKind, name, nil))
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil
+	}
+
+	return err
+}
+
+// CollectGarbage removes the documents of the given product names whose
+// keys are not in live, along with their file hash batches.
+func (s *datastoreStore) CollectGarbage(ctx context.Context, names []string, live []Key) (int, error) {
+	liveSet := make(map[string]bool, len(live))
+	for _, key := range live {
+		liveSet[key.name()] = true
+	}
+
+	removed := 0
+	for _, name := range names {
+		query := datastore.NewQuery(repoIndexKind).FilterField("name", "=", name).KeysOnly()
+		keys, err := s.client.GetAll(ctx, query, nil)
+		if err != nil {
+			return removed, err
+		}
+		for _, key := range keys {
+			if liveSet[key.Name] {
+				continue
+			}
+			// Only legacy per-document child batches are removed here;
+			// content-addressed batches are shared across commits and
+			// must outlive any single referencing document.
+			children, err := s.client.GetAll(ctx, datastore.NewQuery(fileResultsKind).Ancestor(key).KeysOnly(), nil)
+			if err != nil {
+				return removed, err
+			}
+			if err := s.client.DeleteMulti(ctx, children); err != nil {
+				return removed, err
+			}
+			if err := s.client.Delete(ctx, key); err != nil {
+				return removed, err
+			}
+			removed++
+			s.mu.Lock()
+			s.exists[key.Name] = false
+			s.mu.Unlock()
+		}
+	}
+
+	return removed, nil
+}
+
+// SaveDeadLetter records dl, replacing the repository's previous record.
+func (s *datastoreStore) SaveDeadLetter(ctx context.Context, dl DeadLetter) error {
+	key := datastore.NameKey(deadLetterKind, dl.Name, nil)
+	_, err := s.client.Put(ctx, key, &deadLetter{
+		Name:     dl.Name,
+		Reason:   dl.Reason,
+		Attempts: dl.Attempts,
+		When:     dl.When,
+	})
+
+	return err
+}
+
+// batchWrite is one pending content-addressed batch entity.
+type batchWrite struct {
+	name    string
+	results []shared.FileResult
+	size    int
+}
+
+// resultSize approximates one file result's stored size.
+func resultSize(r shared.FileResult) int {
+	return len(r.Path) + len(r.Hash) + 16
+}
+
+// splitResults chunks results into batches bounded by both entry count
+// and payload size, so a repository with a few huge paths can't produce
+// an over-limit entity.
+func splitResults(results []shared.FileResult) []batchWrite {
+	var batches []batchWrite
+	var chunk []shared.FileResult
+	size := 0
+	flush := func() {
+		if len(chunk) == 0 {
+			return
+		}
+		batches = append(batches, batchWrite{name: batchName(chunk), results: chunk, size: size})
+		chunk, size = nil, 0
+	}
+	for _, r := range results {
+		if len(chunk) >= resultBatchSize || size+resultSize(r) > maxBatchBytes {
+			flush()
+		}
+		chunk = append(chunk, r)
+		size += resultSize(r)
+	}
+	flush()
+
+	return batches
+}
+
+// writeBatches stores the given batch entities in parallel transactions,
+// each bounded by the Datastore entity-count and size limits.
+func (s *datastoreStore) writeBatches(ctx context.Context, writes []batchWrite) error {
+	var groups [][]batchWrite
+	var group []batchWrite
+	size := 0
+	for _, w := range writes {
+		if len(group) >= maxTxEntities || size+w.size > maxTxBytes {
+			groups = append(groups, group)
+			group, size = nil, 0
+		}
+		group = append(group, w)
+		size += w.size
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	sem := make(chan struct{}, putParallelism)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group []batchWrite) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+				for _, w := range group {
+					if _, err := tx.Put(datastore.NameKey(fileResultsKind, w.name, nil), &fileResults{FileResults: w.results}); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+			mu.Lock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+			} else {
+				s.mu.Lock()
+				for _, w := range group {
+					s.batches[w.name] = true
+				}
+				s.mu.Unlock()
+			}
+			mu.Unlock()
+		}(group)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// Put stores one document and its file hash batches. The document key is
+// derived from name, version and commit so re-indexing is idempotent,
+// and batches already stored under their content address are skipped
+// rather than re-written. Batches too numerous or large for a single
+// transaction are split into bounded transactions written in parallel;
+// the RepoIndex entity is the commit marker, written last, so readers
+// never see a document whose batches aren't all stored.
+func (s *datastoreStore) Put(ctx context.Context, doc processing.Document) error {
+	batches := splitResults(doc.FileResults)
+	names := make([]string, len(batches))
+	for i, b := range batches {
+		names[i] = b.name
+	}
+	missing, err := s.missingBatches(ctx, names)
+	if err != nil {
+		return err
+	}
+	var writes []batchWrite
+	for _, b := range batches {
+		if missing[b.name] {
+			writes = append(writes, b)
+		}
+	}
+	if err := s.writeBatches(ctx, writes); err != nil {
+		return err
+	}
+
+	key := Key{Name: doc.Name, Version: doc.Version, Commit: doc.CommitTag, HashType: doc.FileHashType}
+	indexKey := datastore.NameKey(repoIndexKind, key.name(), nil)
+	_, err = s.client.Put(ctx, indexKey, &repoIndex{
+		Name:         doc.Name,
+		BaseCPE:      doc.BaseCPE,
+		Version:      doc.Version,
+		Commit:       doc.CommitTag,
+		When:         doc.When,
+		Profile:      doc.Profile,
+		RepoType:     doc.RepoType,
+		FileCount:    doc.FileCount,
+		FileHashType: doc.FileHashType,
+		Batches:      names,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.exists[key.name()] = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+// missingBatches reports which of the named batch entities are not yet
+// stored, consulting the cache first and checking the rest with chunked
+// GetMulti calls.
+func (s *datastoreStore) missingBatches(ctx context.Context, names []string) (map[string]bool, error) {
+	missing := make(map[string]bool)
+	s.mu.Lock()
+	var lookup []*datastore.Key
+	var lookupNames []string
+	for _, name := range names {
+		if s.batches[name] {
+			continue
+		}
+		lookup = append(lookup, datastore.NameKey(fileResultsKind, name, nil))
+		lookupNames = append(lookupNames, name)
+	}
+	s.mu.Unlock()
+
+	for start := 0; start < len(lookup); start += getMultiBatchSize {
+		end := start + getMultiBatchSize
+		if end > len(lookup) {
+			end = len(lookup)
+		}
+		batch := lookup[start:end]
+		dst := make([]fileResults, len(batch))
+		err := s.client.GetMulti(ctx, batch, dst)
+		var multi datastore.MultiError
+		switch {
+		case err == nil:
+			s.mu.Lock()
+			for _, name := range lookupNames[start:end] {
+				s.batches[name] = true
+			}
+			s.mu.Unlock()
+		case errors.As(err, &multi):
+			for i, err := range multi {
+				name := lookupNames[start+i]
+				switch {
+				case err == nil:
+					s.mu.Lock()
+					s.batches[name] = true
+					s.mu.Unlock()
+				case errors.Is(err, datastore.ErrNoSuchEntity):
+					missing[name] = true
+				default:
+					return nil, err
+				}
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	return missing, nil
+}
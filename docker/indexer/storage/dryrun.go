@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/osv/docker/indexer/stages/processing"
+)
+
+// DryRun is a store that writes documents as JSON files instead of
+// storing them, for safely testing config or hashing changes. It reads
+// through to the wrapped store only to report which documents would be
+// new, and reports every version as unindexed so the whole pipeline
+// runs.
+type DryRun struct {
+	inner Store
+	dir   string
+
+	mu         sync.Mutex
+	newKeys    []string
+	existing   []string
+	deadLetter []string
+}
+
+// NewDryRun returns a dry-run store writing JSON files under dir. inner
+// may be nil, in which case the diff report treats everything as new.
+func NewDryRun(inner Store, dir string) (*DryRun, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &DryRun{inner: inner, dir: dir}, nil
+}
+
+// Put writes the document to a JSON file and records whether the wrapped
+// store already has it.
+func (s *DryRun) Put(ctx context.Context, doc processing.Document) error {
+	key := Key{Name: doc.Name, Version: doc.Version, Commit: doc.CommitTag, HashType: doc.FileHashType}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, fileName(key)), data, 0644); err != nil {
+		return err
+	}
+
+	stored := false
+	if s.inner != nil {
+		if stored, err = s.inner.Exists(ctx, key); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	if stored {
+		s.existing = append(s.existing, key.name())
+	} else {
+		s.newKeys = append(s.newKeys, key.name())
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Exists and ExistsMulti report everything as unindexed so a dry run
+// re-processes every version rather than skipping what a previous real
+// run stored.
+func (s *DryRun) Exists(context.Context, Key) (bool, error) {
+	return false, nil
+}
+
+func (s *DryRun) ExistsMulti(_ context.Context, keys []Key) ([]bool, error) {
+	return make([]bool, len(keys)), nil
+}
+
+// Checkpoints are not persisted in a dry run.
+func (s *DryRun) SaveCheckpoint(context.Context, Checkpoint) error {
+	return nil
+}
+
+func (s *DryRun) LoadCheckpoint(context.Context, string) (Checkpoint, bool, error) {
+	return Checkpoint{}, false, nil
+}
+
+func (s *DryRun) ClearCheckpoint(context.Context, string) error {
+	return nil
+}
+
+// SaveDeadLetter records the failure in the report instead of storage.
+func (s *DryRun) SaveDeadLetter(_ context.Context, dl DeadLetter) error {
+	s.mu.Lock()
+	s.deadLetter = append(s.deadLetter, fmt.Sprintf("%s: %s", dl.Name, dl.Reason))
+	s.mu.Unlock()
+
+	return nil
+}
+
+// CollectGarbage never deletes in a dry run.
+func (s *DryRun) CollectGarbage(context.Context, []string, []Key) (int, error) {
+	return 0, nil
+}
+
+// Report summarizes the run: the documents that would be written and
+// whether each already exists in the wrapped store.
+func (s *DryRun) Report() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sort.Strings(s.newKeys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "dry run: %d documents written to %s (%d new, %d already stored)\n",
+		len(s.newKeys)+len(s.existing), s.dir, len(s.newKeys), len(s.existing))
+	for _, key := range s.newKeys {
+		fmt.Fprintf(&b, "  new: %s\n", key)
+	}
+	for _, failure := range s.deadLetter {
+		fmt.Fprintf(&b, "  failed: %s\n", failure)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// Close closes the wrapped store.
+func (s *DryRun) Close() error {
+	if s.inner == nil {
+		return nil
+	}
+
+	return s.inner.Close()
+}
+
+// fileName maps a document key to a safe file name.
+func fileName(key Key) string {
+	name := strings.NewReplacer("/", "_", string(os.PathSeparator), "_").Replace(key.name())
+
+	return name + ".json"
+}
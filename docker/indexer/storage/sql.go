@@ -0,0 +1,312 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// Self-hosting backends: PostgreSQL and a pure-Go SQLite driver so the
+	// indexer binary stays cgo-free.
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+
+	"github.com/google/osv/docker/indexer/stages/processing"
+)
+
+// schema is executed on open; both dialects accept it as-is.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS repo_index (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		base_cpe TEXT NOT NULL,
+		version TEXT NOT NULL,
+		commit_hash TEXT NOT NULL,
+		indexed_at TIMESTAMP NOT NULL,
+		profile TEXT NOT NULL DEFAULT '',
+		repo_type TEXT NOT NULL DEFAULT 'git',
+		file_count INTEGER NOT NULL,
+		file_hash_type TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS repo_index_files (
+		index_id TEXT NOT NULL,
+		path TEXT NOT NULL,
+		hash TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS repo_index_files_index_id ON repo_index_files (index_id)`,
+	`CREATE TABLE IF NOT EXISTS repo_dead_letters (
+		name TEXT PRIMARY KEY,
+		reason TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		failed_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS repo_checkpoints (
+		name TEXT PRIMARY KEY,
+		tag TEXT NOT NULL,
+		commit_hash TEXT NOT NULL,
+		stage TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+}
+
+// sqlStore writes index documents to a relational database. It backs both
+// the PostgreSQL and the SQLite storage backends; the only dialect
+// difference handled here is the placeholder syntax.
+type sqlStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// newSQL opens (and if necessary initializes) the database at dsn.
+func newSQL(ctx context.Context, driver, dsn string) (*sqlStore, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to %s: %w", driver, err)
+	}
+	for _, stmt := range schema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to initialize schema: %w", err)
+		}
+	}
+
+	return &sqlStore{db: db, driver: driver}, nil
+}
+
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+// rebind rewrites ?-placeholders to the driver's syntax.
+func (s *sqlStore) rebind(query string) string {
+	if s.driver != "postgres" {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Exists reports whether key's document is already stored.
+func (s *sqlStore) Exists(ctx context.Context, key Key) (bool, error) {
+	found, err := s.ExistsMulti(ctx, []Key{key})
+	if err != nil {
+		return false, err
+	}
+
+	return found[0], nil
+}
+
+// existsBatchSize keeps the IN clause of ExistsMulti queries bounded.
+const existsBatchSize = 500
+
+// ExistsMulti reports, per key, whether a document is already stored,
+// using one IN query per existsBatchSize keys.
+func (s *sqlStore) ExistsMulti(ctx context.Context, keys []Key) ([]bool, error) {
+	found := make([]bool, len(keys))
+	byID := make(map[string]int, len(keys))
+	for i, key := range keys {
+		byID[key.name()] = i
+	}
+
+	for start := 0; start < len(keys); start += existsBatchSize {
+		end := start + existsBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		args := make([]any, len(batch))
+		for i, key := range batch {
+			args[i] = key.name()
+		}
+		query := s.rebind(`SELECT id FROM repo_index WHERE id IN (?` +
+			strings.Repeat(", ?", len(batch)-1) + `)`)
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			found[byID[id]] = true
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rows.Close()
+	}
+
+	return found, nil
+}
+
+// SaveCheckpoint records cp, replacing the repository's previous
+// checkpoint. Both dialects accept the ON CONFLICT upsert.
+func (s *sqlStore) SaveCheckpoint(ctx context.Context, cp Checkpoint) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(
+		`INSERT INTO repo_checkpoints (name, tag, commit_hash, stage, updated_at)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET
+		 tag = excluded.tag, commit_hash = excluded.commit_hash,
+		 stage = excluded.stage, updated_at = excluded.updated_at`),
+		cp.Name, cp.Tag, cp.Commit, cp.Stage, cp.When)
+
+	return err
+}
+
+// LoadCheckpoint returns the repository's checkpoint, reporting whether
+// one exists.
+func (s *sqlStore) LoadCheckpoint(ctx context.Context, name string) (Checkpoint, bool, error) {
+	cp := Checkpoint{Name: name}
+	err := s.db.QueryRowContext(ctx, s.rebind(
+		`SELECT tag, commit_hash, stage, updated_at FROM repo_checkpoints WHERE name = ?`), name).
+		Scan(&cp.Tag, &cp.Commit, &cp.Stage, &cp.When)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Checkpoint{}, false, nil
+	}
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+
+	return cp, true, nil
+}
+
+// ClearCheckpoint removes the repository's checkpoint.
+func (s *sqlStore) ClearCheckpoint(ctx context.Context, name string) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(`DELETE FROM repo_checkpoints WHERE name = ?`), name)
+
+	return err
+}
+
+// CollectGarbage removes the documents of the given product names whose
+// keys are not in live, along with their file hash rows.
+func (s *sqlStore) CollectGarbage(ctx context.Context, names []string, live []Key) (int, error) {
+	liveSet := make(map[string]bool, len(live))
+	for _, key := range live {
+		liveSet[key.name()] = true
+	}
+
+	removed := 0
+	for _, name := range names {
+		rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT id FROM repo_index WHERE name = ?`), name)
+		if err != nil {
+			return removed, err
+		}
+		var stale []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return removed, err
+			}
+			if !liveSet[id] {
+				stale = append(stale, id)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return removed, err
+		}
+		rows.Close()
+
+		for _, id := range stale {
+			tx, err := s.db.BeginTx(ctx, nil)
+			if err != nil {
+				return removed, err
+			}
+			_, err = tx.ExecContext(ctx, s.rebind(`DELETE FROM repo_index_files WHERE index_id = ?`), id)
+			if err == nil {
+				_, err = tx.ExecContext(ctx, s.rebind(`DELETE FROM repo_index WHERE id = ?`), id)
+			}
+			if err != nil {
+				tx.Rollback()
+				return removed, err
+			}
+			if err := tx.Commit(); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// SaveDeadLetter records dl, replacing the repository's previous record.
+func (s *sqlStore) SaveDeadLetter(ctx context.Context, dl DeadLetter) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(
+		`INSERT INTO repo_dead_letters (name, reason, attempts, failed_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET
+		 reason = excluded.reason, attempts = excluded.attempts,
+		 failed_at = excluded.failed_at`),
+		dl.Name, dl.Reason, dl.Attempts, dl.When)
+
+	return err
+}
+
+// Put stores one document and its file hashes in a single transaction,
+// replacing any previous index of the same (name, version, commit).
+func (s *sqlStore) Put(ctx context.Context, doc processing.Document) error {
+	id := Key{Name: doc.Name, Version: doc.Version, Commit: doc.CommitTag, HashType: doc.FileHashType}.name()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM repo_index WHERE id = ?`), id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM repo_index_files WHERE index_id = ?`), id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(
+		`INSERT INTO repo_index (id, name, base_cpe, version, commit_hash, indexed_at, profile, repo_type, file_count, file_hash_type)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		id, doc.Name, doc.BaseCPE, doc.Version, doc.CommitTag, doc.When, doc.Profile, doc.RepoType, doc.FileCount, doc.FileHashType); err != nil {
+		return err
+	}
+	insert := s.rebind(`INSERT INTO repo_index_files (index_id, path, hash) VALUES (?, ?, ?)`)
+	for _, result := range doc.FileResults {
+		if _, err := tx.ExecContext(ctx, insert, id, result.Path, hex.EncodeToString(result.Hash)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
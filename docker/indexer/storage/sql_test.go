@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/osv/docker/indexer/shared"
+	"github.com/google/osv/docker/indexer/stages/processing"
+)
+
+// newTestStore opens a SQLite store in a temporary directory; the SQLite
+// driver is pure Go, so these tests run anywhere.
+func newTestStore(t *testing.T) Store {
+	t.Helper()
+	store, err := New(context.Background(), "sqlite://"+filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if _, ok, err := store.LoadCheckpoint(ctx, "curl"); err != nil || ok {
+		t.Fatalf("LoadCheckpoint before save = ok %t, err %v, want no checkpoint", ok, err)
+	}
+
+	cp := Checkpoint{
+		Name:   "curl",
+		Tag:    "curl-8_5_0",
+		Commit: "abc123",
+		Stage:  CheckpointStored,
+		When:   time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+	if err := store.SaveCheckpoint(ctx, cp); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+	got, ok, err := store.LoadCheckpoint(ctx, "curl")
+	if err != nil || !ok {
+		t.Fatalf("LoadCheckpoint = ok %t, err %v, want a checkpoint", ok, err)
+	}
+	if got.Name != cp.Name || got.Tag != cp.Tag || got.Commit != cp.Commit || got.Stage != cp.Stage {
+		t.Errorf("LoadCheckpoint = %+v, want %+v", got, cp)
+	}
+	if !got.When.Equal(cp.When) {
+		t.Errorf("checkpoint time = %v, want %v", got.When, cp.When)
+	}
+
+	// Saving again replaces the previous checkpoint.
+	cp.Tag, cp.Commit = "curl-8_6_0", "def456"
+	if err := store.SaveCheckpoint(ctx, cp); err != nil {
+		t.Fatalf("SaveCheckpoint (overwrite): %v", err)
+	}
+	got, ok, err = store.LoadCheckpoint(ctx, "curl")
+	if err != nil || !ok || got.Tag != "curl-8_6_0" || got.Commit != "def456" {
+		t.Fatalf("LoadCheckpoint after overwrite = %+v, ok %t, err %v", got, ok, err)
+	}
+
+	if err := store.ClearCheckpoint(ctx, "curl"); err != nil {
+		t.Fatalf("ClearCheckpoint: %v", err)
+	}
+	if _, ok, err := store.LoadCheckpoint(ctx, "curl"); err != nil || ok {
+		t.Fatalf("LoadCheckpoint after clear = ok %t, err %v, want no checkpoint", ok, err)
+	}
+}
+
+func TestPutExists(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	doc := processing.Document{
+		Name:         "libfoo",
+		BaseCPE:      "cpe:/a:foo:libfoo",
+		Version:      "1.0.0",
+		CommitTag:    "abc123",
+		When:         time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		RepoType:     shared.TypeGit,
+		FileCount:    1,
+		FileHashType: shared.MD5,
+		FileResults:  []shared.FileResult{{Path: "a.c", Hash: []byte{0xde, 0xad}}},
+	}
+	if err := store.Put(ctx, doc); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// A SHA-256 document of the same version is stored under its own key.
+	doc.FileHashType = shared.SHA256
+	if err := store.Put(ctx, doc); err != nil {
+		t.Fatalf("Put (SHA-256): %v", err)
+	}
+
+	keys := []Key{
+		{Name: "libfoo", Version: "1.0.0", Commit: "abc123"},
+		{Name: "libfoo", Version: "1.0.0", Commit: "abc123", HashType: shared.SHA256},
+		{Name: "libfoo", Version: "2.0.0", Commit: "def456"},
+	}
+	want := []bool{true, true, false}
+	found, err := store.ExistsMulti(ctx, keys)
+	if err != nil {
+		t.Fatalf("ExistsMulti: %v", err)
+	}
+	for i := range keys {
+		if found[i] != want[i] {
+			t.Errorf("ExistsMulti[%d] (%+v) = %t, want %t", i, keys[i], found[i], want[i])
+		}
+	}
+	if ok, err := store.Exists(ctx, keys[0]); err != nil || !ok {
+		t.Errorf("Exists = %t, err %v, want stored", ok, err)
+	}
+
+	// Re-indexing the same (name, version, commit, hash type) overwrites
+	// rather than duplicating.
+	doc.FileHashType = shared.MD5
+	doc.FileResults = append(doc.FileResults, shared.FileResult{Path: "b.c", Hash: []byte{0xbe, 0xef}})
+	doc.FileCount = 2
+	if err := store.Put(ctx, doc); err != nil {
+		t.Fatalf("Put (overwrite): %v", err)
+	}
+	if ok, err := store.Exists(ctx, keys[0]); err != nil || !ok {
+		t.Errorf("Exists after overwrite = %t, err %v, want stored", ok, err)
+	}
+}
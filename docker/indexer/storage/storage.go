@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage persists index documents. Datastore is the production
+// backend; the PostgreSQL and SQLite backends let the whole pipeline be
+// self-hosted outside GCP.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/osv/docker/indexer/shared"
+	"github.com/google/osv/docker/indexer/stages/processing"
+)
+
+// Key identifies one stored index document.
+type Key struct {
+	Name    string
+	Version string
+	Commit  string
+	// HashType is the document's file hash type. Empty is treated as
+	// MD5.
+	HashType string
+}
+
+// name returns the backend-independent document identifier. MD5
+// documents keep the original name-version-commit form so entities
+// indexed before hash types were configurable stay addressable.
+func (k Key) name() string {
+	id := fmt.Sprintf("%s-%s-%s", k.Name, k.Version, k.Commit)
+	if k.HashType == "" || k.HashType == shared.MD5 {
+		return id
+	}
+
+	return id + "-" + k.HashType
+}
+
+// CheckpointStored is the checkpoint stage recorded once a tag's
+// documents are fully stored.
+const CheckpointStored = "stored"
+
+// Checkpoint records per-repository indexing progress so a crashed run
+// can resume after the last fully processed tag instead of starting the
+// repository over.
+type Checkpoint struct {
+	// Name is the config entry name of the repository.
+	Name string
+	// Tag and Commit identify the last tag the recorded stage completed
+	// for.
+	Tag    string
+	Commit string
+	// Stage is the pipeline stage the checkpoint was taken at.
+	Stage string
+	When  time.Time
+}
+
+// DeadLetter records a repository whose indexing kept failing after its
+// retry budget was spent, so operators can inspect and requeue it
+// without it blocking the rest of the pipeline.
+type DeadLetter struct {
+	// Name is the config entry name of the repository.
+	Name string
+	// Reason is the last failure.
+	Reason string
+	// Attempts is how many runs failed before giving up.
+	Attempts int
+	When     time.Time
+}
+
+// Store persists index documents.
+type Store interface {
+	// Put stores one document and its file hashes. Storing the same
+	// (name, version, commit) document again overwrites it, so
+	// re-indexing is idempotent.
+	Put(ctx context.Context, doc processing.Document) error
+	// Exists reports whether a document is already stored.
+	Exists(ctx context.Context, key Key) (bool, error)
+	// ExistsMulti reports, per key, whether a document is already stored.
+	// It batches backend lookups, so preparation should prefer it over
+	// per-tag Exists calls when skipping already-indexed versions.
+	ExistsMulti(ctx context.Context, keys []Key) ([]bool, error)
+	// SaveCheckpoint records cp as the repository's current progress,
+	// replacing any previous checkpoint.
+	SaveCheckpoint(ctx context.Context, cp Checkpoint) error
+	// LoadCheckpoint returns the repository's checkpoint, reporting
+	// whether one exists.
+	LoadCheckpoint(ctx context.Context, name string) (Checkpoint, bool, error)
+	// ClearCheckpoint removes the repository's checkpoint once a run
+	// completes.
+	ClearCheckpoint(ctx context.Context, name string) error
+	// SaveDeadLetter records a repository that exhausted its retry
+	// budget, replacing any previous record.
+	SaveDeadLetter(ctx context.Context, dl DeadLetter) error
+	// CollectGarbage removes the documents (and their file hashes) of
+	// the given product names whose keys are not in live — documents of
+	// tags that were deleted or force-pushed away. It returns the number
+	// of documents removed.
+	CollectGarbage(ctx context.Context, names []string, live []Key) (int, error)
+	// Close releases the backend connection.
+	Close() error
+}
+
+// New returns the store for a backend spec: "datastore://PROJECT",
+// "postgres://..." (a PostgreSQL connection URL) or "sqlite://PATH".
+func New(ctx context.Context, spec string) (Store, error) {
+	scheme, rest, ok := strings.Cut(spec, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid storage spec %q", spec)
+	}
+	switch scheme {
+	case "datastore":
+		return newDatastore(ctx, rest)
+	case "postgres":
+		// PostgreSQL takes the full connection URL, scheme included.
+		return newSQL(ctx, "postgres", spec)
+	case "sqlite":
+		return newSQL(ctx, "sqlite", rest)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", scheme)
+	}
+}
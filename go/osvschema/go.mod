@@ -0,0 +1,3 @@
+module github.com/google/osv/go/osvschema
+
+go 1.22
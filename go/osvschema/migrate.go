@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osvschema
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// This file upgrades records written against older schema versions
+// (1.2/1.3) to the current version. Upgrades work on the raw JSON
+// structure rather than the typed model, since the point is to repair
+// shapes the typed model no longer accepts. Third-party feeds are the
+// main source of such records.
+
+// legacyEcosystems maps the upper-cased ecosystem names used by early
+// exports to the canonical OSV names.
+var legacyEcosystems = map[string]string{
+	"NPM":       "npm",
+	"PIP":       "PyPI",
+	"RUBYGEMS":  "RubyGems",
+	"MAVEN":     "Maven",
+	"NUGET":     "NuGet",
+	"COMPOSER":  "Packagist",
+	"GO":        "Go",
+	"RUST":      "crates.io",
+	"CRATES.IO": "crates.io",
+}
+
+var commitRe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Upgrade rewrites a serialized OSV record from an older schema version
+// to the current one and stamps it with SchemaVersion. Records already
+// at the current version pass through unchanged apart from the stamp.
+func Upgrade(data []byte) ([]byte, error) {
+	var record map[string]interface{}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(UpgradeRecord(record))
+}
+
+// UpgradeRecord is Upgrade on an already-decoded record. The input is
+// modified in place and returned.
+func UpgradeRecord(record map[string]interface{}) map[string]interface{} {
+	upgradeSeverity(record)
+	for _, affected := range asSlice(record["affected"]) {
+		entry, ok := affected.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		upgradeEcosystem(entry)
+		upgradeRanges(entry)
+	}
+	record["schema_version"] = SchemaVersion
+
+	return record
+}
+
+// upgradeSeverity moves the pre-1.2 plain-string severity ("HIGH") into
+// database_specific; the current severity field is a list of typed
+// scores.
+func upgradeSeverity(record map[string]interface{}) {
+	level, ok := record["severity"].(string)
+	if !ok {
+		return
+	}
+	delete(record, "severity")
+	dbSpecific, ok := record["database_specific"].(map[string]interface{})
+	if !ok {
+		dbSpecific = map[string]interface{}{}
+		record["database_specific"] = dbSpecific
+	}
+	if _, exists := dbSpecific["severity"]; !exists {
+		dbSpecific["severity"] = level
+	}
+}
+
+func upgradeEcosystem(affected map[string]interface{}) {
+	pkg, ok := affected["package"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	name, ok := pkg["ecosystem"].(string)
+	if !ok {
+		return
+	}
+	if canonical, ok := legacyEcosystems[name]; ok {
+		pkg["ecosystem"] = canonical
+	}
+}
+
+// upgradeRanges repairs two legacy range shapes: ranges carrying
+// "introduced"/"fixed" directly instead of an events list, and version
+// ranges whose events mix in commit hashes, which the current schema
+// requires in a separate GIT range.
+func upgradeRanges(affected map[string]interface{}) {
+	var upgraded []interface{}
+	for _, r := range asSlice(affected["ranges"]) {
+		rng, ok := r.(map[string]interface{})
+		if !ok {
+			upgraded = append(upgraded, r)
+			continue
+		}
+		if _, ok := rng["events"]; !ok {
+			var events []interface{}
+			if introduced, ok := rng["introduced"].(string); ok {
+				events = append(events, map[string]interface{}{"introduced": introduced})
+				delete(rng, "introduced")
+			}
+			if fixed, ok := rng["fixed"].(string); ok {
+				events = append(events, map[string]interface{}{"fixed": fixed})
+				delete(rng, "fixed")
+			}
+			rng["events"] = events
+		}
+		upgraded = append(upgraded, splitCommitEvents(rng)...)
+	}
+	if upgraded != nil {
+		affected["ranges"] = upgraded
+	}
+}
+
+// splitCommitEvents moves commit-hash events out of a SEMVER/ECOSYSTEM
+// range into a sibling GIT range, keeping the repo if one was set.
+func splitCommitEvents(rng map[string]interface{}) []interface{} {
+	if rng["type"] == string(RangeGit) {
+		return []interface{}{rng}
+	}
+	var versions, commits []interface{}
+	for _, e := range asSlice(rng["events"]) {
+		event, ok := e.(map[string]interface{})
+		if ok && eventIsCommit(event) {
+			commits = append(commits, event)
+			continue
+		}
+		versions = append(versions, e)
+	}
+	if len(commits) == 0 {
+		return []interface{}{rng}
+	}
+	gitRange := map[string]interface{}{
+		"type":   string(RangeGit),
+		"events": commits,
+	}
+	if repo, ok := rng["repo"]; ok {
+		gitRange["repo"] = repo
+		delete(rng, "repo")
+	}
+	if len(versions) == 0 {
+		return []interface{}{gitRange}
+	}
+	rng["events"] = versions
+
+	return []interface{}{rng, gitRange}
+}
+
+func eventIsCommit(event map[string]interface{}) bool {
+	for _, value := range event {
+		s, ok := value.(string)
+		if ok && commitRe.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func asSlice(v interface{}) []interface{} {
+	s, _ := v.([]interface{})
+
+	return s
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osvschema defines the full OSV schema
+// (https://ossf.github.io/osv-schema/) as Go types with JSON
+// (de)serialization, plus validation of the schema's structural
+// invariants. It is the canonical model for anything in this repository
+// producing or consuming OSV records.
+package osvschema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// SchemaVersion is the schema version this package models.
+const SchemaVersion = "1.6.0"
+
+// RangeType is the type of an affected range.
+type RangeType string
+
+const (
+	RangeGit       RangeType = "GIT"
+	RangeSemVer    RangeType = "SEMVER"
+	RangeEcosystem RangeType = "ECOSYSTEM"
+)
+
+// SeverityType is the scoring system of a severity entry.
+type SeverityType string
+
+const (
+	SeverityCVSSV2 SeverityType = "CVSS_V2"
+	SeverityCVSSV3 SeverityType = "CVSS_V3"
+	SeverityCVSSV4 SeverityType = "CVSS_V4"
+	SeverityUbuntu SeverityType = "Ubuntu"
+)
+
+// ReferenceType categorizes a reference URL.
+type ReferenceType string
+
+const (
+	ReferenceAdvisory   ReferenceType = "ADVISORY"
+	ReferenceArticle    ReferenceType = "ARTICLE"
+	ReferenceDetection  ReferenceType = "DETECTION"
+	ReferenceDiscussion ReferenceType = "DISCUSSION"
+	ReferenceReport     ReferenceType = "REPORT"
+	ReferenceFix        ReferenceType = "FIX"
+	ReferenceIntroduced ReferenceType = "INTRODUCED"
+	ReferencePackage    ReferenceType = "PACKAGE"
+	ReferenceEvidence   ReferenceType = "EVIDENCE"
+	ReferenceWeb        ReferenceType = "WEB"
+)
+
+// CreditType describes a credited party's role.
+type CreditType string
+
+const (
+	CreditFinder               CreditType = "FINDER"
+	CreditReporter             CreditType = "REPORTER"
+	CreditAnalyst              CreditType = "ANALYST"
+	CreditCoordinator          CreditType = "COORDINATOR"
+	CreditRemediationDeveloper CreditType = "REMEDIATION_DEVELOPER"
+	CreditRemediationReviewer  CreditType = "REMEDIATION_REVIEWER"
+	CreditRemediationVerifier  CreditType = "REMEDIATION_VERIFIER"
+	CreditTool                 CreditType = "TOOL"
+	CreditSponsor              CreditType = "SPONSOR"
+	CreditOther                CreditType = "OTHER"
+)
+
+// Package identifies the affected package.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	PURL      string `json:"purl,omitempty"`
+}
+
+// Event is a single version or commit event inside a range. Exactly one
+// field must be set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Range is a span of affected versions or commits.
+type Range struct {
+	Type             RangeType              `json:"type"`
+	Repo             string                 `json:"repo,omitempty"`
+	Events           []Event                `json:"events"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Severity is one severity score of a record.
+type Severity struct {
+	Type  SeverityType `json:"type"`
+	Score string       `json:"score"`
+}
+
+// Affected describes one affected package with its ranges and versions.
+type Affected struct {
+	Package           Package                `json:"package,omitempty"`
+	Severity          []Severity             `json:"severity,omitempty"`
+	Ranges            []Range                `json:"ranges,omitempty"`
+	Versions          []string               `json:"versions,omitempty"`
+	EcosystemSpecific map[string]interface{} `json:"ecosystem_specific,omitempty"`
+	DatabaseSpecific  map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Reference is a URL with additional context about the vulnerability.
+type Reference struct {
+	Type ReferenceType `json:"type"`
+	URL  string        `json:"url"`
+}
+
+// Credit acknowledges a party involved in finding or fixing the
+// vulnerability.
+type Credit struct {
+	Name    string     `json:"name"`
+	Contact []string   `json:"contact,omitempty"`
+	Type    CreditType `json:"type,omitempty"`
+}
+
+// Vulnerability is a complete OSV record.
+type Vulnerability struct {
+	SchemaVersion string    `json:"schema_version,omitempty"`
+	ID            string    `json:"id"`
+	Modified      time.Time `json:"modified"`
+	// Published and Withdrawn are pointers because omitempty does not
+	// apply to struct values: a plain time.Time would marshal the zero
+	// time, and a withdrawn field — whose mere presence marks the record
+	// withdrawn — must be absent rather than zero.
+	Published        *time.Time             `json:"published,omitempty"`
+	Withdrawn        *time.Time             `json:"withdrawn,omitempty"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Related          []string               `json:"related,omitempty"`
+	Upstream         []string               `json:"upstream,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Severity         []Severity             `json:"severity,omitempty"`
+	Affected         []Affected             `json:"affected,omitempty"`
+	References       []Reference            `json:"references,omitempty"`
+	Credits          []Credit               `json:"credits,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Timestamp adapts a time to the optional timestamp fields: it returns
+// a pointer to t, or nil for the zero time so the field is omitted
+// entirely.
+func Timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// idRe matches OSV IDs: an upper-case alphanumeric prefix, a year or
+// numeric discriminator, and a suffix, joined with dashes.
+var idRe = regexp.MustCompile(`^[A-Z][A-Z0-9]*-[0-9A-Za-z]+(-[0-9A-Za-z.]+)*$`)
+
+// Validate checks the record against the schema's structural invariants:
+// required fields, valid enum values, well-formed events and the
+// presence of an introduced event in every range. All violations are
+// reported, joined into one error.
+func (v *Vulnerability) Validate() error {
+	var errs []error
+	if v.ID == "" {
+		errs = append(errs, errors.New("id is required"))
+	} else if !idRe.MatchString(v.ID) {
+		errs = append(errs, fmt.Errorf("invalid id %q", v.ID))
+	}
+	if v.Modified.IsZero() {
+		errs = append(errs, errors.New("modified is required"))
+	}
+	for _, severity := range v.Severity {
+		errs = append(errs, validateSeverity(severity)...)
+	}
+	for i, affected := range v.Affected {
+		for _, severity := range affected.Severity {
+			errs = append(errs, validateSeverity(severity)...)
+		}
+		for j, rng := range affected.Ranges {
+			errs = append(errs, validateRange(i, j, rng)...)
+		}
+	}
+	for _, ref := range v.References {
+		switch ref.Type {
+		case ReferenceAdvisory, ReferenceArticle, ReferenceDetection,
+			ReferenceDiscussion, ReferenceReport, ReferenceFix,
+			ReferenceIntroduced, ReferencePackage, ReferenceEvidence,
+			ReferenceWeb:
+		default:
+			errs = append(errs, fmt.Errorf("invalid reference type %q", ref.Type))
+		}
+		if ref.URL == "" {
+			errs = append(errs, errors.New("reference url is required"))
+		}
+	}
+	for _, credit := range v.Credits {
+		if credit.Name == "" {
+			errs = append(errs, errors.New("credit name is required"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateSeverity(severity Severity) []error {
+	var errs []error
+	switch severity.Type {
+	case SeverityCVSSV2, SeverityCVSSV3, SeverityCVSSV4, SeverityUbuntu:
+	default:
+		errs = append(errs, fmt.Errorf("invalid severity type %q", severity.Type))
+	}
+	if severity.Score == "" {
+		errs = append(errs, errors.New("severity score is required"))
+	}
+
+	return errs
+}
+
+func validateRange(affectedIdx, rangeIdx int, rng Range) []error {
+	at := fmt.Sprintf("affected[%d].ranges[%d]", affectedIdx, rangeIdx)
+	var errs []error
+	switch rng.Type {
+	case RangeGit, RangeSemVer, RangeEcosystem:
+	default:
+		errs = append(errs, fmt.Errorf("%s: invalid range type %q", at, rng.Type))
+	}
+	if rng.Type == RangeGit && rng.Repo == "" {
+		errs = append(errs, fmt.Errorf("%s: GIT range requires repo", at))
+	}
+	if len(rng.Events) == 0 {
+		errs = append(errs, fmt.Errorf("%s: range requires events", at))
+	}
+	introduced := false
+	for k, event := range rng.Events {
+		set := 0
+		for _, field := range []string{event.Introduced, event.Fixed, event.LastAffected, event.Limit} {
+			if field != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			errs = append(errs, fmt.Errorf("%s.events[%d]: exactly one field must be set", at, k))
+		}
+		if event.Introduced != "" {
+			introduced = true
+		}
+	}
+	if len(rng.Events) > 0 && !introduced {
+		errs = append(errs, fmt.Errorf("%s: range requires an introduced event", at))
+	}
+
+	return errs
+}
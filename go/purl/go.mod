@@ -0,0 +1,3 @@
+module github.com/google/osv/go/purl
+
+go 1.22
@@ -0,0 +1,342 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package purl builds package URLs (https://github.com/package-url/purl-spec)
+// with correct namespace handling, percent-encoding and qualifier ordering,
+// and maps between OSV ecosystem names and purl types. It replaces ad-hoc
+// string concatenation in the scanner and SBOM tooling.
+package purl
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// PackageURL is a parsed-out package URL.
+type PackageURL struct {
+	// Type is the purl type, e.g. "npm" or "deb".
+	Type string
+	// Namespace is the type-specific name prefix, e.g. the Maven group ID
+	// or the Debian distro. Empty for types without namespaces.
+	Namespace string
+	Name      string
+	Version   string
+	// Qualifiers hold extra identifying data such as "distro" or "arch".
+	Qualifiers map[string]string
+}
+
+// String renders the purl in canonical form: lower-case type, sorted
+// qualifiers and percent-encoded components.
+func (p PackageURL) String() string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(strings.ToLower(p.Type))
+	b.WriteByte('/')
+	if p.Namespace != "" {
+		// Namespaces may contain several segments; encode each segment but
+		// keep the separating slashes.
+		segments := strings.Split(p.Namespace, "/")
+		for _, segment := range segments {
+			b.WriteString(encode(segment))
+			b.WriteByte('/')
+		}
+	}
+	b.WriteString(encode(p.Name))
+	if p.Version != "" {
+		b.WriteByte('@')
+		b.WriteString(encode(p.Version))
+	}
+	if len(p.Qualifiers) > 0 {
+		keys := make([]string, 0, len(p.Qualifiers))
+		for key := range p.Qualifiers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			if i == 0 {
+				b.WriteByte('?')
+			} else {
+				b.WriteByte('&')
+			}
+			b.WriteString(strings.ToLower(key))
+			b.WriteByte('=')
+			b.WriteString(encode(p.Qualifiers[key]))
+		}
+	}
+
+	return b.String()
+}
+
+// encode percent-encodes a purl component. The spec encodes like a URL
+// path segment but additionally reserves "@" and "?", plus "&" and "="
+// so qualifier values survive the qualifier-string syntax and round-trip
+// through Parse.
+func encode(s string) string {
+	s = url.PathEscape(s)
+	s = strings.ReplaceAll(s, "@", "%40")
+	s = strings.ReplaceAll(s, "?", "%3F")
+	s = strings.ReplaceAll(s, "&", "%26")
+	s = strings.ReplaceAll(s, "=", "%3D")
+
+	return s
+}
+
+// Parse parses a canonical purl string. The scheme and type are
+// required; namespace, version, qualifiers and subpath are optional.
+// Percent-encoded components are decoded.
+func Parse(s string) (PackageURL, error) {
+	rest, ok := strings.CutPrefix(s, "pkg:")
+	if !ok {
+		return PackageURL{}, fmt.Errorf("purl %q does not start with pkg:", s)
+	}
+	// The subpath and qualifiers are delimited unambiguously from the
+	// right; strip them before splitting path segments.
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, rawQualifiers, hasQualifiers := strings.Cut(rest, "?")
+
+	var p PackageURL
+	if hasQualifiers {
+		p.Qualifiers = make(map[string]string)
+		for _, pair := range strings.Split(rawQualifiers, "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			decoded, err := url.PathUnescape(value)
+			if err != nil {
+				return PackageURL{}, fmt.Errorf("invalid qualifier in purl %q: %w", s, err)
+			}
+			p.Qualifiers[strings.ToLower(key)] = decoded
+		}
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		version, err := url.PathUnescape(rest[i+1:])
+		if err != nil {
+			return PackageURL{}, fmt.Errorf("invalid version in purl %q: %w", s, err)
+		}
+		p.Version = version
+		rest = rest[:i]
+	}
+
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" {
+		return PackageURL{}, fmt.Errorf("purl %q has no type or name", s)
+	}
+	p.Type = strings.ToLower(segments[0])
+	decoded := make([]string, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		d, err := url.PathUnescape(segment)
+		if err != nil {
+			return PackageURL{}, fmt.Errorf("invalid component in purl %q: %w", s, err)
+		}
+		decoded = append(decoded, d)
+	}
+	p.Name = decoded[len(decoded)-1]
+	p.Namespace = strings.Join(decoded[:len(decoded)-1], "/")
+
+	return p, nil
+}
+
+// ecosystemTypes maps OSV ecosystem names to purl types for ecosystems
+// whose names differ. Ecosystems missing here use the lower-cased
+// ecosystem name as the type.
+var ecosystemTypes = map[string]string{
+	"crates.io":      "cargo",
+	"Go":             "golang",
+	"Packagist":      "composer",
+	"RubyGems":       "gem",
+	"Debian":         "deb",
+	"Alpine":         "apk",
+	"OSS-Fuzz":       "generic",
+	"GitHub Actions": "github",
+}
+
+// typeEcosystems is the reverse of ecosystemTypes, plus the ecosystems
+// whose purl type only differs in case.
+var typeEcosystems = map[string]string{
+	"cargo":    "crates.io",
+	"golang":   "Go",
+	"composer": "Packagist",
+	"gem":      "RubyGems",
+	"deb":      "Debian",
+	"apk":      "Alpine",
+	"npm":      "npm",
+	"pypi":     "PyPI",
+	"maven":    "Maven",
+	"nuget":    "NuGet",
+	"hex":      "Hex",
+	"pub":      "Pub",
+	"swift":    "SwiftURL",
+	"hackage":  "Hackage",
+	"cran":     "CRAN",
+}
+
+// FromOSV builds the purl for a package identified the OSV way, by
+// ecosystem and name. Type-specific conventions are applied: Maven
+// "group:artifact" names and namespaced npm/Packagist names become
+// namespace/name pairs, and Debian/Alpine packages get their distro as
+// both namespace and qualifier.
+func FromOSV(ecosystem, name, version string) PackageURL {
+	// OSV ecosystem names may carry a release suffix, e.g. "Debian:12".
+	ecosystem, release, _ := strings.Cut(ecosystem, ":")
+	purlType, ok := ecosystemTypes[ecosystem]
+	if !ok {
+		purlType = strings.ToLower(ecosystem)
+	}
+	p := PackageURL{Type: purlType, Name: name, Version: version}
+	switch purlType {
+	case "maven":
+		if group, artifact, ok := strings.Cut(name, ":"); ok {
+			p.Namespace, p.Name = group, artifact
+		}
+	case "npm", "composer", "golang":
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			p.Namespace, p.Name = name[:i], name[i+1:]
+		}
+	case "deb":
+		p.Namespace = "debian"
+		if release != "" {
+			p.Qualifiers = map[string]string{"distro": "debian-" + release}
+		}
+	case "apk":
+		p.Namespace = "alpine"
+		if release != "" {
+			p.Qualifiers = map[string]string{"distro": "alpine-" + release}
+		}
+	}
+
+	return p
+}
+
+// OSVName returns the package name the OSV way, undoing the
+// type-specific namespace splitting FromOSV applies: Maven purls become
+// "group:artifact" and namespaced npm/Packagist/Go purls rejoin with a
+// slash. Distro namespaces identify the ecosystem, not the package, and
+// are dropped.
+func (p PackageURL) OSVName() string {
+	if p.Namespace == "" {
+		return p.Name
+	}
+	switch strings.ToLower(p.Type) {
+	case "maven":
+		return p.Namespace + ":" + p.Name
+	case "deb", "apk", "rpm":
+		return p.Name
+	}
+
+	return p.Namespace + "/" + p.Name
+}
+
+// Ecosystem returns the OSV ecosystem name for a purl type, or "" if the
+// type has no OSV equivalent.
+func Ecosystem(purlType string) string {
+	return typeEcosystems[strings.ToLower(purlType)]
+}
+
+// distroEcosystems maps the namespaces of OS package purl types to OSV
+// ecosystems. Linux distributions share purl types (deb, apk, rpm) and
+// are told apart by namespace.
+var distroEcosystems = map[string]string{
+	"debian":      "Debian",
+	"ubuntu":      "Ubuntu",
+	"alpine":      "Alpine",
+	"wolfi":       "Wolfi",
+	"chainguard":  "Chainguard",
+	"redhat":      "Red Hat",
+	"almalinux":   "AlmaLinux",
+	"rocky":       "Rocky Linux",
+	"rocky-linux": "Rocky Linux",
+	"opensuse":    "openSUSE",
+	"suse":        "SUSE",
+	"sles":        "SUSE",
+	"mageia":      "Mageia",
+}
+
+// debianReleases maps Debian release codenames to the version numbers
+// OSV suffixes the Debian ecosystem with.
+var debianReleases = map[string]string{
+	"wheezy":   "7",
+	"jessie":   "8",
+	"stretch":  "9",
+	"buster":   "10",
+	"bullseye": "11",
+	"bookworm": "12",
+	"trixie":   "13",
+}
+
+// OSVEcosystem returns the OSV ecosystem string a purl's package should
+// be queried in, including the release suffix for distro-versioned
+// ecosystems ("Debian:12", "Alpine:v3.18") when the purl's distro
+// qualifier carries one. ok is false when the purl has no OSV
+// equivalent; querying such packages returns no results regardless of
+// their vulnerability status.
+func OSVEcosystem(p PackageURL) (ecosystem string, ok bool) {
+	purlType := strings.ToLower(p.Type)
+	switch purlType {
+	case "deb", "apk", "rpm":
+		ecosystem, ok = distroEcosystems[strings.ToLower(p.Namespace)]
+		if !ok {
+			return "", false
+		}
+	default:
+		ecosystem, ok = typeEcosystems[purlType]
+		if !ok {
+			return "", false
+		}
+	}
+
+	if release := distroRelease(ecosystem, p.Qualifiers["distro"]); release != "" {
+		ecosystem += ":" + release
+	}
+
+	return ecosystem, true
+}
+
+// distroRelease extracts the release suffix for an OS ecosystem from a
+// purl distro qualifier, which may be a "name-version" pair
+// ("debian-12", "alpine-3.18") or a bare Debian codename ("bookworm").
+func distroRelease(ecosystem, distro string) string {
+	if distro == "" {
+		return ""
+	}
+	distro = strings.ToLower(distro)
+	if release, ok := debianReleases[distro]; ok && ecosystem == "Debian" {
+		return release
+	}
+	release := distro
+	if _, version, ok := strings.Cut(distro, "-"); ok {
+		release = version
+	}
+	switch ecosystem {
+	case "Debian":
+		if release, ok := debianReleases[release]; ok {
+			return release
+		}
+
+		return release
+	case "Ubuntu":
+		return release
+	case "Alpine":
+		// OSV names Alpine releases with a v prefix and without the patch
+		// level: "Alpine:v3.18".
+		release = strings.TrimPrefix(release, "v")
+		if parts := strings.Split(release, "."); len(parts) > 2 {
+			release = parts[0] + "." + parts[1]
+		}
+
+		return "v" + release
+	}
+	// The remaining OS ecosystems are not versioned in OSV.
+
+	return ""
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		purl PackageURL
+		want string
+	}{
+		{
+			PackageURL{Type: "npm", Name: "lodash", Version: "4.17.21"},
+			"pkg:npm/lodash@4.17.21",
+		},
+		{
+			PackageURL{Type: "npm", Namespace: "@angular", Name: "core", Version: "12.0.0"},
+			"pkg:npm/%40angular/core@12.0.0",
+		},
+		{
+			PackageURL{Type: "maven", Namespace: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1"},
+			"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+		},
+		{
+			PackageURL{Type: "deb", Namespace: "debian", Name: "curl", Version: "7.88.1-10",
+				Qualifiers: map[string]string{"distro": "debian-12", "arch": "amd64"}},
+			"pkg:deb/debian/curl@7.88.1-10?arch=amd64&distro=debian-12",
+		},
+		{
+			// Reserved characters in qualifier values must be encoded so
+			// they do not read as qualifier syntax.
+			PackageURL{Type: "generic", Name: "thing",
+				Qualifiers: map[string]string{"checksum": "sha256=abc", "source": "a&b"}},
+			"pkg:generic/thing?checksum=sha256%3Dabc&source=a%26b",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.purl.String(); got != tt.want {
+			t.Errorf("String(%+v) = %q, want %q", tt.purl, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want PackageURL
+	}{
+		{
+			"pkg:npm/%40angular/core@12.0.0",
+			PackageURL{Type: "npm", Namespace: "@angular", Name: "core", Version: "12.0.0"},
+		},
+		{
+			"pkg:deb/debian/curl@7.88.1-10?distro=debian-12#some/subpath",
+			PackageURL{Type: "deb", Namespace: "debian", Name: "curl", Version: "7.88.1-10",
+				Qualifiers: map[string]string{"distro": "debian-12"}},
+		},
+		{
+			"pkg:golang/github.com/go-git/go-git/v5@5.11.0",
+			PackageURL{Type: "golang", Namespace: "github.com/go-git/go-git", Name: "v5", Version: "5.11.0"},
+		},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.s)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{"", "npm/lodash", "pkg:", "pkg:npm"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestStringParseRoundTrip(t *testing.T) {
+	tests := []PackageURL{
+		{Type: "npm", Name: "lodash", Version: "4.17.21"},
+		{Type: "npm", Namespace: "@angular", Name: "core", Version: "12.0.0"},
+		{Type: "maven", Namespace: "com.example", Name: "lib", Version: "1.0-SNAPSHOT"},
+		{Type: "golang", Namespace: "github.com/google/osv", Name: "purl", Version: "0.0.1"},
+		{Type: "deb", Namespace: "debian", Name: "curl", Version: "7.88.1-10",
+			Qualifiers: map[string]string{"distro": "debian-12"}},
+		// Reserved purl characters in every position.
+		{Type: "generic", Name: "odd@name", Version: "1.0?x"},
+		{Type: "generic", Name: "thing",
+			Qualifiers: map[string]string{"checksum": "sha256=abc", "source": "x&y=z", "note": "50% done"}},
+	}
+	for _, p := range tests {
+		s := p.String()
+		got, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse(String(%+v)) = Parse(%q): %v", p, s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, p) {
+			t.Errorf("Parse(String(%+v)) = Parse(%q) = %+v, not the original", p, s, got)
+		}
+	}
+}
+
+func TestFromOSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		ecosystem, name string
+	}{
+		{"npm", "@angular/core"},
+		{"Maven", "org.apache.logging.log4j:log4j-core"},
+		{"Go", "github.com/go-git/go-git/v5"},
+		{"PyPI", "requests"},
+		{"Debian:12", "curl"},
+	}
+	for _, tt := range tests {
+		p := FromOSV(tt.ecosystem, tt.name, "1.0.0")
+		if got := p.OSVName(); got != tt.name {
+			t.Errorf("FromOSV(%q, %q).OSVName() = %q, want %q", tt.ecosystem, tt.name, got, tt.name)
+		}
+	}
+}
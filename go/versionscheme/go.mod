@@ -0,0 +1,3 @@
+module github.com/google/osv/go/versionscheme
+
+go 1.22
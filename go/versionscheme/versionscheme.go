@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versionscheme detects and normalizes the versioning scheme of
+// tags and version strings (semver, date-based, "release-1_2_3" styles),
+// so the indexer stores consistent versions and the scanner renders fix
+// suggestions the same way. Per-repo overrides handle projects whose tags
+// would otherwise be misdetected.
+package versionscheme
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Scheme identifies a versioning scheme.
+type Scheme int
+
+const (
+	// SchemeUnknown means the string did not match any known scheme; the
+	// input is kept as-is.
+	SchemeUnknown Scheme = iota
+	// SchemeSemVer covers dotted numeric versions, optionally with a "v"
+	// prefix and pre-release/build suffixes.
+	SchemeSemVer
+	// SchemeDate covers date-based versions such as "20240115" or
+	// "2024.01.15".
+	SchemeDate
+	// SchemeReleaseString covers "release-1_2_3" / "REL_1_2_3" style tags
+	// using separators other than dots.
+	SchemeReleaseString
+)
+
+// String implements fmt.Stringer.
+func (s Scheme) String() string {
+	switch s {
+	case SchemeSemVer:
+		return "semver"
+	case SchemeDate:
+		return "date"
+	case SchemeReleaseString:
+		return "release-string"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseScheme parses a scheme name as used in configuration files.
+func ParseScheme(s string) Scheme {
+	switch strings.ToLower(s) {
+	case "semver":
+		return SchemeSemVer
+	case "date":
+		return SchemeDate
+	case "release-string":
+		return SchemeReleaseString
+	default:
+		return SchemeUnknown
+	}
+}
+
+var (
+	semverRe = regexp.MustCompile(`^v?(\d+(?:\.\d+){0,2})((?:[-+][0-9A-Za-z.-]+)?)$`)
+	dateRe   = regexp.MustCompile(`^(\d{4})[.-]?(\d{2})[.-]?(\d{2})$`)
+	// releaseRe matches tags like "release-1_2_3", "REL_1_2_3" or
+	// "name-1-2-3": an optional alphabetic prefix followed by numbers
+	// joined with "_" or "-".
+	releaseRe = regexp.MustCompile(`^(?:[A-Za-z]+[-_])*?(\d+(?:[-_]\d+)+)$`)
+)
+
+// Override forces the scheme for version strings with a given prefix, for
+// repositories whose tags would otherwise be misdetected (e.g. numeric
+// product names).
+type Override struct {
+	// Prefix is matched (and stripped) before detection.
+	Prefix string
+	// Scheme is the scheme to assume for matching strings.
+	Scheme Scheme
+}
+
+// Detect returns the scheme of a tag or version string.
+func Detect(s string) Scheme {
+	switch {
+	case dateRe.MatchString(s):
+		return SchemeDate
+	case semverRe.MatchString(s):
+		return SchemeSemVer
+	case releaseRe.MatchString(s):
+		return SchemeReleaseString
+	default:
+		return SchemeUnknown
+	}
+}
+
+// Normalize returns the canonical dotted form of a tag or version string
+// together with the detected scheme. Strings of unknown scheme are
+// returned unchanged.
+func Normalize(s string) (string, Scheme) {
+	return NormalizeWith(s, nil)
+}
+
+// NormalizeWith is Normalize with per-repo overrides applied first.
+func NormalizeWith(s string, overrides []Override) (string, Scheme) {
+	for _, o := range overrides {
+		if strings.HasPrefix(s, o.Prefix) {
+			return canonicalize(strings.TrimPrefix(s, o.Prefix), o.Scheme), o.Scheme
+		}
+	}
+	scheme := Detect(s)
+
+	return canonicalize(s, scheme), scheme
+}
+
+// canonicalize rewrites s into dotted numeric form for its scheme.
+func canonicalize(s string, scheme Scheme) string {
+	switch scheme {
+	case SchemeSemVer:
+		m := semverRe.FindStringSubmatch(s)
+		if m == nil {
+			return strings.TrimPrefix(s, "v")
+		}
+
+		return m[1] + m[2]
+	case SchemeDate:
+		m := dateRe.FindStringSubmatch(s)
+		if m == nil {
+			return s
+		}
+
+		return m[1] + "." + m[2] + "." + m[3]
+	case SchemeReleaseString:
+		m := releaseRe.FindStringSubmatch(s)
+		if m == nil {
+			return s
+		}
+
+		return strings.NewReplacer("_", ".", "-", ".").Replace(m[1])
+	default:
+		return s
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/google/osv/tools/cpe-purl-map
+
+go 1.22
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// cpe-purl-map builds and maintains a CPE <-> PURL/repository mapping from
+// a directory of OSV records (e.g. an extracted all.zip export) and an
+// optional dump of the indexer's BaseCPE data.
+//
+// The mapping is published as a JSON dataset consumed by the scanner's CPE
+// fallback path, so that CPE-centric scanners and SBOMs can be matched
+// against OSV.
+//
+// Usage:
+//
+//	cpe-purl-map -osv-dir ./osv-records -base-cpes base_cpes.json -out cpe_purl_map.json
+//
+// If -out already exists it is loaded first and updated in place, so the
+// dataset accumulates across runs.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Entry is one row of the mapping dataset.
+type Entry struct {
+	CPE string `json:"cpe"`
+	// PURLs lists package URLs known to correspond to the CPE.
+	PURLs []string `json:"purls,omitempty"`
+	// Repos lists source repository URLs known to correspond to the CPE.
+	Repos []string `json:"repos,omitempty"`
+}
+
+// record is the subset of an OSV record the tool needs.
+type record struct {
+	ID       string `json:"id"`
+	Affected []struct {
+		Package struct {
+			PURL string `json:"purl"`
+		} `json:"package"`
+		Ranges []struct {
+			Type string `json:"type"`
+			Repo string `json:"repo"`
+		} `json:"ranges"`
+		DatabaseSpecific struct {
+			CPEs []string `json:"cpes"`
+		} `json:"database_specific"`
+	} `json:"affected"`
+	DatabaseSpecific struct {
+		CPEs []string `json:"cpes"`
+	} `json:"database_specific"`
+}
+
+// baseCPE mirrors the indexer's RepoIndex BaseCPE field: the CPE prefix an
+// indexed repository was registered under.
+type baseCPE struct {
+	BaseCPE string `json:"base_cpe"`
+	Repo    string `json:"repo"`
+}
+
+func main() {
+	osvDir := flag.String("osv-dir", "", "directory of OSV records to scan")
+	baseCPEs := flag.String("base-cpes", "", "JSON dump of indexer BaseCPE data (optional)")
+	out := flag.String("out", "cpe_purl_map.json", "mapping dataset to create or update")
+	flag.Parse()
+
+	if *osvDir == "" && *baseCPEs == "" {
+		log.Fatal("at least one of -osv-dir and -base-cpes is required")
+	}
+
+	mapping := map[string]*Entry{}
+	if data, err := os.ReadFile(*out); err == nil {
+		var entries []*Entry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			log.Fatalf("Failed to parse existing %s: %v", *out, err)
+		}
+		for _, e := range entries {
+			mapping[e.CPE] = e
+		}
+		log.Printf("Loaded %d existing entries from %s", len(mapping), *out)
+	}
+
+	if *osvDir != "" {
+		if err := scanOSVDir(*osvDir, mapping); err != nil {
+			log.Fatalf("Failed to scan %s: %v", *osvDir, err)
+		}
+	}
+	if *baseCPEs != "" {
+		if err := loadBaseCPEs(*baseCPEs, mapping); err != nil {
+			log.Fatalf("Failed to load %s: %v", *baseCPEs, err)
+		}
+	}
+
+	entries := make([]*Entry, 0, len(mapping))
+	for _, e := range mapping {
+		sort.Strings(e.PURLs)
+		sort.Strings(e.Repos)
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CPE < entries[j].CPE })
+
+	output, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, append(output, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote %d entries to %s", len(entries), *out)
+}
+
+// scanOSVDir walks dir for OSV JSON records and folds their CPE data into
+// mapping.
+func scanOSVDir(dir string, mapping map[string]*Entry) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			log.Printf("Skipping %s: %v", path, err)
+			return nil
+		}
+		for _, affected := range rec.Affected {
+			cpes := append(rec.DatabaseSpecific.CPEs, affected.DatabaseSpecific.CPEs...)
+			for _, cpe := range cpes {
+				entry := entryFor(mapping, cpe)
+				if affected.Package.PURL != "" {
+					entry.PURLs = appendUnique(entry.PURLs, affected.Package.PURL)
+				}
+				for _, r := range affected.Ranges {
+					if r.Type == "GIT" && r.Repo != "" {
+						entry.Repos = appendUnique(entry.Repos, r.Repo)
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// loadBaseCPEs folds an indexer BaseCPE dump into mapping.
+func loadBaseCPEs(path string, mapping map[string]*Entry) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var cpes []baseCPE
+	if err := json.Unmarshal(data, &cpes); err != nil {
+		return err
+	}
+	for _, c := range cpes {
+		if c.BaseCPE == "" || c.Repo == "" {
+			continue
+		}
+		entry := entryFor(mapping, c.BaseCPE)
+		entry.Repos = appendUnique(entry.Repos, c.Repo)
+	}
+
+	return nil
+}
+
+func entryFor(mapping map[string]*Entry, cpe string) *Entry {
+	if e, ok := mapping[cpe]; ok {
+		return e
+	}
+	e := &Entry{CPE: cpe}
+	mapping[cpe] = e
+
+	return e
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, existing := range s {
+		if existing == v {
+			return s
+		}
+	}
+
+	return append(s, v)
+}
@@ -0,0 +1,660 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// datastore-admin performs routine Datastore maintenance without ad-hoc
+// scripts. All commands stream a kind's entities through the same
+// parallel batch iterator:
+//
+//	datastore-admin -project PROJECT -kind KIND count
+//	datastore-admin -project PROJECT -kind KIND delete
+//	datastore-admin -project PROJECT -kind KIND export -out entities.ndjson
+//	datastore-admin -project PROJECT -kind KIND copy -to-project OTHER
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// batchSize is the number of entities fetched and handed to a worker at
+// a time, matching the Datastore mutation limit per call.
+const batchSize = 500
+
+// batch is one unit of work produced by the iterator. shard is the
+// partition it came from, seq its position within that shard's walk and
+// cursor the query cursor just past it, for resumable runs.
+type batch struct {
+	shard    int
+	seq      int
+	cursor   string
+	keys     []*datastore.Key
+	entities []datastore.PropertyList
+}
+
+// iterOptions configures forEachBatch beyond the queries themselves.
+type iterOptions struct {
+	// keysOnly skips fetching entity properties.
+	keysOnly bool
+	// startCursors resumes each shard's walk at its encoded query
+	// cursor; empty entries start from the beginning.
+	startCursors []string
+	// done, if set, is called after fn succeeds for a batch. Batches
+	// complete in any order.
+	done func(b batch)
+}
+
+// filters collects repeatable -filter flags of the form
+// "property op value".
+type filters []string
+
+func (f *filters) String() string { return strings.Join(*f, ", ") }
+
+func (f *filters) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	projectID := flag.String("project", "", "GCP project ID")
+	kind := flag.String("kind", "", "entity kind to operate on")
+	workers := flag.Int("workers", 8, "concurrent batches to process")
+	var where filters
+	flag.Var(&where, "filter", "property predicate 'property op value', repeatable (e.g. 'name = libxml2')")
+	keyPrefix := flag.String("key-prefix", "", "only operate on entities whose key name starts with this prefix")
+	shards := flag.Int("shards", 1, "split the keyspace into this many concurrently-walked partitions")
+	partitionProperty := flag.String("partition-property", "", "partition on this string property's range instead of __key__ (requires -shards > 1)")
+	flag.Parse()
+
+	if *projectID == "" || *kind == "" || flag.NArg() < 1 {
+		log.Fatal("usage: datastore-admin -project PROJECT -kind KIND [-filter ...] [-key-prefix P] <count|delete|export|copy> [args]")
+	}
+
+	ctx := context.Background()
+	client, err := datastore.NewClient(ctx, *projectID)
+	if err != nil {
+		log.Fatalf("Failed to create datastore client: %v", err)
+	}
+	defer client.Close()
+
+	query, err := buildQuery(*kind, where, *keyPrefix)
+	if err != nil {
+		log.Fatalf("Invalid filter: %v", err)
+	}
+	queries, err := partitionQueries(query, *kind, *partitionProperty, *keyPrefix, *shards)
+	if err != nil {
+		log.Fatalf("Invalid partitioning: %v", err)
+	}
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	switch command {
+	case "count":
+		err = count(ctx, client, queries, *workers)
+	case "delete":
+		err = deleteKind(ctx, client, queries, *workers, args)
+	case "export":
+		err = export(ctx, client, queries, *workers, args)
+	case "copy":
+		err = copyKind(ctx, client, queries, *workers, args)
+	default:
+		log.Fatalf("Unknown command %q", command)
+	}
+	if err != nil {
+		log.Fatalf("%s failed: %v", command, err)
+	}
+}
+
+// buildQuery applies the property predicates and key prefix to a query
+// over kind, so commands can operate on stale subsets (e.g. the
+// RepoIndex entries of one decommissioned repo) instead of a whole kind.
+func buildQuery(kind string, where filters, keyPrefix string) (*datastore.Query, error) {
+	query := datastore.NewQuery(kind)
+	for _, f := range where {
+		parts := strings.SplitN(f, " ", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("filter %q is not of the form 'property op value'", f)
+		}
+		prop, op, raw := parts[0], parts[1], parts[2]
+		switch op {
+		case "=", "<", "<=", ">", ">=", "!=":
+		default:
+			return nil, fmt.Errorf("filter %q has unsupported operator %q", f, op)
+		}
+		query = query.FilterField(prop, op, parseValue(raw))
+	}
+	if keyPrefix != "" {
+		// Key names starting with the prefix form one contiguous __key__
+		// range.
+		query = query.FilterField("__key__", ">=", datastore.NameKey(kind, keyPrefix, nil)).
+			FilterField("__key__", "<", datastore.NameKey(kind, keyPrefix+"\xff", nil))
+	}
+
+	return query, nil
+}
+
+// partitionQueries splits the query into shards of roughly equal
+// keyspace, walked by concurrent producers. Boundaries are single bytes
+// spread evenly across 0x00–0xff, applied as range filters on the
+// partition property when one is given, and otherwise on __key__ (the
+// fallback for kinds without a suitable string property). Byte
+// boundaries are uneven for skewed data but need no knowledge of the
+// value format — unlike assuming, say, hex commit hashes.
+func partitionQueries(query *datastore.Query, kind, prop, keyPrefix string, shards int) ([]*datastore.Query, error) {
+	if shards < 1 || shards > 256 {
+		return nil, fmt.Errorf("-shards must be between 1 and 256, got %d", shards)
+	}
+	if shards == 1 {
+		return []*datastore.Query{query}, nil
+	}
+
+	queries := make([]*datastore.Query, 0, shards)
+	for i := 0; i < shards; i++ {
+		q := query
+		if i > 0 {
+			bound := string(byte(i * 256 / shards))
+			if prop != "" {
+				q = q.FilterField(prop, ">=", bound)
+			} else {
+				q = q.FilterField("__key__", ">=", datastore.NameKey(kind, keyPrefix+bound, nil))
+			}
+		}
+		if i < shards-1 {
+			bound := string(byte((i + 1) * 256 / shards))
+			if prop != "" {
+				q = q.FilterField(prop, "<", bound)
+			} else {
+				q = q.FilterField("__key__", "<", datastore.NameKey(kind, keyPrefix+bound, nil))
+			}
+		}
+		queries = append(queries, q)
+	}
+
+	return queries, nil
+}
+
+// parseValue converts a filter value to the most specific type it parses
+// as, matching how the indexer stores its properties.
+func parseValue(raw string) interface{} {
+	if b, err := strconv.ParseBool(raw); err == nil {
+		return b
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t
+	}
+
+	return raw
+}
+
+// forEachBatch streams the queries' entities (keys only when
+// opts.keysOnly is set) in batches to a pool of workers. One producer
+// per query walks its slice of the keyspace in __key__ order with a
+// streaming query; workers run fn concurrently, and the first error
+// cancels the run.
+func forEachBatch(ctx context.Context, client *datastore.Client, queries []*datastore.Query, workers int, opts iterOptions, fn func(ctx context.Context, b batch) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan batch, workers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range batches {
+				if err := fn(ctx, b); err != nil {
+					fail(err)
+					return
+				}
+				if opts.done != nil {
+					opts.done(b)
+				}
+			}
+		}()
+	}
+
+	var producers sync.WaitGroup
+	for shard, query := range queries {
+		query = query.Order("__key__")
+		if opts.keysOnly {
+			query = query.KeysOnly()
+		}
+		if shard < len(opts.startCursors) && opts.startCursors[shard] != "" {
+			cursor, err := datastore.DecodeCursor(opts.startCursors[shard])
+			if err != nil {
+				fail(fmt.Errorf("invalid resume cursor for shard %d: %w", shard, err))
+				break
+			}
+			query = query.Start(cursor)
+		}
+		producers.Add(1)
+		go func(shard int, query *datastore.Query) {
+			defer producers.Done()
+			it := client.Run(ctx, query)
+			var current batch
+			seq := 0
+			flush := func() {
+				if len(current.keys) == 0 {
+					return
+				}
+				if cursor, err := it.Cursor(); err == nil {
+					current.cursor = cursor.String()
+				}
+				current.shard = shard
+				current.seq = seq
+				seq++
+				select {
+				case batches <- current:
+				case <-ctx.Done():
+				}
+				current = batch{}
+			}
+			for {
+				var entity datastore.PropertyList
+				key, err := it.Next(&entity)
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					fail(err)
+					break
+				}
+				current.keys = append(current.keys, key)
+				if !opts.keysOnly {
+					current.entities = append(current.entities, entity)
+				}
+				if len(current.keys) == batchSize {
+					flush()
+				}
+				if ctx.Err() != nil {
+					break
+				}
+			}
+			flush()
+		}(shard, query)
+	}
+	producers.Wait()
+	close(batches)
+	wg.Wait()
+
+	return firstErr
+}
+
+// throttle adaptively paces mutations: contention or quota errors
+// double the delay before the next batch, successes decay it back to
+// zero, so the tool finds the rate Datastore sustains instead of
+// hammering it with a fixed sleep.
+type throttle struct {
+	mu    sync.Mutex
+	delay time.Duration
+}
+
+const (
+	minThrottle = 100 * time.Millisecond
+	maxThrottle = 30 * time.Second
+)
+
+// wait sleeps for the current delay.
+func (t *throttle) wait(ctx context.Context) {
+	t.mu.Lock()
+	delay := t.delay
+	t.mu.Unlock()
+	if delay == 0 {
+		return
+	}
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+	}
+}
+
+func (t *throttle) backoff() {
+	t.mu.Lock()
+	t.delay *= 2
+	if t.delay < minThrottle {
+		t.delay = minThrottle
+	}
+	if t.delay > maxThrottle {
+		t.delay = maxThrottle
+	}
+	t.mu.Unlock()
+}
+
+func (t *throttle) success() {
+	t.mu.Lock()
+	t.delay /= 2
+	if t.delay < minThrottle {
+		t.delay = 0
+	}
+	t.mu.Unlock()
+}
+
+// retryable reports whether err indicates Datastore contention or quota
+// exhaustion, i.e. backing off and retrying the batch may succeed.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Aborted, codes.ResourceExhausted, codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+
+	return false
+}
+
+// progress persists a resume cursor. Workers complete batches out of
+// order, so it only advances the persisted cursor along the contiguous
+// prefix of completed batches: resuming from it may redo a few batches
+// but never skips one.
+type progress struct {
+	mu      sync.Mutex
+	path    string
+	next    int
+	pending map[int]string
+}
+
+func newProgress(path string) *progress {
+	return &progress{path: path, pending: map[int]string{}}
+}
+
+// load returns the previously persisted cursor, if any.
+func (p *progress) load() (string, error) {
+	data, err := os.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// record marks batch seq as complete and persists the newest cursor
+// all of whose predecessors are complete.
+func (p *progress) record(seq int, cursor string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[seq] = cursor
+	persist := ""
+	for {
+		c, ok := p.pending[p.next]
+		if !ok {
+			break
+		}
+		delete(p.pending, p.next)
+		p.next++
+		persist = c
+	}
+	if persist == "" {
+		return
+	}
+	if err := os.WriteFile(p.path, []byte(persist+"\n"), 0644); err != nil {
+		log.Printf("Persisting resume cursor failed: %v", err)
+	}
+}
+
+// clear removes the cursor file after a clean run.
+func (p *progress) clear() {
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		log.Printf("Removing resume cursor file failed: %v", err)
+	}
+}
+
+// count prints the number of matching entities.
+func count(ctx context.Context, client *datastore.Client, queries []*datastore.Query, workers int) error {
+	var total int64
+	err := forEachBatch(ctx, client, queries, workers, iterOptions{keysOnly: true}, func(_ context.Context, b batch) error {
+		atomic.AddInt64(&total, int64(len(b.keys)))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(total)
+
+	return nil
+}
+
+// sampleSize is the number of matching keys a dry run prints.
+const sampleSize = 10
+
+// deleteKind removes every matching entity. -dry-run only counts and
+// samples what would be deleted; -audit-log records every deleted key
+// (with an entity backup when -backup is set) so a deletion can be
+// reviewed afterwards and restored via import of the backup.
+func deleteKind(ctx context.Context, client *datastore.Client, queries []*datastore.Query, workers int, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "count and sample matching keys without deleting")
+	auditLog := fs.String("audit-log", "", "append one JSON line per deleted entity to this file")
+	backup := fs.Bool("backup", false, "include the full entity in each audit log line")
+	resume := fs.String("resume", "", "persist a resume cursor to this file and continue an interrupted run from it (the query must be unchanged between runs)")
+	fs.Parse(args)
+	if *backup && *auditLog == "" {
+		return fmt.Errorf("-backup requires -audit-log")
+	}
+
+	if *dryRun {
+		var total int64
+		var mu sync.Mutex
+		var sample []string
+		err := forEachBatch(ctx, client, queries, workers, iterOptions{keysOnly: true}, func(_ context.Context, b batch) error {
+			atomic.AddInt64(&total, int64(len(b.keys)))
+			mu.Lock()
+			for _, key := range b.keys {
+				if len(sample) == sampleSize {
+					break
+				}
+				sample = append(sample, key.String())
+			}
+			mu.Unlock()
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("Would delete %d entities", total)
+		for _, key := range sample {
+			log.Printf("  %s", key)
+		}
+
+		return nil
+	}
+
+	var audit *json.Encoder
+	var auditMu sync.Mutex
+	if *auditLog != "" {
+		f, err := os.OpenFile(*auditLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		audit = json.NewEncoder(f)
+	}
+
+	opts := iterOptions{keysOnly: !*backup}
+	var progs []*progress
+	if *resume != "" {
+		// One cursor file per shard; a single shard keeps the plain path.
+		progs = make([]*progress, len(queries))
+		opts.startCursors = make([]string, len(queries))
+		for i := range queries {
+			path := *resume
+			if len(queries) > 1 {
+				path = fmt.Sprintf("%s.%d", *resume, i)
+			}
+			progs[i] = newProgress(path)
+			cursor, err := progs[i].load()
+			if err != nil {
+				return err
+			}
+			if cursor != "" {
+				log.Printf("Resuming shard %d from cursor in %s", i, path)
+				opts.startCursors[i] = cursor
+			}
+		}
+		opts.done = func(b batch) { progs[b.shard].record(b.seq, b.cursor) }
+	}
+
+	var rate throttle
+	var deleted int64
+	err := forEachBatch(ctx, client, queries, workers, opts, func(ctx context.Context, b batch) error {
+		// Log before deleting: on a failure the log may list keys that
+		// survived, but it never misses one that was deleted.
+		if audit != nil {
+			auditMu.Lock()
+			for i, key := range b.keys {
+				row := map[string]interface{}{"key": key.String(), "deleted_at": time.Now().UTC().Format(time.RFC3339)}
+				if *backup {
+					entity := map[string]interface{}{}
+					for _, prop := range b.entities[i] {
+						entity[prop.Name] = prop.Value
+					}
+					row["entity"] = entity
+				}
+				if err := audit.Encode(row); err != nil {
+					auditMu.Unlock()
+					return err
+				}
+			}
+			auditMu.Unlock()
+		}
+		for {
+			rate.wait(ctx)
+			err := client.DeleteMulti(ctx, b.keys)
+			if err == nil {
+				break
+			}
+			if !retryable(err) || ctx.Err() != nil {
+				return err
+			}
+			rate.backoff()
+			log.Printf("Backing off after %v", err)
+		}
+		rate.success()
+		atomic.AddInt64(&deleted, int64(len(b.keys)))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, prog := range progs {
+		prog.clear()
+	}
+	log.Printf("Deleted %d entities", deleted)
+
+	return nil
+}
+
+// export writes every matching entity as newline-delimited JSON.
+func export(ctx context.Context, client *datastore.Client, queries []*datastore.Query, workers int, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	out := fs.String("out", "", "output file (defaults to stdout)")
+	fs.Parse(args)
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+
+	return forEachBatch(ctx, client, queries, workers, iterOptions{}, func(_ context.Context, b batch) error {
+		for i, entity := range b.entities {
+			row := map[string]interface{}{"key": b.keys[i].String()}
+			for _, prop := range entity {
+				row[prop.Name] = prop.Value
+			}
+			mu.Lock()
+			err := enc.Encode(row)
+			mu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// copyKind writes every matching entity, under the same keys, into
+// another project's Datastore.
+func copyKind(ctx context.Context, client *datastore.Client, queries []*datastore.Query, workers int, args []string) error {
+	fs := flag.NewFlagSet("copy", flag.ExitOnError)
+	toProject := fs.String("to-project", "", "destination GCP project ID")
+	fs.Parse(args)
+	if *toProject == "" {
+		return fmt.Errorf("copy requires -to-project")
+	}
+	dst, err := datastore.NewClient(ctx, *toProject)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	var copied int64
+	err = forEachBatch(ctx, client, queries, workers, iterOptions{}, func(ctx context.Context, b batch) error {
+		entities := make([]*datastore.PropertyList, len(b.entities))
+		for i := range b.entities {
+			entities[i] = &b.entities[i]
+		}
+		if _, err := dst.PutMulti(ctx, b.keys, entities); err != nil {
+			return err
+		}
+		atomic.AddInt64(&copied, int64(len(b.keys)))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Copied %d entities to %s", copied, *toProject)
+
+	return nil
+}
@@ -0,0 +1,10 @@
+module github.com/google/osv/tools/osv-lint
+
+go 1.22
+
+require (
+	github.com/google/osv/go/osvschema v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/google/osv/go/osvschema => ../../go/osvschema
@@ -0,0 +1,273 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// osv-lint validates OSV advisory files before publication: schema
+// invariants, per-prefix ID formats, event ordering inside ranges and,
+// optionally, that referenced package versions exist in the upstream
+// registry (via deps.dev). It prints one finding per line and exits
+// non-zero if any file fails.
+//
+// Usage:
+//
+//	osv-lint [-online] <file-or-directory>...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+var online = flag.Bool("online", false, "verify affected versions exist in the upstream registry via deps.dev")
+
+const depsDevAPI = "https://api.deps.dev/v3alpha"
+
+// idPatterns holds the exact ID format required by each known database
+// prefix. IDs with other prefixes only need to satisfy the schema's
+// generic format.
+var idPatterns = map[string]*regexp.Regexp{
+	"CVE":     regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`),
+	"GHSA":    regexp.MustCompile(`^GHSA(-[23456789cfghjmpqrvwx]{4}){3}$`),
+	"PYSEC":   regexp.MustCompile(`^PYSEC-\d{4}-\d+$`),
+	"GO":      regexp.MustCompile(`^GO-\d{4}-\d+$`),
+	"RUSTSEC": regexp.MustCompile(`^RUSTSEC-\d{4}-\d{4}$`),
+	"OSV":     regexp.MustCompile(`^OSV-(\d{4}-)?\d+$`),
+	"DSA":     regexp.MustCompile(`^DSA-\d+(-\d+)?$`),
+	"DLA":     regexp.MustCompile(`^DLA-\d+(-\d+)?$`),
+	"USN":     regexp.MustCompile(`^USN-\d+-\d+$`),
+}
+
+// depsDevSystems maps OSV ecosystems to the deps.dev system names used
+// for version existence checks.
+var depsDevSystems = map[string]string{
+	"npm":       "npm",
+	"Maven":     "maven",
+	"PyPI":      "pypi",
+	"crates.io": "cargo",
+	"Go":        "go",
+	"NuGet":     "nuget",
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, arg := range flag.Args() {
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !isAdvisoryFile(path) {
+				return nil
+			}
+			for _, finding := range lintFile(path) {
+				failed = true
+				fmt.Printf("%s: %s\n", path, finding)
+			}
+
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", arg, err)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func isAdvisoryFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// lintFile returns every finding for one advisory file.
+func lintFile(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	vuln, err := decode(path, data)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var findings []string
+	if err := vuln.Validate(); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			findings = append(findings, line)
+		}
+	}
+	if prefix, _, ok := strings.Cut(vuln.ID, "-"); ok {
+		if pattern, known := idPatterns[prefix]; known && !pattern.MatchString(vuln.ID) {
+			findings = append(findings, fmt.Sprintf("id %q does not match the %s format %s", vuln.ID, prefix, pattern))
+		}
+	}
+	for i, affected := range vuln.Affected {
+		for j, rng := range affected.Ranges {
+			findings = append(findings, lintEventOrder(i, j, rng)...)
+		}
+		if *online {
+			findings = append(findings, checkVersionsExist(affected)...)
+		}
+	}
+
+	return findings
+}
+
+// decode parses JSON or YAML into the typed model. YAML goes through a
+// JSON round trip so both formats hit the same field mapping.
+func decode(path string, data []byte) (*osvschema.Vulnerability, error) {
+	var vuln osvschema.Vulnerability
+	if filepath.Ext(path) == ".json" {
+		if err := json.Unmarshal(data, &vuln); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		return &vuln, nil
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(jsonData, &vuln); err != nil {
+		return nil, fmt.Errorf("invalid advisory: %w", err)
+	}
+
+	return &vuln, nil
+}
+
+// lintEventOrder checks that SEMVER range events do not go backwards:
+// each introduced must precede the following fixed/last_affected.
+// ECOSYSTEM ranges are skipped, as their ordering rules are not known
+// here.
+func lintEventOrder(affectedIdx, rangeIdx int, rng osvschema.Range) []string {
+	if rng.Type != osvschema.RangeSemVer {
+		return nil
+	}
+	at := fmt.Sprintf("affected[%d].ranges[%d]", affectedIdx, rangeIdx)
+	var findings []string
+	last := ""
+	for k, event := range rng.Events {
+		version := event.Introduced
+		if version == "" {
+			version = event.Fixed
+		}
+		if version == "" {
+			version = event.LastAffected
+		}
+		if version == "" || version == "0" {
+			continue
+		}
+		if last != "" && semverCompare(version, last) < 0 {
+			findings = append(findings, fmt.Sprintf("%s.events[%d]: %q sorts before the preceding event %q", at, k, version, last))
+		}
+		last = version
+	}
+
+	return findings
+}
+
+// semverCompare orders two semver strings by their numeric core; that is
+// enough to catch reversed events without pulling in full precedence.
+func semverCompare(a, b string) int {
+	aParts := numericCore(a)
+	bParts := numericCore(b)
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var av, bv int
+		if i < len(aParts) {
+			av = aParts[i]
+		}
+		if i < len(bParts) {
+			bv = bParts[i]
+		}
+		if av != bv {
+			if av < bv {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func numericCore(s string) []int {
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "-")
+	s, _, _ = strings.Cut(s, "+")
+	var parts []int
+	for _, part := range strings.Split(s, ".") {
+		n := 0
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return parts
+			}
+			n = n*10 + int(r-'0')
+		}
+		parts = append(parts, n)
+	}
+
+	return parts
+}
+
+// checkVersionsExist queries deps.dev for each explicitly listed version
+// of an affected package.
+func checkVersionsExist(affected osvschema.Affected) []string {
+	system, ok := depsDevSystems[affected.Package.Ecosystem]
+	if !ok {
+		return nil
+	}
+	var findings []string
+	for _, version := range affected.Versions {
+		reqURL := fmt.Sprintf("%s/systems/%s/packages/%s/versions/%s",
+			depsDevAPI, system, url.PathEscape(affected.Package.Name), url.PathEscape(version))
+		resp, err := http.Get(reqURL)
+		if err != nil {
+			findings = append(findings, fmt.Sprintf("could not verify %s %s: %v", affected.Package.Name, version, err))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			findings = append(findings, fmt.Sprintf("version %s of %s does not exist in the %s registry", version, affected.Package.Name, affected.Package.Ecosystem))
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attest wraps scan reports in in-toto statements and signs
+// them keylessly via Sigstore, so downstream policy engines can verify
+// that an artifact was scanned and by which scanner version.
+package attest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/sigstore/sigstore-go/pkg/sign"
+	"github.com/sigstore/sigstore/pkg/oauthflow"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// statementType is the in-toto statement framework version.
+	statementType = "https://in-toto.io/Statement/v1"
+	// PredicateType identifies the predicate as an osv-scanner report.
+	PredicateType = "https://osv.dev/attestation/scan/v1"
+
+	fulcioURL  = "https://fulcio.sigstore.dev"
+	rekorURL   = "https://rekor.sigstore.dev"
+	oidcIssuer = "https://oauth2.sigstore.dev/auth"
+)
+
+// statement is an in-toto statement with the scan report as predicate.
+type statement struct {
+	Type          string          `json:"_type"`
+	Subject       []subject       `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// subject names the report being attested by its digest.
+type subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Attest wraps the JSON report in an in-toto statement and signs it
+// keylessly: an ephemeral key is certified by Fulcio against the
+// signer's OIDC identity and the signature is logged in Rekor. The
+// identity token is taken from the SIGSTORE_ID_TOKEN environment
+// variable (as ambient CI credentials usually are), falling back to an
+// interactive browser flow. The returned bytes are a serialized
+// Sigstore bundle, verifiable with standard Sigstore tooling.
+func Attest(ctx context.Context, report []byte, name string) ([]byte, error) {
+	digest := sha256.Sum256(report)
+	payload, err := json.Marshal(statement{
+		Type: statementType,
+		Subject: []subject{{
+			Name:   name,
+			Digest: map[string]string{"sha256": hex.EncodeToString(digest[:])},
+		}},
+		PredicateType: PredicateType,
+		Predicate:     report,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token := os.Getenv("SIGSTORE_ID_TOKEN")
+	if token == "" {
+		idToken, err := oauthflow.OIDConnect(oidcIssuer, "sigstore", "", "", oauthflow.DefaultIDTokenGetter)
+		if err != nil {
+			return nil, err
+		}
+		token = idToken.RawString
+	}
+
+	keypair, err := sign.NewEphemeralKeypair(nil)
+	if err != nil {
+		return nil, err
+	}
+	content := &sign.DSSEData{
+		Data:        payload,
+		PayloadType: "application/vnd.in-toto+json",
+	}
+	opts := sign.BundleOptions{
+		CertificateProvider: sign.NewFulcio(&sign.FulcioOptions{
+			BaseURL: fulcioURL,
+			Timeout: 30 * time.Second,
+			Retries: 1,
+		}),
+		CertificateProviderOptions: &sign.CertificateProviderOptions{
+			IDToken: token,
+		},
+		TransparencyLogs: []sign.Transparency{sign.NewRekor(&sign.RekorOptions{
+			BaseURL: rekorURL,
+			Timeout: 90 * time.Second,
+			Retries: 1,
+		})},
+		Context: ctx,
+	}
+	bundle, err := sign.Bundle(content, keypair, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(bundle)
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package badge renders a scan's outcome as a status badge, either as a
+// self-contained SVG shield or as a shields.io endpoint JSON document, for
+// embedding in READMEs and internal portals.
+package badge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// summary tallies distinct vulnerability groups by level.
+type summary struct {
+	counts map[severity.Level]int
+	total  int
+}
+
+func summarize(results *models.VulnerabilityResults) summary {
+	s := summary{counts: make(map[severity.Level]int)}
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			_, level := severity.MaxOf(pkg.Vulnerabilities)
+			s.counts[level] += len(pkg.Groups)
+			s.total += len(pkg.Groups)
+		}
+	}
+
+	return s
+}
+
+// message renders e.g. "2 critical, 5 high" or "no known vulnerabilities".
+func (s summary) message() string {
+	if s.total == 0 {
+		return "no known vulnerabilities"
+	}
+	var parts []string
+	for _, level := range []severity.Level{
+		severity.LevelCritical, severity.LevelHigh, severity.LevelMedium, severity.LevelLow, severity.LevelUnknown,
+	} {
+		if n := s.counts[level]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, strings.ToLower(level.String())))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// color returns the shield color for the worst present level.
+func (s summary) color() string {
+	switch {
+	case s.counts[severity.LevelCritical] > 0:
+		return "#e05d44" // red
+	case s.counts[severity.LevelHigh] > 0:
+		return "#fe7d37" // orange
+	case s.counts[severity.LevelMedium] > 0:
+		return "#dfb317" // yellow
+	case s.total > 0:
+		return "#a4a61d" // yellowgreen
+	default:
+		return "#4c1" // brightgreen
+	}
+}
+
+// GenerateSVG renders results as a flat shields-style SVG badge.
+func GenerateSVG(results *models.VulnerabilityResults) []byte {
+	const label = "osv-scanner"
+	s := summarize(results)
+	msg := s.message()
+
+	// Approximate text width; the shields flat style uses ~6.5px per
+	// character of Verdana 11px.
+	labelWidth := 7*len(label) + 10
+	msgWidth := 7*len(msg) + 10
+	width := labelWidth + msgWidth
+
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <rect width="%d" height="20" fill="#555"/>
+  <rect x="%d" width="%d" height="20" fill="%s"/>
+  <rect width="%d" height="20" fill="url(#s)"/>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%d" y="14">%s</text>
+    <text x="%d" y="14">%s</text>
+  </g>
+</svg>
+`,
+		width, label, msg,
+		labelWidth,
+		labelWidth, msgWidth, s.color(),
+		width,
+		labelWidth/2, label,
+		labelWidth+msgWidth/2, msg)
+
+	return []byte(svg)
+}
+
+// endpointJSON is the shields.io endpoint badge schema.
+type endpointJSON struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// GenerateJSON renders results as a shields.io endpoint JSON document,
+// also served by the scanner's daemon mode.
+func GenerateJSON(results *models.VulnerabilityResults) ([]byte, error) {
+	s := summarize(results)
+
+	return json.MarshalIndent(endpointJSON{
+		SchemaVersion: 1,
+		Label:         "osv-scanner",
+		Message:       s.message(),
+		Color:         s.color(),
+	}, "", "  ")
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench generates a synthetic workload and times the extraction
+// and query phases against an in-process fake API, so parsing and
+// querying performance is measurable release to release without network
+// noise.
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/osvtest"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// Options sizes the synthetic workload and selects profile outputs.
+type Options struct {
+	// Lockfiles and Packages size the workload: Lockfiles project
+	// directories, each with a package-lock.json of Packages entries.
+	Lockfiles int
+	Packages  int
+	// CPUProfile and MemProfile are pprof output paths; empty disables
+	// the profile.
+	CPUProfile string
+	MemProfile string
+}
+
+// vulnerableEvery is the fraction of synthetic packages with a matching
+// advisory, so the query and hydration paths do real work.
+const vulnerableEvery = 20
+
+// Run generates the workload, scans it against a fake API and reports
+// per-phase timings to w.
+func Run(ctx context.Context, opts Options, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "osv-scanner-bench-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err := generate(dir, opts.Lockfiles, opts.Packages); err != nil {
+		return err
+	}
+	defer osvtest.NewServer(fixtures(opts.Packages)...).Install()()
+
+	if opts.CPUProfile != "" {
+		f, err := os.Create(opts.CPUProfile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	start := time.Now()
+	sources, err := scanner.ScanDir(ctx, dir, true)
+	if err != nil {
+		return fmt.Errorf("extraction failed: %w", err)
+	}
+	extractTime := time.Since(start)
+	packages := 0
+	for _, source := range sources {
+		packages += len(source.Packages)
+	}
+
+	start = time.Now()
+	results, err := scanner.QuerySources(ctx, sources)
+	if err != nil {
+		return fmt.Errorf("querying failed: %w", err)
+	}
+	queryTime := time.Since(start)
+	findings := 0
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			findings += len(pkg.Groups)
+		}
+	}
+
+	fmt.Fprintf(w, "extract: %d packages from %d lockfiles in %v (%.0f packages/s)\n",
+		packages, len(sources), extractTime.Round(time.Millisecond), float64(packages)/extractTime.Seconds())
+	fmt.Fprintf(w, "query:   %d findings in %v (%.0f packages/s)\n",
+		findings, queryTime.Round(time.Millisecond), float64(packages)/queryTime.Seconds())
+
+	if opts.MemProfile != "" {
+		f, err := os.Create(opts.MemProfile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generate writes the synthetic project tree: one directory per
+// lockfile, each a v3 package-lock.json listing the same packages.
+func generate(dir string, lockfiles, packages int) error {
+	type lockPackage struct {
+		Version string `json:"version"`
+	}
+	entries := make(map[string]lockPackage, packages)
+	for i := 0; i < packages; i++ {
+		entries[fmt.Sprintf("node_modules/bench-pkg-%d", i)] = lockPackage{Version: benchVersion(i)}
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"lockfileVersion": 3,
+		"packages":        entries,
+	})
+	if err != nil {
+		return err
+	}
+	for i := 0; i < lockfiles; i++ {
+		project := filepath.Join(dir, fmt.Sprintf("project-%d", i))
+		if err := os.MkdirAll(project, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(project, "package-lock.json"), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fixtures builds an advisory for every vulnerableEvery-th synthetic
+// package.
+func fixtures(packages int) []osv.Vulnerability {
+	var vulns []osv.Vulnerability
+	for i := 0; i < packages; i += vulnerableEvery {
+		vulns = append(vulns, osv.Vulnerability{
+			ID:       fmt.Sprintf("BENCH-%d", i),
+			Modified: time.Now().UTC(),
+			Summary:  "synthetic benchmark advisory",
+			Affected: []osv.Affected{{
+				Package:  osv.Package{Name: fmt.Sprintf("bench-pkg-%d", i), Ecosystem: "npm"},
+				Versions: []string{benchVersion(i)},
+			}},
+		})
+	}
+
+	return vulns
+}
+
+// benchVersion is the version of the i-th synthetic package.
+func benchVersion(i int) string {
+	return fmt.Sprintf("1.%d.0", i)
+}
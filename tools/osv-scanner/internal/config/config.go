@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config loads osv-scanner.toml files, merging them hierarchically:
+// a root config's ignores apply to nested projects unless a config closer
+// to the scanned source overrides the same vulnerability ID. Each effective
+// entry remembers the file it came from so verbose output can show where an
+// exception was granted.
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigName is the file name looked up in every directory between the
+// scan root and a scanned source.
+const ConfigName = "osv-scanner.toml"
+
+// IgnoreEntry is one [[IgnoredVulns]] entry of an osv-scanner.toml.
+type IgnoreEntry struct {
+	ID          string    `toml:"id"`
+	IgnoreUntil time.Time `toml:"ignoreUntil"`
+	Reason      string    `toml:"reason"`
+}
+
+// AnnotationEntry is one [[Annotations]] entry of an osv-scanner.toml,
+// mapping a vulnerability ID to organizational tracking metadata.
+type AnnotationEntry struct {
+	ID     string    `toml:"id"`
+	Ticket string    `toml:"ticket"`
+	Owner  string    `toml:"owner"`
+	Due    time.Time `toml:"due"`
+}
+
+type fileConfig struct {
+	IgnoredVulns []IgnoreEntry     `toml:"IgnoredVulns"`
+	Annotations  []AnnotationEntry `toml:"Annotations"`
+}
+
+// ResolvedIgnore is an effective ignore entry together with the config
+// file that supplied it.
+type ResolvedIgnore struct {
+	IgnoreEntry
+	// ConfigPath is the osv-scanner.toml the entry came from.
+	ConfigPath string
+}
+
+// Config is the effective configuration for one scanned source.
+type Config struct {
+	// Ignores maps vulnerability IDs to the winning (nearest) entry.
+	Ignores map[string]ResolvedIgnore
+	// Annotations maps vulnerability IDs to their tracking metadata,
+	// merged with the same nearest-config-wins rule as ignores.
+	Annotations map[string]AnnotationEntry
+	// Paths lists the config files that were merged, root first.
+	Paths []string
+}
+
+// Annotation returns the tracking metadata declared for id, if any.
+func (c *Config) Annotation(id string) (AnnotationEntry, bool) {
+	entry, ok := c.Annotations[id]
+
+	return entry, ok
+}
+
+// ShouldIgnore reports whether id is ignored at time now, returning the
+// entry (with provenance) when it is. Entries with an ignoreUntil in the
+// past have expired.
+func (c *Config) ShouldIgnore(id string, now time.Time) (ResolvedIgnore, bool) {
+	entry, ok := c.Ignores[id]
+	if !ok {
+		return ResolvedIgnore{}, false
+	}
+	if !entry.IgnoreUntil.IsZero() && entry.IgnoreUntil.Before(now) {
+		return ResolvedIgnore{}, false
+	}
+
+	return entry, true
+}
+
+// Manager loads and caches effective configs for scanned sources.
+type Manager struct {
+	// Root bounds the upward search for config files; configs above Root
+	// are not consulted. Empty means stop at the filesystem root.
+	Root string
+
+	fileCache map[string]*fileConfig
+}
+
+// EffectiveConfig returns the merged configuration that applies to the
+// source at path, with entries from configs nearer the source overriding
+// those from configs nearer the root.
+func (m *Manager) EffectiveConfig(path string) (*Config, error) {
+	if m.fileCache == nil {
+		m.fileCache = make(map[string]*fileConfig)
+	}
+
+	// Collect config paths from the source's directory up to Root, then
+	// reverse so the root config is applied first and nearer ones override.
+	var configPaths []string
+	dir := filepath.Dir(path)
+	for {
+		configPaths = append(configPaths, filepath.Join(dir, ConfigName))
+		if m.Root != "" && filepath.Clean(dir) == filepath.Clean(m.Root) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	effective := &Config{
+		Ignores:     make(map[string]ResolvedIgnore),
+		Annotations: make(map[string]AnnotationEntry),
+	}
+	for i := len(configPaths) - 1; i >= 0; i-- {
+		configPath := configPaths[i]
+		cfg, err := m.loadFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			continue
+		}
+		effective.Paths = append(effective.Paths, configPath)
+		for _, entry := range cfg.IgnoredVulns {
+			effective.Ignores[entry.ID] = ResolvedIgnore{IgnoreEntry: entry, ConfigPath: configPath}
+		}
+		for _, entry := range cfg.Annotations {
+			effective.Annotations[entry.ID] = entry
+		}
+	}
+
+	return effective, nil
+}
+
+// loadFile parses the config at configPath, returning (nil, nil) when the
+// file does not exist.
+func (m *Manager) loadFile(configPath string) (*fileConfig, error) {
+	if cfg, ok := m.fileCache[configPath]; ok {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			m.fileCache[configPath] = nil
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	var cfg fileConfig
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	m.fileCache[configPath] = &cfg
+
+	return &cfg, nil
+}
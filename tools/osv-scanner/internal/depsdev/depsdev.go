@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package depsdev is a client for the deps.dev API, used to annotate
+// findings with dependent counts, OpenSSF Scorecard scores and latest
+// versions — signals for prioritizing fixes by blast radius and for
+// gauging the health of upgrade targets.
+package depsdev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// apiBase is the base URL of the deps.dev HTTP API.
+const apiBase = "https://api.deps.dev/v3alpha"
+
+// Client queries the deps.dev API.
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+// NewClient returns a client for the public deps.dev API.
+func NewClient() *Client {
+	return &Client{BaseURL: apiBase, HTTP: http.DefaultClient}
+}
+
+// system maps an OSV ecosystem to its deps.dev system name.
+func system(ecosystem string) (string, error) {
+	name := map[string]string{
+		"npm":       "npm",
+		"Maven":     "maven",
+		"PyPI":      "pypi",
+		"Go":        "go",
+		"crates.io": "cargo",
+		"NuGet":     "nuget",
+		"RubyGems":  "rubygems",
+	}[ecosystem]
+	if name == "" {
+		return "", fmt.Errorf("ecosystem %q is not covered by deps.dev", ecosystem)
+	}
+
+	return name, nil
+}
+
+// get fetches path relative to the API base and decodes the JSON
+// response into out.
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("deps.dev returned %s for %s", resp.Status, path)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Info assembles the deps.dev annotations for one package version. Each
+// signal is fetched independently and missing ones are simply left
+// zero, so partial data (e.g. a package without a known source project)
+// still enriches.
+func (c *Client) Info(ctx context.Context, ecosystem, name, version string) (*models.DepsDevInfo, error) {
+	sys, err := system(ecosystem)
+	if err != nil {
+		return nil, err
+	}
+	pkgPath := fmt.Sprintf("/systems/%s/packages/%s", sys, url.PathEscape(name))
+	versionPath := fmt.Sprintf("%s/versions/%s", pkgPath, url.PathEscape(version))
+	info := &models.DepsDevInfo{}
+
+	var pkg struct {
+		Versions []struct {
+			VersionKey struct {
+				Version string `json:"version"`
+			} `json:"versionKey"`
+			IsDefault bool `json:"isDefault"`
+		} `json:"versions"`
+	}
+	if err := c.get(ctx, pkgPath, &pkg); err != nil {
+		return nil, err
+	}
+	for _, v := range pkg.Versions {
+		if v.IsDefault {
+			info.LatestVersion = v.VersionKey.Version
+			break
+		}
+	}
+
+	var dependents struct {
+		DependentCount int64 `json:"dependentCount"`
+	}
+	if err := c.get(ctx, versionPath+":dependents", &dependents); err == nil {
+		info.DependentCount = dependents.DependentCount
+	}
+
+	var ver struct {
+		RelatedProjects []struct {
+			ProjectKey struct {
+				ID string `json:"id"`
+			} `json:"projectKey"`
+		} `json:"relatedProjects"`
+	}
+	if err := c.get(ctx, versionPath, &ver); err == nil && len(ver.RelatedProjects) > 0 {
+		var project struct {
+			Scorecard struct {
+				OverallScore float64 `json:"overallScore"`
+			} `json:"scorecard"`
+		}
+		projectPath := "/projects/" + url.PathEscape(ver.RelatedProjects[0].ProjectKey.ID)
+		if err := c.get(ctx, projectPath, &project); err == nil {
+			info.ScorecardScore = project.Scorecard.OverallScore
+		}
+	}
+
+	return info, nil
+}
+
+// Annotate fills in the DepsDev field of every vulnerable package in
+// results. Lookups are deduplicated across sources; packages deps.dev
+// does not know are skipped with a log line rather than failing the
+// scan.
+func Annotate(ctx context.Context, results *models.VulnerabilityResults) error {
+	client := NewClient()
+	cache := make(map[string]*models.DepsDevInfo)
+	for i := range results.Results {
+		for j := range results.Results[i].Packages {
+			pkg := &results.Results[i].Packages[j]
+			if pkg.Package.Name == "" || pkg.Package.Version == "" {
+				continue
+			}
+			key := pkg.Package.Ecosystem + "/" + pkg.Package.Name + "@" + pkg.Package.Version
+			info, ok := cache[key]
+			if !ok {
+				var err error
+				info, err = client.Info(ctx, pkg.Package.Ecosystem, pkg.Package.Name, pkg.Package.Version)
+				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					log.Printf("deps.dev data unavailable for %s: %v", key, err)
+				}
+				cache[key] = info
+			}
+			pkg.DepsDev = info
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enrich annotates scan results with exploitability data: FIRST
+// EPSS exploit-probability scores and CISA KEV (Known Exploited
+// Vulnerabilities) catalog membership.
+//
+// Both datasets are fetched over HTTPS and cached on disk for cacheTTL, so
+// repeated scans (and offline re-runs within the window) do not re-download
+// them.
+package enrich
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+const (
+	epssURL = "https://epss.cyentia.com/epss_scores-current.csv.gz"
+	kevURL  = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+
+	cacheTTL = 24 * time.Hour
+)
+
+// Enrich fills in the EPSS and KEV fields of every vulnerability group in
+// results. cacheDir is created if needed; pass "" to use the user cache
+// directory.
+func Enrich(ctx context.Context, results *models.VulnerabilityResults, cacheDir string) error {
+	if cacheDir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return err
+		}
+		cacheDir = filepath.Join(userCache, "osv-scanner")
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return err
+	}
+
+	epss, err := loadEPSS(ctx, cacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to load EPSS scores: %w", err)
+	}
+	kev, err := loadKEV(ctx, cacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to load KEV catalog: %w", err)
+	}
+
+	for i := range results.Results {
+		for j := range results.Results[i].Packages {
+			pkg := &results.Results[i].Packages[j]
+			for k := range pkg.Groups {
+				group := &pkg.Groups[k]
+				// EPSS and KEV are keyed by CVE ID; a group's aliases
+				// include any CVEs for the weakness.
+				for _, id := range group.IDs {
+					if !strings.HasPrefix(id, "CVE-") {
+						continue
+					}
+					if score, ok := epss[id]; ok && score > group.EPSS {
+						group.EPSS = score
+					}
+					if _, ok := kev[id]; ok {
+						group.KEV = true
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// cachedFetch returns the contents of url, reusing the copy cached under
+// name in cacheDir if it is younger than cacheTTL.
+func cachedFetch(ctx context.Context, cacheDir, name, url string) ([]byte, error) {
+	path := filepath.Join(cacheDir, name)
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < cacheTTL {
+		return os.ReadFile(path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// Fall back to a stale cache rather than failing when offline.
+		if data, readErr := os.ReadFile(path); readErr == nil {
+			return data, nil
+		}
+
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// loadEPSS returns the EPSS probability for every scored CVE.
+func loadEPSS(ctx context.Context, cacheDir string) (map[string]float64, error) {
+	data, err := cachedFetch(ctx, cacheDir, "epss_scores.csv.gz", epssURL)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	r := csv.NewReader(gz)
+	// The file starts with a "#model_version:..." comment line.
+	r.Comment = '#'
+	scores := make(map[string]float64)
+	header := true
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header {
+			header = false
+			continue
+		}
+		if len(record) < 2 {
+			continue
+		}
+		score, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			continue
+		}
+		scores[record[0]] = score
+	}
+
+	return scores, nil
+}
+
+// loadKEV returns the set of CVE IDs in the CISA KEV catalog.
+func loadKEV(ctx context.Context, cacheDir string) (map[string]struct{}, error) {
+	data, err := cachedFetch(ctx, cacheDir, "known_exploited_vulnerabilities.json", kevURL)
+	if err != nil {
+		return nil, err
+	}
+	var catalog struct {
+		Vulnerabilities []struct {
+			CVEID string `json:"cveID"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, err
+	}
+	kev := make(map[string]struct{}, len(catalog.Vulnerabilities))
+	for _, vuln := range catalog.Vulnerabilities {
+		kev[vuln.CVEID] = struct{}{}
+	}
+
+	return kev, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package extractor defines the interface for package extractors and a
+// registry so that support for additional manifest formats (including
+// proprietary ones) can be plugged in without modifying the scanner.
+package extractor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// Extractor detects and extracts packages from a manifest or lockfile.
+//
+// Implementations must be safe for concurrent use.
+type Extractor interface {
+	// Name is a unique, stable identifier for the extractor, used in
+	// verbose output and configuration.
+	Name() string
+	// Detect reports whether the file at path looks like a format this
+	// extractor can handle. It should be cheap and must not read the file.
+	Detect(path string) bool
+	// Extract extracts the packages declared by the file at path. r is
+	// positioned at the start of the file's contents.
+	Extract(path string, r io.Reader) ([]models.PackageInfo, error)
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Extractor{}
+)
+
+// Register makes an extractor available to the scanner. It panics if an
+// extractor with the same name is already registered, mirroring
+// database/sql.Register.
+func Register(e Extractor) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := registry[e.Name()]; dup {
+		panic(fmt.Sprintf("extractor: Register called twice for %q", e.Name()))
+	}
+	registry[e.Name()] = e
+}
+
+// ForPath returns the first registered extractor (in name order, for
+// determinism) that detects the file at path.
+func ForPath(path string) (Extractor, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, name := range sortedNames() {
+		if e := registry[name]; e.Detect(path) {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
+
+// All returns all registered extractors in name order.
+func All() []Extractor {
+	mu.RLock()
+	defer mu.RUnlock()
+	extractors := make([]Extractor, 0, len(registry))
+	for _, name := range sortedNames() {
+		extractors = append(extractors, registry[name])
+	}
+
+	return extractors
+}
+
+// sortedNames returns registry keys in order. Callers must hold mu.
+func sortedNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package feed tails modified OSV records and invokes a callback for
+// each new or updated advisory — the building block for notification
+// services and mirrors. The tailer polls the database exports through
+// the incremental download client, so an unchanged ecosystem costs one
+// conditional request per poll, and tracks a modification watermark per
+// ecosystem so each record version is delivered once.
+package feed
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// Handler receives one new or updated advisory.
+type Handler func(vuln *osv.Vulnerability)
+
+// Tailer polls the exports of a set of ecosystems for modified records.
+type Tailer struct {
+	// Ecosystems names the export feeds to tail, e.g. "PyPI", "npm".
+	Ecosystems []string
+	// Dir is where exports are synced; reusing it across runs carries
+	// the incremental download state over.
+	Dir string
+	// Interval is the time between polls.
+	Interval time.Duration
+	// Since suppresses records last modified before it on the first
+	// poll. The zero value delivers the whole backlog.
+	Since time.Time
+
+	// watermark is the latest modification time delivered per
+	// ecosystem; atWatermark holds the IDs already delivered at exactly
+	// that time, since several records may share it.
+	watermark   map[string]time.Time
+	atWatermark map[string]map[string]bool
+}
+
+// Run polls until ctx is cancelled, invoking handler for every new or
+// updated advisory. The first poll runs immediately. Per-ecosystem
+// failures are logged and retried next poll.
+func (t *Tailer) Run(ctx context.Context, handler Handler) error {
+	if len(t.Ecosystems) == 0 {
+		return fmt.Errorf("feed: no ecosystems to tail")
+	}
+	if t.Interval <= 0 {
+		return fmt.Errorf("feed: poll interval must be positive")
+	}
+	t.watermark = make(map[string]time.Time, len(t.Ecosystems))
+	t.atWatermark = make(map[string]map[string]bool, len(t.Ecosystems))
+	for _, ecosystem := range t.Ecosystems {
+		t.watermark[ecosystem] = t.Since
+		t.atWatermark[ecosystem] = make(map[string]bool)
+	}
+
+	ticker := time.NewTicker(t.Interval)
+	defer ticker.Stop()
+	for {
+		for _, ecosystem := range t.Ecosystems {
+			if err := t.poll(ctx, ecosystem, handler); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.Printf("Failed to poll %s feed: %v", ecosystem, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll syncs one ecosystem's export and delivers the records modified
+// past the watermark, in modification order within the poll.
+func (t *Tailer) poll(ctx context.Context, ecosystem string, handler Handler) error {
+	path, err := osv.DownloadEcosystem(ctx, ecosystem, t.Dir)
+	if err != nil {
+		return err
+	}
+
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	mark := t.watermark[ecosystem]
+	delivered := t.atWatermark[ecosystem]
+	newMark := mark
+	newDelivered := make(map[string]bool)
+	for _, file := range r.File {
+		if filepath.Ext(file.Name) != ".json" {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return err
+		}
+		var vuln osv.Vulnerability
+		err = json.NewDecoder(f).Decode(&vuln)
+		f.Close()
+		if err != nil {
+			log.Printf("Skipping invalid record %s in %s export: %v", file.Name, ecosystem, err)
+			continue
+		}
+		if vuln.Modified.Before(mark) {
+			continue
+		}
+		if vuln.Modified.Equal(mark) && delivered[vuln.ID] {
+			// Still at the watermark: remember it so the next poll does
+			// not deliver it again either.
+			if mark.Equal(newMark) {
+				newDelivered[vuln.ID] = true
+			}
+
+			continue
+		}
+		handler(&vuln)
+		switch {
+		case vuln.Modified.After(newMark):
+			newMark = vuln.Modified
+			newDelivered = map[string]bool{vuln.ID: true}
+		case vuln.Modified.Equal(newMark):
+			newDelivered[vuln.ID] = true
+		}
+	}
+	t.watermark[ecosystem] = newMark
+	t.atWatermark[ecosystem] = newDelivered
+
+	return nil
+}
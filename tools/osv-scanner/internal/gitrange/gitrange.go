@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitrange resolves whether a commit falls inside a GIT range of
+// an OSV advisory using the repository's actual ancestry: a commit is
+// affected if it descends from an introducing commit and from no fixing
+// commit. Repositories are cloned bare into a cache directory and
+// reused across queries.
+package gitrange
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// Resolver answers GIT range queries, caching one bare clone per
+// repository.
+type Resolver struct {
+	cacheDir string
+	repos    map[string]*git.Repository
+}
+
+// NewResolver returns a resolver cloning into cacheDir; an empty
+// cacheDir uses a directory under the user cache.
+func NewResolver(cacheDir string) (*Resolver, error) {
+	if cacheDir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		cacheDir = filepath.Join(userCache, "osv-scanner", "repos")
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Resolver{cacheDir: cacheDir, repos: map[string]*git.Repository{}}, nil
+}
+
+// Affected reports whether commit is inside the affected span of rng:
+// a descendant of some introduced commit (or any commit, for the "0"
+// sentinel) and of no fixed commit. Commits affected up to a
+// last_affected event match if they are ancestors of it. Resolution
+// failures, including unknown commits, report unaffected rather than
+// erroring, so advisory matching degrades instead of aborting the scan.
+func (r *Resolver) Affected(repo, commit string, rng osv.Range) bool {
+	repoObj, err := r.open(repo)
+	if err != nil {
+		log.Printf("Failed to clone %s for GIT range matching: %v", repo, err)
+		return false
+	}
+	target, err := repoObj.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return false
+	}
+
+	introduced := false
+	for _, event := range rng.Events {
+		switch {
+		case event.Introduced == "0":
+			introduced = true
+		case event.Introduced != "":
+			if isAncestor(repoObj, event.Introduced, target) {
+				introduced = true
+			}
+		}
+	}
+	if !introduced {
+		return false
+	}
+	for _, event := range rng.Events {
+		if event.Fixed != "" && isAncestor(repoObj, event.Fixed, target) {
+			return false
+		}
+		if event.LastAffected != "" {
+			last, err := repoObj.CommitObject(plumbing.NewHash(event.LastAffected))
+			if err != nil {
+				continue
+			}
+			if ok, err := target.IsAncestor(last); err != nil || !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isAncestor reports whether the commit named by hash is an ancestor of
+// (or equal to) target.
+func isAncestor(repo *git.Repository, hash string, target *object.Commit) bool {
+	ancestor, err := repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return false
+	}
+	if ancestor.Hash == target.Hash {
+		return true
+	}
+	ok, err := ancestor.IsAncestor(target)
+
+	return err == nil && ok
+}
+
+// open returns the cached clone of repo, cloning it bare on first use.
+// An existing clone that lacks a queried commit is not refetched; the
+// cache directory can be deleted to force a fresh clone.
+func (r *Resolver) open(repo string) (*git.Repository, error) {
+	if cached, ok := r.repos[repo]; ok {
+		return cached, nil
+	}
+	sum := sha256.Sum256([]byte(repo))
+	dir := filepath.Join(r.cacheDir, hex.EncodeToString(sum[:8]))
+	repoObj, err := git.PlainOpen(dir)
+	if err != nil {
+		repoObj, err = git.PlainClone(dir, true, &git.CloneOptions{URL: repo})
+		if err != nil {
+			return nil, fmt.Errorf("failed to clone %s: %w", repo, err)
+		}
+	}
+	r.repos[repo] = repoObj
+
+	return repoObj, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gobinary extracts the module dependencies embedded in compiled
+// Go binaries (the buildinfo section written since Go 1.18), so release
+// artifacts can be scanned without their source.
+package gobinary
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// Scan reads the build info of the Go binary at path and returns its
+// module dependencies as a scanned source, including the Go standard
+// library version as the "stdlib" package.
+func Scan(path string) (scanner.ScannedSource, error) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return scanner.ScannedSource{}, fmt.Errorf("%s is not a Go binary with build info: %w", path, err)
+	}
+
+	var pkgs []models.PackageInfo
+	if version := strings.TrimPrefix(info.GoVersion, "go"); version != "" && !strings.Contains(version, "devel") {
+		pkgs = append(pkgs, models.PackageInfo{
+			Name:      "stdlib",
+			Version:   version,
+			Ecosystem: "Go",
+		})
+	}
+	for _, dep := range info.Deps {
+		pkgs = append(pkgs, moduleToPackage(dep))
+	}
+
+	return scanner.ScannedSource{
+		Source:   models.SourceInfo{Path: path, Type: "go-binary"},
+		Packages: pkgs,
+	}, nil
+}
+
+// moduleToPackage converts a build info module, honoring any replacement,
+// into a queryable package.
+func moduleToPackage(mod *debug.Module) models.PackageInfo {
+	if mod.Replace != nil {
+		mod = mod.Replace
+	}
+
+	return models.PackageInfo{
+		Name: mod.Path,
+		// The Go ecosystem in OSV uses versions without the "v" prefix.
+		Version:   strings.TrimPrefix(mod.Version, "v"),
+		Ecosystem: "Go",
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grouper groups vulnerabilities that are aliases of each other so
+// that a weakness published as CVE + GHSA + ecosystem advisory is counted
+// and reported once.
+package grouper
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// Group partitions vulns into groups connected through their `aliases` and
+// `related` entries, returning one GroupInfo per group with all IDs listed.
+func Group(vulns []osv.Vulnerability) []models.GroupInfo {
+	// Union-find over the vulnerability indices.
+	parent := make([]int, len(vulns))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+	union := func(i, j int) {
+		parent[find(i)] = find(j)
+	}
+
+	// idToIndex maps every ID, alias and related ID to the vulnerabilities
+	// mentioning it.
+	idToIndex := make(map[string][]int)
+	for i, vuln := range vulns {
+		for _, id := range identifiers(vuln) {
+			idToIndex[id] = append(idToIndex[id], i)
+		}
+	}
+	for _, indices := range idToIndex {
+		for _, i := range indices[1:] {
+			union(indices[0], i)
+		}
+	}
+
+	groupedIDs := make(map[int]map[string]struct{})
+	for i, vuln := range vulns {
+		root := find(i)
+		if groupedIDs[root] == nil {
+			groupedIDs[root] = make(map[string]struct{})
+		}
+		groupedIDs[root][vuln.ID] = struct{}{}
+		for _, alias := range vuln.Aliases {
+			groupedIDs[root][alias] = struct{}{}
+		}
+	}
+
+	groups := make([]models.GroupInfo, 0, len(groupedIDs))
+	for _, idSet := range groupedIDs {
+		ids := make([]string, 0, len(idSet))
+		for id := range idSet {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if pi, pj := idPrecedence(ids[i]), idPrecedence(ids[j]); pi != pj {
+				return pi < pj
+			}
+
+			return ids[i] < ids[j]
+		})
+		groups = append(groups, models.GroupInfo{IDs: ids})
+	}
+	// Sort groups by their canonical ID for deterministic output.
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].IDs[0] < groups[j].IDs[0]
+	})
+
+	return groups
+}
+
+// identifiers returns every ID through which vuln can be connected to
+// another record: its own ID plus aliases and related entries.
+func identifiers(vuln osv.Vulnerability) []string {
+	ids := []string{vuln.ID}
+	ids = append(ids, vuln.Aliases...)
+	ids = append(ids, vuln.Related...)
+
+	return ids
+}
+
+// idPrecedence orders IDs so that the most specific databases sort first:
+// ecosystem advisories, then GHSA, then CVE. The first ID of a group is
+// treated as its canonical ID.
+func idPrecedence(id string) int {
+	switch {
+	case strings.HasPrefix(id, "CVE-"):
+		return 2
+	case strings.HasPrefix(id, "GHSA-"):
+		return 1
+	default:
+		return 0
+	}
+}
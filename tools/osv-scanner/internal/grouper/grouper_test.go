@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grouper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+func TestGroup(t *testing.T) {
+	tests := []struct {
+		name  string
+		vulns []osv.Vulnerability
+		want  [][]string
+	}{
+		{
+			name: "unrelated records stay separate",
+			vulns: []osv.Vulnerability{
+				{ID: "GHSA-aaaa-aaaa-aaaa"},
+				{ID: "GHSA-bbbb-bbbb-bbbb"},
+			},
+			want: [][]string{
+				{"GHSA-aaaa-aaaa-aaaa"},
+				{"GHSA-bbbb-bbbb-bbbb"},
+			},
+		},
+		{
+			name: "alias joins records and the ecosystem ID is canonical",
+			vulns: []osv.Vulnerability{
+				{ID: "CVE-2023-0001"},
+				{ID: "GHSA-aaaa-aaaa-aaaa", Aliases: []string{"CVE-2023-0001"}},
+				{ID: "PYSEC-2023-1", Aliases: []string{"CVE-2023-0001"}},
+			},
+			want: [][]string{
+				{"PYSEC-2023-1", "GHSA-aaaa-aaaa-aaaa", "CVE-2023-0001"},
+			},
+		},
+		{
+			name: "related entries connect transitively",
+			vulns: []osv.Vulnerability{
+				{ID: "OSV-2023-1", Related: []string{"OSV-2023-2"}},
+				{ID: "OSV-2023-2"},
+				{ID: "OSV-2023-3", Related: []string{"OSV-2023-2"}},
+				{ID: "OSV-2023-9"},
+			},
+			want: [][]string{
+				{"OSV-2023-1", "OSV-2023-2", "OSV-2023-3"},
+				{"OSV-2023-9"},
+			},
+		},
+		{
+			name: "aliases of absent records are listed, related are not",
+			vulns: []osv.Vulnerability{
+				{ID: "GHSA-aaaa-aaaa-aaaa", Aliases: []string{"CVE-2023-0002"}, Related: []string{"OSV-2023-5"}},
+			},
+			want: [][]string{
+				{"GHSA-aaaa-aaaa-aaaa", "CVE-2023-0002"},
+			},
+		},
+		{
+			name:  "no vulnerabilities",
+			vulns: nil,
+			want:  [][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := Group(tt.vulns)
+			got := make([][]string, 0, len(groups))
+			for _, group := range groups {
+				got = append(got, group.IDs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Group() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history persists scan reports over time, keyed by artifact
+// digest, so the daemon can answer "what changed since the last scan of
+// this artifact" instead of only reporting point-in-time results.
+//
+// Reports are stored in a bbolt database — the same embedded store the
+// result store uses, avoiding a second storage engine and a cgo
+// dependency — one record per scan, keyed by digest plus timestamp so a
+// prefix scan yields one artifact's history in order.
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+var scansBucket = []byte("scans")
+
+// keySeparator joins digest and timestamp in record keys. Digests are
+// hex and timestamps RFC 3339, so "/" cannot appear in either part.
+const keySeparator = "/"
+
+// Store is a disk-backed history of scan reports.
+type Store struct {
+	db *bolt.DB
+}
+
+// Record is one stored scan of one artifact.
+type Record struct {
+	// Digest identifies the scanned artifact, e.g. the SHA-256 of an
+	// uploaded lockfile or image archive.
+	Digest string `json:"digest"`
+	// Ref is the human-readable name the artifact was submitted under.
+	Ref  string    `json:"ref,omitempty"`
+	Time time.Time `json:"time"`
+	// New lists the finding groups (canonical IDs) not present in the
+	// previous scan of the same artifact. On the first scan every
+	// finding is new.
+	New     []string                     `json:"new,omitempty"`
+	Results *models.VulnerabilityResults `json:"results"`
+}
+
+// Open opens (creating if necessary) the history store at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history store %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(scansBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add stores one scan of the artifact identified by digest and returns
+// the stored record, with New computed against the artifact's previous
+// scan.
+func (s *Store) Add(digest, ref string, results *models.VulnerabilityResults) (*Record, error) {
+	record := &Record{
+		Digest:  digest,
+		Ref:     ref,
+		Time:    time.Now().UTC(),
+		Results: results,
+	}
+	previous, ok, err := s.Latest(digest)
+	if err != nil {
+		return nil, err
+	}
+	var seen map[string]bool
+	if ok {
+		seen = make(map[string]bool)
+		for _, id := range findingIDs(previous.Results) {
+			seen[id] = true
+		}
+	}
+	for _, id := range findingIDs(results) {
+		if !seen[id] {
+			record.New = append(record.New, id)
+		}
+	}
+
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	key := []byte(digest + keySeparator + record.Time.Format(time.RFC3339Nano))
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(scansBucket).Put(key, encoded)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Latest returns the most recent record for digest, if any.
+func (s *Store) Latest(digest string) (*Record, bool, error) {
+	records, err := s.History(digest)
+	if err != nil || len(records) == 0 {
+		return nil, false, err
+	}
+
+	return records[len(records)-1], true, nil
+}
+
+// History returns every record for digest in chronological order.
+func (s *Store) History(digest string) ([]*Record, error) {
+	prefix := []byte(digest + keySeparator)
+	var records []*Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(scansBucket).Cursor()
+		for k, v := c.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var record Record
+			if err := json.Unmarshal(v, &record); err != nil {
+				return err
+			}
+			records = append(records, &record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Digests lists every tracked artifact digest.
+func (s *Store) Digests() ([]string, error) {
+	seen := make(map[string]bool)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(scansBucket).ForEach(func(k, _ []byte) error {
+			if i := bytes.Index(k, []byte(keySeparator)); i > 0 {
+				seen[string(k[:i])] = true
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	digests := make([]string, 0, len(seen))
+	for digest := range seen {
+		digests = append(digests, digest)
+	}
+	sort.Strings(digests)
+
+	return digests, nil
+}
+
+// findingIDs returns the sorted canonical IDs of every finding group in
+// results.
+func findingIDs(results *models.VulnerabilityResults) []string {
+	if results == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				if len(group.IDs) > 0 {
+					seen[group.IDs[0]] = true
+				}
+			}
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
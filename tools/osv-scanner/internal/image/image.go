@@ -0,0 +1,360 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package image extracts container images into a flattened root filesystem
+// that the scanner can walk. It understands `docker save` tarballs and OCI
+// image layout directories, so images produced as files by build systems
+// (Bazel rules_oci, buildah) can be scanned without a daemon or registry.
+package image
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// whiteoutPrefix marks files deleted by a layer in the OCI/AUFS whiteout
+// convention.
+const whiteoutPrefix = ".wh."
+
+// ExtractDockerArchive flattens the first image of a `docker save` tarball
+// into destDir.
+func ExtractDockerArchive(tarPath, destDir string) error {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// First pass: find the manifest to learn the layer order.
+	var manifests []struct {
+		Layers []string `json:"Layers"`
+	}
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Name == "manifest.json" {
+			if err := json.NewDecoder(tr).Decode(&manifests); err != nil {
+				return fmt.Errorf("invalid manifest.json in %s: %w", tarPath, err)
+			}
+		}
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("%s: no manifest.json, not a docker save archive", tarPath)
+	}
+
+	// Second pass per layer: docker archives are not guaranteed to store
+	// layers in application order, so apply them by seeking per layer.
+	for _, layer := range manifests[0].Layers {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		tr := tar.NewReader(f)
+		found := false
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if hdr.Name != layer {
+				continue
+			}
+			found = true
+			if err := applyLayerCached(tr, destDir, layerKey(layer)); err != nil {
+				return fmt.Errorf("applying layer %s: %w", layer, err)
+			}
+			break
+		}
+		if !found {
+			return fmt.Errorf("%s: layer %s missing from archive", tarPath, layer)
+		}
+	}
+
+	return nil
+}
+
+// ExtractOCILayout flattens the image of an OCI image layout directory
+// into destDir. Multi-arch layouts must identify a single image: either
+// the layout holds one, or a platform is selected via
+// ExtractOCIPlatform.
+func ExtractOCILayout(layoutDir, destDir string) error {
+	return extractOCI(layoutDir, destDir, "")
+}
+
+// ExtractOCIPlatform returns an extractor for the image matching
+// platform ("os/arch", or "os/arch/variant") in a multi-arch OCI
+// layout.
+func ExtractOCIPlatform(platform string) func(layoutDir, destDir string) error {
+	return func(layoutDir, destDir string) error {
+		return extractOCI(layoutDir, destDir, platform)
+	}
+}
+
+// ociManifest is one image manifest reachable from a layout's index,
+// with the platform its index entry declares.
+type ociManifest struct {
+	Digest   string
+	Platform string
+}
+
+// Platforms lists the platforms of the image manifests an OCI layout's
+// index points at, following nested manifest lists.
+func Platforms(layoutDir string) ([]string, error) {
+	manifests, err := resolveManifests(layoutDir)
+	if err != nil {
+		return nil, err
+	}
+	var platforms []string
+	for _, manifest := range manifests {
+		if manifest.Platform != "" {
+			platforms = append(platforms, manifest.Platform)
+		}
+	}
+
+	return platforms, nil
+}
+
+// resolveManifests follows a layout's index down to image manifests,
+// recursing through nested indexes (manifest lists).
+func resolveManifests(layoutDir string) ([]ociManifest, error) {
+	indexData, err := os.ReadFile(filepath.Join(layoutDir, "index.json"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an OCI layout: %w", layoutDir, err)
+	}
+
+	return resolveIndex(layoutDir, indexData)
+}
+
+func resolveIndex(layoutDir string, indexData []byte) ([]ociManifest, error) {
+	var index struct {
+		Manifests []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+			Platform  *struct {
+				OS           string `json:"os"`
+				Architecture string `json:"architecture"`
+				Variant      string `json:"variant,omitempty"`
+			} `json:"platform,omitempty"`
+		} `json:"manifests"`
+	}
+	if err := json.Unmarshal(indexData, &index); err != nil {
+		return nil, err
+	}
+
+	var manifests []ociManifest
+	for _, entry := range index.Manifests {
+		if strings.HasSuffix(entry.MediaType, "image.index.v1+json") ||
+			strings.HasSuffix(entry.MediaType, "distribution.manifest.list.v2+json") {
+			nested, err := os.ReadFile(blobPath(layoutDir, entry.Digest))
+			if err != nil {
+				return nil, err
+			}
+			resolved, err := resolveIndex(layoutDir, nested)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, resolved...)
+			continue
+		}
+		manifest := ociManifest{Digest: entry.Digest}
+		if entry.Platform != nil {
+			manifest.Platform = entry.Platform.OS + "/" + entry.Platform.Architecture
+			if entry.Platform.Variant != "" {
+				manifest.Platform += "/" + entry.Platform.Variant
+			}
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	return manifests, nil
+}
+
+// extractOCI flattens the layout's image for the given platform, or its
+// only image when platform is empty. An ambiguous layout is an error
+// listing the available platforms rather than a silent arbitrary pick.
+func extractOCI(layoutDir, destDir, platform string) error {
+	manifests, err := resolveManifests(layoutDir)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("%s: index.json lists no manifests", layoutDir)
+	}
+	chosen := manifests[0]
+	if platform != "" {
+		found := false
+		for _, manifest := range manifests {
+			if manifest.Platform == platform {
+				chosen, found = manifest, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s has no %s image (available: %s)", layoutDir, platform, platformList(manifests))
+		}
+	} else if len(manifests) > 1 {
+		return fmt.Errorf("%s is a multi-arch layout; pass --platform to choose one of: %s", layoutDir, platformList(manifests))
+	}
+
+	manifestData, err := os.ReadFile(blobPath(layoutDir, chosen.Digest))
+	if err != nil {
+		return err
+	}
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		f, err := os.Open(blobPath(layoutDir, layer.Digest))
+		if err != nil {
+			return err
+		}
+		var r io.Reader = f
+		if strings.HasSuffix(layer.MediaType, "+gzip") || strings.HasSuffix(layer.MediaType, ".gzip") {
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				f.Close()
+				return err
+			}
+			r = gz
+		}
+		err = applyLayerCached(r, destDir, layerKey(layer.Digest))
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("applying layer %s: %w", layer.Digest, err)
+		}
+	}
+
+	return nil
+}
+
+// platformList renders the platforms of a layout's manifests for error
+// messages.
+func platformList(manifests []ociManifest) string {
+	var platforms []string
+	for _, manifest := range manifests {
+		if manifest.Platform != "" {
+			platforms = append(platforms, manifest.Platform)
+		}
+	}
+	if len(platforms) == 0 {
+		return "none declared"
+	}
+
+	return strings.Join(platforms, ", ")
+}
+
+func blobPath(layoutDir, digest string) string {
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		algo, hex = "sha256", digest
+	}
+
+	return filepath.Join(layoutDir, "blobs", algo, hex)
+}
+
+// applyLayer extracts one layer tar into destDir, honoring whiteouts.
+// Entries that would escape destDir are skipped.
+func applyLayer(r io.Reader, destDir string) error {
+	return applyTar(r, destDir, true)
+}
+
+// applyTar extracts a layer tar into destDir. With applyWhiteouts set,
+// whiteout entries delete what they mark; without it they are kept as
+// empty marker files, so a cached layer directory replays with the same
+// effect as the tar. Entries that would escape destDir are skipped.
+func applyTar(r io.Reader, destDir string, applyWhiteouts bool) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, ok := securePath(destDir, hdr.Name)
+		if !ok {
+			continue
+		}
+
+		base := filepath.Base(hdr.Name)
+		if applyWhiteouts && strings.HasPrefix(base, whiteoutPrefix) {
+			if base == whiteoutPrefix+whiteoutPrefix+".opq" {
+				// Opaque whiteout: the parent directory starts empty.
+				dir := filepath.Dir(target)
+				os.RemoveAll(dir)
+				os.MkdirAll(dir, 0755)
+				continue
+			}
+			os.RemoveAll(filepath.Join(filepath.Dir(target), strings.TrimPrefix(base, whiteoutPrefix)))
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.Create(target)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink, tar.TypeLink:
+			// Links are not followed while scanning the flattened
+			// filesystem, so they are skipped rather than recreated.
+		}
+	}
+}
+
+// securePath joins name onto destDir, rejecting escapes via "..".
+func securePath(destDir, name string) (string, bool) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+		return "", false
+	}
+
+	return target, true
+}
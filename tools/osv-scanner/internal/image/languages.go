@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/gobinary"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// ScanLanguagePackages walks an extracted image filesystem for language
+// packages installed into it, which have no lockfile for the regular
+// extractors to find: Python distributions in site-packages, npm
+// packages in node_modules trees, and the module dependencies of Go
+// binaries. (JAR files are already covered by the Java archive
+// extractor during the directory scan.) Unreadable or malformed entries
+// are logged and skipped; an image scan should degrade, not fail, on
+// one odd file.
+func ScanLanguagePackages(dir string) []scanner.ScannedSource {
+	// Installed packages are grouped per installation root
+	// (a site-packages directory, a node_modules tree), mirroring how
+	// lockfile sources group their packages.
+	pythonRoots := make(map[string][]models.PackageInfo)
+	nodeRoots := make(map[string][]models.PackageInfo)
+	var sources []scanner.ScannedSource
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.Printf("Skipping %s: %v", path, err)
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		parent := filepath.Dir(path)
+		switch {
+		case (d.Name() == "METADATA" || d.Name() == "PKG-INFO") &&
+			(strings.HasSuffix(parent, ".dist-info") || strings.HasSuffix(parent, ".egg-info")):
+			pkg, ok := parsePythonMetadata(path)
+			if !ok {
+				return nil
+			}
+			root := filepath.Dir(parent)
+			pythonRoots[root] = append(pythonRoots[root], pkg)
+		case d.Name() == "package.json":
+			root, ok := nodeModulesRoot(parent)
+			if !ok {
+				return nil
+			}
+			pkg, ok := parseInstalledPackageJSON(path)
+			if !ok {
+				return nil
+			}
+			nodeRoots[root] = append(nodeRoots[root], pkg)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil || info.Mode()&0111 == 0 {
+				return nil
+			}
+			source, err := gobinary.Scan(path)
+			if err != nil {
+				// Most executables are not Go binaries.
+				return nil
+			}
+			sources = append(sources, source)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Language package walk of %s failed: %v", dir, err)
+	}
+
+	for root, pkgs := range pythonRoots {
+		sources = append(sources, scanner.ScannedSource{
+			Source:   models.SourceInfo{Path: root, Type: "python-install"},
+			Packages: pkgs,
+		})
+	}
+	for root, pkgs := range nodeRoots {
+		sources = append(sources, scanner.ScannedSource{
+			Source:   models.SourceInfo{Path: root, Type: "node-install"},
+			Packages: pkgs,
+		})
+	}
+	// Map iteration order is random; keep report order stable.
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Source.Path < sources[j].Source.Path
+	})
+
+	return sources
+}
+
+// parsePythonMetadata reads the Name and Version headers of an installed
+// distribution's METADATA or PKG-INFO file.
+func parsePythonMetadata(path string) (models.PackageInfo, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return models.PackageInfo{}, false
+	}
+	defer f.Close()
+
+	pkg := models.PackageInfo{Ecosystem: "PyPI"}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		// Headers end at the first blank line.
+		if line == "" {
+			break
+		}
+		if name, ok := strings.CutPrefix(line, "Name: "); ok {
+			pkg.Name = name
+		}
+		if version, ok := strings.CutPrefix(line, "Version: "); ok {
+			pkg.Version = version
+		}
+	}
+
+	return pkg, pkg.Name != "" && pkg.Version != ""
+}
+
+// nodeModulesRoot reports whether moduleDir is a directly installed
+// module (node_modules/name or node_modules/@scope/name, not a
+// package.json nested deeper inside a module) and returns the
+// node_modules tree it belongs to.
+func nodeModulesRoot(moduleDir string) (string, bool) {
+	parent := filepath.Dir(moduleDir)
+	if filepath.Base(parent) == "node_modules" {
+		return parent, true
+	}
+	if strings.HasPrefix(filepath.Base(parent), "@") && filepath.Base(filepath.Dir(parent)) == "node_modules" {
+		return filepath.Dir(parent), true
+	}
+
+	return "", false
+}
+
+// parseInstalledPackageJSON reads the installed name and version of one
+// module's package.json.
+func parseInstalledPackageJSON(path string) (models.PackageInfo, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.PackageInfo{}, false
+	}
+	var manifest struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return models.PackageInfo{}, false
+	}
+	pkg := models.PackageInfo{
+		Name:      manifest.Name,
+		Version:   manifest.Version,
+		Ecosystem: "npm",
+	}
+
+	return pkg, pkg.Name != "" && pkg.Version != ""
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// layerCacheDir is where extracted layers are kept between scans, keyed
+// by layer digest. Empty means no caching.
+var layerCacheDir string
+
+// EnableLayerCache keeps each extracted layer on disk keyed by its
+// digest, so re-scanning images that share base layers — the common
+// case in CI, where hundreds of images sit on a handful of bases —
+// skips decompressing and unpacking those layers again and only
+// re-queries the API. An empty dir places the cache under the user
+// cache directory.
+func EnableLayerCache(dir string) error {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(base, "osv-scanner", "layers")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	layerCacheDir = dir
+
+	return nil
+}
+
+// hexDigest matches the hex of a sha256 layer digest inside a layer
+// identifier, whether "sha256:abc..." or "blobs/sha256/abc...".
+var hexDigest = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// layerKey derives the cache key for a layer from its identifier, or ""
+// when the identifier is not content-addressed and caching would be
+// unsound.
+func layerKey(identifier string) string {
+	return hexDigest.FindString(identifier)
+}
+
+// applyLayerCached is applyLayer through the layer cache: a cached
+// layer is replayed from disk without touching r; a miss unpacks r into
+// the cache first. Without a cache or a content-addressed key it
+// degrades to a plain applyLayer.
+func applyLayerCached(r io.Reader, destDir, key string) error {
+	if layerCacheDir == "" || key == "" {
+		return applyLayer(r, destDir)
+	}
+	cached := filepath.Join(layerCacheDir, key)
+	if _, err := os.Stat(cached); err != nil {
+		tmp, err := os.MkdirTemp(layerCacheDir, ".layer-*")
+		if err != nil {
+			return applyLayer(r, destDir)
+		}
+		if err := unpackLayer(r, tmp); err != nil {
+			os.RemoveAll(tmp)
+			return err
+		}
+		if err := os.Rename(tmp, cached); err != nil {
+			// Lost the rename race to a concurrent scan; its copy is
+			// just as good.
+			os.RemoveAll(tmp)
+		}
+	}
+
+	return applyLayerDir(cached, destDir)
+}
+
+// unpackLayer extracts a layer tar into dir verbatim, keeping whiteout
+// entries as empty marker files so replaying the directory has the same
+// effect as applying the tar.
+func unpackLayer(r io.Reader, dir string) error {
+	return applyTar(r, dir, false)
+}
+
+// applyLayerDir replays a cached layer directory onto destDir,
+// honoring the whiteout markers unpackLayer preserved.
+func applyLayerDir(srcDir, destDir string) error {
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		target, ok := securePath(destDir, rel)
+		if !ok {
+			return nil
+		}
+
+		base := filepath.Base(rel)
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			if base == whiteoutPrefix+whiteoutPrefix+".opq" {
+				dir := filepath.Dir(target)
+				os.RemoveAll(dir)
+				os.MkdirAll(dir, 0755)
+				return nil
+			}
+			os.RemoveAll(filepath.Join(filepath.Dir(target), strings.TrimPrefix(base, whiteoutPrefix)))
+			return nil
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		// Hard-link out of the cache when possible; a cross-device cache
+		// falls back to copying.
+		os.Remove(target)
+		if err := os.Link(path, target); err == nil {
+			return nil
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+// copyFile copies a regular file.
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// ScanOSPackages reads the OS package databases of an extracted image
+// filesystem: the dpkg status file (including the status.d fragments
+// distroless images use) and the apk installed database. The distro
+// release is detected from os-release and packages are queried in the
+// versioned ecosystem ("Debian:12", "Alpine:v3.18") that
+// release-specific advisories are keyed by; without it, an advisory
+// fixed in one release and open in another can silently mismatch.
+// Binary packages carry the source package they were built from, which
+// OS advisories are keyed by, so libssl3 matches openssl advisories.
+func ScanOSPackages(dir string) []scanner.ScannedSource {
+	ecosystem := osEcosystem(osRelease(dir))
+	var sources []scanner.ScannedSource
+
+	dpkgPaths := []string{filepath.Join(dir, "var/lib/dpkg/status")}
+	if fragments, err := filepath.Glob(filepath.Join(dir, "var/lib/dpkg/status.d/*")); err == nil {
+		dpkgPaths = append(dpkgPaths, fragments...)
+	}
+	var dpkgPackages []models.PackageInfo
+	dpkgPath := dpkgPaths[0]
+	for _, path := range dpkgPaths {
+		pkgs, err := parseDpkgStatus(path, dpkgEcosystem(ecosystem))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("Skipping %s: %v", path, err)
+			}
+			continue
+		}
+		dpkgPackages = append(dpkgPackages, pkgs...)
+	}
+	if len(dpkgPackages) > 0 {
+		sources = append(sources, scanner.ScannedSource{
+			Source:   models.SourceInfo{Path: dpkgPath, Type: "os-install"},
+			Packages: dpkgPackages,
+		})
+	}
+
+	apkPath := filepath.Join(dir, "lib/apk/db/installed")
+	apkPackages, err := parseApkInstalled(apkPath, apkEcosystem(ecosystem))
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("Skipping %s: %v", apkPath, err)
+	}
+	if len(apkPackages) > 0 {
+		sources = append(sources, scanner.ScannedSource{
+			Source:   models.SourceInfo{Path: apkPath, Type: "os-install"},
+			Packages: apkPackages,
+		})
+	}
+
+	return sources
+}
+
+// osRelease parses the image's os-release file into its key-value
+// pairs, or returns nil when the image has none.
+func osRelease(dir string) map[string]string {
+	for _, path := range []string{"etc/os-release", "usr/lib/os-release"} {
+		f, err := os.Open(filepath.Join(dir, path))
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+		fields := make(map[string]string)
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			line := strings.TrimSpace(s.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				continue
+			}
+			fields[key] = strings.Trim(value, `"'`)
+		}
+
+		return fields
+	}
+
+	return nil
+}
+
+// osEcosystem maps an image's os-release to the versioned OSV ecosystem
+// its packages should be queried in, or "" for unrecognized distros.
+func osEcosystem(release map[string]string) string {
+	version := release["VERSION_ID"]
+	switch release["ID"] {
+	case "debian":
+		// Debian's VERSION_ID is the major release OSV suffixes with.
+		if version != "" {
+			return "Debian:" + version
+		}
+
+		return "Debian"
+	case "ubuntu":
+		if version != "" {
+			return "Ubuntu:" + version
+		}
+
+		return "Ubuntu"
+	case "alpine":
+		// OSV names Alpine releases "v<major>.<minor>"; VERSION_ID
+		// carries a patch level ("3.18.4").
+		if parts := strings.Split(version, "."); len(parts) >= 2 {
+			return "Alpine:v" + parts[0] + "." + parts[1]
+		}
+
+		return "Alpine"
+	case "wolfi":
+		return "Wolfi"
+	case "chainguard":
+		return "Chainguard"
+	}
+
+	return ""
+}
+
+// dpkgEcosystem falls back to the unversioned Debian ecosystem when the
+// image has a dpkg database but no recognizable os-release.
+func dpkgEcosystem(ecosystem string) string {
+	if ecosystem == "" {
+		return "Debian"
+	}
+
+	return ecosystem
+}
+
+// apkEcosystem is the apk counterpart of dpkgEcosystem.
+func apkEcosystem(ecosystem string) string {
+	if ecosystem == "" {
+		return "Alpine"
+	}
+
+	return ecosystem
+}
+
+// parseDpkgStatus reads the installed packages of one dpkg status file,
+// whose stanzas are RFC 822-style fields separated by blank lines.
+func parseDpkgStatus(path, ecosystem string) ([]models.PackageInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pkgs []models.PackageInfo
+	var pkg models.PackageInfo
+	installed := true
+	flush := func() {
+		if pkg.SourceName == pkg.Name {
+			pkg.SourceName = ""
+		}
+		if pkg.Name != "" && pkg.Version != "" && installed {
+			pkg.Ecosystem = ecosystem
+			pkgs = append(pkgs, pkg)
+		}
+		pkg = models.PackageInfo{}
+		installed = true
+	}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "Package: "):
+			pkg.Name = strings.TrimPrefix(line, "Package: ")
+		case strings.HasPrefix(line, "Version: "):
+			pkg.Version = strings.TrimPrefix(line, "Version: ")
+		case strings.HasPrefix(line, "Source: "):
+			// "Source: glibc (2.36-9+deb12u4)" — the parenthesized source
+			// version, present when it differs from the binary's, is not
+			// what advisories range over here.
+			source := strings.TrimPrefix(line, "Source: ")
+			if name, _, ok := strings.Cut(source, " ("); ok {
+				source = name
+			}
+			pkg.SourceName = source
+		case strings.HasPrefix(line, "Status: "):
+			// Removed-but-not-purged packages keep a stanza; only
+			// installed ones are present in the filesystem.
+			installed = strings.HasSuffix(line, " installed")
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return pkgs, nil
+}
+
+// parseApkInstalled reads the packages of an apk installed database,
+// whose records are single-letter fields separated by blank lines.
+func parseApkInstalled(path, ecosystem string) ([]models.PackageInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pkgs []models.PackageInfo
+	var pkg models.PackageInfo
+	flush := func() {
+		if pkg.SourceName == pkg.Name {
+			pkg.SourceName = ""
+		}
+		if pkg.Name != "" && pkg.Version != "" {
+			pkg.Ecosystem = ecosystem
+			pkgs = append(pkgs, pkg)
+		}
+		pkg = models.PackageInfo{}
+	}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "P:"):
+			pkg.Name = strings.TrimPrefix(line, "P:")
+		case strings.HasPrefix(line, "V:"):
+			pkg.Version = strings.TrimPrefix(line, "V:")
+		case strings.HasPrefix(line, "o:"):
+			// The origin field names the source package the binary was
+			// split from; Alpine secfixes are keyed by it.
+			pkg.SourceName = strings.TrimPrefix(line, "o:")
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return pkgs, nil
+}
@@ -0,0 +1,378 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Registry credentials from the --registry-username/--registry-password
+// flags; they take precedence over docker config.json and credential
+// helpers for every registry.
+var (
+	RegistryUsername string
+	RegistryPassword string
+)
+
+// Pull flattens the image at ref — "host/repo:tag", "host/repo@digest",
+// or a Docker Hub short name like "debian:12" — from its registry into
+// destDir, so private registry images (ECR, GCR, GHCR) can be scanned
+// directly without a local daemon. platform selects one image of a
+// multi-arch reference; pulling an ambiguous reference without it is an
+// error listing what is available.
+func Pull(ctx context.Context, ref, platform, destDir string) error {
+	host, repo, reference := parseRef(ref)
+	p := &puller{host: host, repo: repo}
+
+	manifestData, mediaType, err := p.fetchManifest(ctx, reference)
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", ref, err)
+	}
+	if strings.HasSuffix(mediaType, "manifest.list.v2+json") || strings.HasSuffix(mediaType, "image.index.v1+json") {
+		digest, err := selectPlatformManifest(manifestData, platform, ref)
+		if err != nil {
+			return err
+		}
+		manifestData, _, err = p.fetchManifest(ctx, digest)
+		if err != nil {
+			return fmt.Errorf("failed to pull %s: %w", ref, err)
+		}
+	}
+
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return fmt.Errorf("invalid manifest for %s: %w", ref, err)
+	}
+	for _, layer := range manifest.Layers {
+		if err := p.applyBlob(ctx, layer.Digest, layer.MediaType, destDir); err != nil {
+			return fmt.Errorf("failed to pull layer %s of %s: %w", layer.Digest, ref, err)
+		}
+	}
+
+	return nil
+}
+
+// ExtractRegistry adapts Pull to the extract function scanImage takes.
+func ExtractRegistry(ctx context.Context, platform string) func(ref, destDir string) error {
+	return func(ref, destDir string) error {
+		return Pull(ctx, ref, platform, destDir)
+	}
+}
+
+// parseRef splits an image reference into registry host, repository and
+// reference (tag or digest), applying the Docker Hub conventions: a
+// reference without a registry host goes to registry-1.docker.io, and
+// official images get the library/ namespace.
+func parseRef(ref string) (host, repo, reference string) {
+	host = "registry-1.docker.io"
+	rest := ref
+	if first, remainder, ok := strings.Cut(ref, "/"); ok &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		host, rest = first, remainder
+	}
+	reference = "latest"
+	if repo, digest, ok := strings.Cut(rest, "@"); ok {
+		return host, repo, digest
+	}
+	repo = rest
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		repo, reference = rest[:i], rest[i+1:]
+	}
+	if host == "registry-1.docker.io" && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+
+	return host, repo, reference
+}
+
+// selectPlatformManifest picks the manifest digest matching platform
+// out of a manifest list.
+func selectPlatformManifest(indexData []byte, platform, ref string) (string, error) {
+	var index struct {
+		Manifests []struct {
+			Digest   string `json:"digest"`
+			Platform *struct {
+				OS           string `json:"os"`
+				Architecture string `json:"architecture"`
+				Variant      string `json:"variant,omitempty"`
+			} `json:"platform,omitempty"`
+		} `json:"manifests"`
+	}
+	if err := json.Unmarshal(indexData, &index); err != nil {
+		return "", fmt.Errorf("invalid manifest list for %s: %w", ref, err)
+	}
+	var platforms []string
+	for _, entry := range index.Manifests {
+		if entry.Platform == nil {
+			continue
+		}
+		p := entry.Platform.OS + "/" + entry.Platform.Architecture
+		if entry.Platform.Variant != "" {
+			p += "/" + entry.Platform.Variant
+		}
+		if p == platform {
+			return entry.Digest, nil
+		}
+		platforms = append(platforms, p)
+	}
+	if platform == "" {
+		return "", fmt.Errorf("%s is multi-arch; pass --platform to choose one of: %s", ref, strings.Join(platforms, ", "))
+	}
+
+	return "", fmt.Errorf("%s has no %s image (available: %s)", ref, platform, strings.Join(platforms, ", "))
+}
+
+// puller fetches manifests and blobs from one repository, holding the
+// bearer token the registry's token service issued for it.
+type puller struct {
+	host  string
+	repo  string
+	token string
+}
+
+// manifestAccept lists the manifest media types the puller understands,
+// both the OCI and Docker flavors and their multi-arch lists.
+const manifestAccept = "application/vnd.oci.image.manifest.v1+json, " +
+	"application/vnd.oci.image.index.v1+json, " +
+	"application/vnd.docker.distribution.manifest.v2+json, " +
+	"application/vnd.docker.distribution.manifest.list.v2+json"
+
+func (p *puller) fetchManifest(ctx context.Context, reference string) (data []byte, mediaType string, err error) {
+	resp, err := p.get(ctx, fmt.Sprintf("https://%s/v2/%s/manifests/%s", p.host, p.repo, reference), manifestAccept)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// applyBlob streams one layer blob onto destDir, through the layer
+// cache when one is enabled.
+func (p *puller) applyBlob(ctx context.Context, digest, mediaType, destDir string) error {
+	resp, err := p.get(ctx, fmt.Sprintf("https://%s/v2/%s/blobs/%s", p.host, p.repo, digest), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var r io.Reader = resp.Body
+	if strings.HasSuffix(mediaType, "+gzip") || strings.HasSuffix(mediaType, ".gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		r = gz
+	}
+
+	return applyLayerCached(r, destDir, layerKey(digest))
+}
+
+// get performs one registry request, transparently acquiring a bearer
+// token from the registry's token service on a 401 challenge.
+func (p *puller) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if p.token != "" {
+			req.Header.Set("Authorization", "Bearer "+p.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := p.authorize(ctx, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("registry returned %s for %s", resp.Status, url)
+		}
+
+		return resp, nil
+	}
+}
+
+// authorize answers a Bearer challenge by requesting a token from the
+// registry's token service, presenting whatever credentials are
+// configured for the host.
+func (p *puller) authorize(ctx context.Context, challenge string) error {
+	params, ok := strings.CutPrefix(challenge, "Bearer ")
+	if !ok {
+		return fmt.Errorf("registry %s requires unsupported authentication %q", p.host, challenge)
+	}
+	fields := make(map[string]string)
+	for _, field := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"`)
+	}
+	if fields["realm"] == "" {
+		return fmt.Errorf("registry %s sent a challenge without a realm", p.host)
+	}
+
+	tokenURL := fields["realm"] + "?service=" + fields["service"] + "&scope=repository:" + p.repo + ":pull"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return err
+	}
+	if username, password, ok := credentialsFor(p.host); ok {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token service for %s returned %s; are the registry credentials valid?", p.host, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	p.token = token.Token
+	if p.token == "" {
+		p.token = token.AccessToken
+	}
+	if p.token == "" {
+		return fmt.Errorf("token service for %s returned no token", p.host)
+	}
+
+	return nil
+}
+
+// credentialsFor resolves the credentials to present for a registry
+// host: the explicit flags first, then the host's entry in docker
+// config.json, then its credential helper.
+func credentialsFor(host string) (username, password string, ok bool) {
+	if RegistryUsername != "" {
+		return RegistryUsername, RegistryPassword, true
+	}
+
+	config := dockerConfig()
+	if config == nil {
+		return "", "", false
+	}
+	if auth, found := config.Auths[host]; found {
+		if auth.Username != "" {
+			return auth.Username, auth.Password, true
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err == nil {
+				if username, password, found := strings.Cut(string(decoded), ":"); found {
+					return username, password, true
+				}
+			}
+		}
+	}
+	helper := config.CredHelpers[host]
+	if helper == "" {
+		helper = config.CredsStore
+	}
+	if helper != "" {
+		return helperCredentials(helper, host)
+	}
+
+	return "", "", false
+}
+
+// dockerConfigFile is the docker client configuration, honoring
+// DOCKER_CONFIG the way the docker CLI does.
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth,omitempty"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore,omitempty"`
+	CredHelpers map[string]string `json:"credHelpers,omitempty"`
+}
+
+func dockerConfig() *dockerConfigFile {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return nil
+	}
+	var config dockerConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil
+	}
+
+	return &config
+}
+
+// helperCredentials asks a docker credential helper
+// (docker-credential-ecr-login, docker-credential-gcloud, ...) for the
+// host's credentials.
+func helperCredentials(helper, host string) (username, password string, ok bool) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(host)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", false
+	}
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return "", "", false
+	}
+
+	return creds.Username, creds.Secret, creds.Username != ""
+}
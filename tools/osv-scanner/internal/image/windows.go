@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"encoding/xml"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// IsWindows reports whether the extracted filesystem came from a
+// Windows-based image, recognized by the Files/Hives layout Windows
+// layer tars use.
+func IsWindows(dir string) bool {
+	if info, err := os.Stat(filepath.Join(dir, "Files", "Windows")); err == nil && info.IsDir() {
+		return true
+	}
+	if info, err := os.Stat(filepath.Join(dir, "Hives")); err == nil && info.IsDir() {
+		return true
+	}
+
+	return false
+}
+
+// nuspecFile is the metadata of one NuGet-format package, which both
+// NuGet and Chocolatey install on disk.
+type nuspecFile struct {
+	Metadata struct {
+		ID      string `xml:"id"`
+		Version string `xml:"version"`
+	} `xml:"metadata"`
+}
+
+// ScanWindowsPackages inventories the identifiable packages of an
+// extracted Windows image: Chocolatey installs and NuGet package
+// directories, both recognizable by their .nuspec metadata. Software
+// installed via MSI leaves no package manifest on the filesystem and
+// cannot be inventoried; callers report that as a skipped source rather
+// than failing silently.
+func ScanWindowsPackages(dir string) []scanner.ScannedSource {
+	roots := make(map[string]*scanner.ScannedSource)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.Printf("Skipping %s: %v", path, err)
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".nuspec") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Skipping %s: %v", path, err)
+			return nil
+		}
+		var spec nuspecFile
+		if err := xml.Unmarshal(data, &spec); err != nil || spec.Metadata.ID == "" || spec.Metadata.Version == "" {
+			return nil
+		}
+
+		root, sourceType := packageRoot(path)
+		source, ok := roots[root]
+		if !ok {
+			source = &scanner.ScannedSource{
+				Source: models.SourceInfo{Path: root, Type: sourceType},
+			}
+			roots[root] = source
+		}
+		source.Packages = append(source.Packages, models.PackageInfo{
+			Name:      spec.Metadata.ID,
+			Version:   spec.Metadata.Version,
+			Ecosystem: "NuGet",
+		})
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Windows package walk of %s failed: %v", dir, err)
+	}
+
+	sources := make([]scanner.ScannedSource, 0, len(roots))
+	for _, source := range roots {
+		sources = append(sources, *source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Source.Path < sources[j].Source.Path
+	})
+
+	return sources
+}
+
+// packageRoot groups a .nuspec under its installation root: the
+// chocolatey lib directory for Chocolatey installs, otherwise the
+// directory two levels up (the NuGet packages directory in its
+// name/version layout).
+func packageRoot(nuspecPath string) (string, string) {
+	dir := filepath.Dir(nuspecPath)
+	for parent := dir; ; {
+		next := filepath.Dir(parent)
+		if next == parent {
+			break
+		}
+		if strings.EqualFold(filepath.Base(next), "chocolatey") {
+			return parent, "chocolatey-install"
+		}
+		parent = next
+	}
+
+	return filepath.Dir(filepath.Dir(dir)), "nuget-install"
+}
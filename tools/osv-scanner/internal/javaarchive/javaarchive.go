@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package javaarchive extracts the Maven artifacts embedded in Java
+// archives (jar/war/ear), including nested shaded jars, by reading the
+// pom.properties files bundled with each artifact. This catches vulnerable
+// libraries repackaged into application archives, log4j-style.
+package javaarchive
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/go/purl"
+
+	"github.com/google/osv/tools/osv-scanner/internal/extractor"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// maxNestedSize bounds how much of a nested jar is buffered for
+// recursion.
+const maxNestedSize = 512 << 20 // 512 MiB
+
+type javaExtractor struct{}
+
+func (javaExtractor) Name() string {
+	return "java/archive"
+}
+
+func (javaExtractor) Detect(p string) bool {
+	switch strings.ToLower(filepath.Ext(p)) {
+	case ".jar", ".war", ".ear":
+		return true
+	default:
+		return false
+	}
+}
+
+func (javaExtractor) Extract(p string, _ io.Reader) ([]models.PackageInfo, error) {
+	// The zip format needs random access, so the archive is reopened from
+	// its path rather than read from the stream.
+	z, err := zip.OpenReader(p)
+	if err != nil {
+		return nil, err
+	}
+	defer z.Close()
+
+	return scanZip(&z.Reader)
+}
+
+// scanZip collects the artifacts declared by every pom.properties in the
+// archive and recurses into nested jars.
+func scanZip(z *zip.Reader) ([]models.PackageInfo, error) {
+	var pkgs []models.PackageInfo
+	for _, f := range z.File {
+		base := path.Base(f.Name)
+		switch {
+		case base == "pom.properties":
+			rc, err := f.Open()
+			if err != nil {
+				continue
+			}
+			pkg, ok := parsePomProperties(rc)
+			rc.Close()
+			if ok {
+				pkgs = append(pkgs, pkg)
+			}
+		case strings.HasSuffix(strings.ToLower(base), ".jar"):
+			if f.UncompressedSize64 > maxNestedSize {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				continue
+			}
+			data, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				continue
+			}
+			nested, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				continue
+			}
+			nestedPkgs, err := scanZip(nested)
+			if err != nil {
+				continue
+			}
+			pkgs = append(pkgs, nestedPkgs...)
+		}
+	}
+
+	return pkgs, nil
+}
+
+// parsePomProperties reads the groupId/artifactId/version triple of a
+// Maven pom.properties.
+func parsePomProperties(r io.Reader) (models.PackageInfo, bool) {
+	props := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		props[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	group, artifact, version := props["groupId"], props["artifactId"], props["version"]
+	if group == "" || artifact == "" || version == "" {
+		return models.PackageInfo{}, false
+	}
+
+	return models.PackageInfo{
+		Name:      group + ":" + artifact,
+		Version:   version,
+		Ecosystem: "Maven",
+		PURL:      purl.FromOSV("Maven", group+":"+artifact, version).String(),
+	}, true
+}
+
+func init() {
+	extractor.Register(javaExtractor{})
+}
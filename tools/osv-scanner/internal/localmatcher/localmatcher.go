@@ -0,0 +1,367 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package localmatcher answers OSV queries against advisories loaded
+// from disk, evaluating affected ranges with the per-ecosystem version
+// ordering rules, so scans can run without the OSV API.
+package localmatcher
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/semantics"
+)
+
+// DB is an in-memory index of OSV advisories.
+type DB struct {
+	// byPackage indexes advisories by ecosystem-qualified package name.
+	byPackage map[string][]*osv.Vulnerability
+	// byRepo indexes advisories with GIT ranges by repository URL, for
+	// commit queries.
+	byRepo map[string][]*osv.Vulnerability
+	// byHash indexes advisories by the artifact hashes their records
+	// list (e.g. malicious-package records naming the published
+	// tarball), for matching unmanaged binary blobs.
+	byHash map[string][]*osv.Vulnerability
+}
+
+// CommitAffected reports whether commit falls inside a GIT range; it is
+// a hook so ancestry-aware resolution can be plugged in. The default
+// only recognizes commits that appear literally as an event, which is
+// exact but incomplete.
+var CommitAffected = func(repo, commit string, rng osv.Range) bool {
+	for _, event := range rng.Events {
+		if event.Introduced == commit || event.LastAffected == commit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadDir loads every .json advisory under dir.
+func LoadDir(dir string) (*DB, error) {
+	db := New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return db.add(path, f)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// LoadZip loads every .json advisory inside the zip archive at path, the
+// format OSV database exports use.
+func LoadZip(path string) (*DB, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	db := New()
+	for _, file := range r.File {
+		if filepath.Ext(file.Name) != ".json" {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = db.add(path+":"+file.Name, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// New returns an empty database; advisories are added with Add or via
+// the Load functions.
+func New() *DB {
+	return &DB{
+		byPackage: map[string][]*osv.Vulnerability{},
+		byRepo:    map[string][]*osv.Vulnerability{},
+		byHash:    map[string][]*osv.Vulnerability{},
+	}
+}
+
+func (db *DB) add(name string, r io.Reader) error {
+	var vuln osv.Vulnerability
+	if err := json.NewDecoder(r).Decode(&vuln); err != nil {
+		return fmt.Errorf("invalid advisory %s: %w", name, err)
+	}
+	db.Add(&vuln)
+
+	return nil
+}
+
+// Add indexes a single advisory. The Load functions are the usual entry
+// points; tests and fakes build databases directly.
+func (db *DB) Add(vuln *osv.Vulnerability) {
+	for _, affected := range vuln.Affected {
+		if affected.Package.Name != "" {
+			key := packageKey(affected.Package.Ecosystem, affected.Package.Name)
+			db.byPackage[key] = appendUnique(db.byPackage[key], vuln)
+		}
+		for _, rng := range affected.Ranges {
+			if rng.Type == "GIT" && rng.Repo != "" {
+				db.byRepo[rng.Repo] = appendUnique(db.byRepo[rng.Repo], vuln)
+			}
+		}
+		for _, hash := range collectHashes(affected.DatabaseSpecific) {
+			db.byHash[hash] = appendUnique(db.byHash[hash], vuln)
+		}
+	}
+	for _, hash := range collectHashes(vuln.DatabaseSpecific) {
+		db.byHash[hash] = appendUnique(db.byHash[hash], vuln)
+	}
+}
+
+// hashKeys are the database_specific fields artifact hashes have been
+// seen under; there is no schema-level home for them, so records vary.
+var hashKeys = []string{"sha256", "sha1", "md5", "artifact_hashes", "hashes"}
+
+// collectHashes pulls artifact hashes out of a database_specific block,
+// tolerating the shapes in the wild: a single hex string, a list of
+// them, or a map of algorithm to hash.
+func collectHashes(specific map[string]interface{}) []string {
+	var hashes []string
+	for _, key := range hashKeys {
+		hashes = appendHashes(hashes, specific[key])
+	}
+
+	return hashes
+}
+
+func appendHashes(hashes []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if isHex(v) {
+			hashes = append(hashes, strings.ToLower(v))
+		}
+	case []interface{}:
+		for _, item := range v {
+			hashes = appendHashes(hashes, item)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			hashes = appendHashes(hashes, item)
+		}
+	}
+
+	return hashes
+}
+
+// isHex reports whether s looks like a hex digest of a supported
+// algorithm (MD5, SHA-1 or SHA-256).
+func isHex(s string) bool {
+	if len(s) != 32 && len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// QueryHash returns the advisories whose records list the given
+// artifact hash (hex, any case).
+func (db *DB) QueryHash(hash string) []osv.Vulnerability {
+	var matched []osv.Vulnerability
+	for _, vuln := range db.byHash[strings.ToLower(hash)] {
+		matched = append(matched, *vuln)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	return matched
+}
+
+// Query returns the advisories affecting the queried package version or
+// commit, sorted by ID.
+func (db *DB) Query(query *osv.Query) []osv.Vulnerability {
+	var candidates []*osv.Vulnerability
+	if query.Commit != "" {
+		for _, vulns := range db.byRepo {
+			candidates = append(candidates, vulns...)
+		}
+	} else {
+		candidates = db.byPackage[packageKey(query.Package.Ecosystem, query.Package.Name)]
+	}
+
+	var matched []osv.Vulnerability
+	for _, vuln := range candidates {
+		if vulnAffects(vuln, query) {
+			matched = append(matched, *vuln)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	return matched
+}
+
+// packageKey builds the index key, dropping the release suffix of
+// ecosystems like "Debian:12" so advisories and queries using either
+// form meet.
+func packageKey(ecosystem, name string) string {
+	base, _, _ := strings.Cut(ecosystem, ":")
+
+	return base + "/" + name
+}
+
+func appendUnique(vulns []*osv.Vulnerability, vuln *osv.Vulnerability) []*osv.Vulnerability {
+	for _, v := range vulns {
+		if v == vuln {
+			return vulns
+		}
+	}
+
+	return append(vulns, vuln)
+}
+
+func vulnAffects(vuln *osv.Vulnerability, query *osv.Query) bool {
+	for _, affected := range vuln.Affected {
+		if query.Commit != "" {
+			for _, rng := range affected.Ranges {
+				if rng.Type == "GIT" && CommitAffected(rng.Repo, query.Commit, rng) {
+					return true
+				}
+			}
+
+			continue
+		}
+		if packageKey(affected.Package.Ecosystem, affected.Package.Name) !=
+			packageKey(query.Package.Ecosystem, query.Package.Name) {
+			continue
+		}
+		if affectedMatches(affected, query.Version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// affectedMatches evaluates one affected entry against a version, via
+// the explicit versions list and the SEMVER/ECOSYSTEM ranges.
+func affectedMatches(affected osv.Affected, version string) bool {
+	cmp, haveCmp := semantics.ForEcosystem(affected.Package.Ecosystem)
+	for _, v := range affected.Versions {
+		if v == version || (haveCmp && cmp.Compare(v, version) == 0) {
+			return true
+		}
+	}
+	for _, rng := range affected.Ranges {
+		switch rng.Type {
+		case "SEMVER":
+			if rangeContains(semverCmp{}, rng.Events, version) {
+				return true
+			}
+		case "ECOSYSTEM":
+			if haveCmp && rangeContains(cmp, rng.Events, version) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// semverCmp adapts the SemVer ordering for SEMVER ranges, which apply
+// regardless of ecosystem.
+type semverCmp struct{}
+
+func (semverCmp) Compare(a, b string) int {
+	cmp, _ := semantics.ForEcosystem("npm")
+
+	return cmp.Compare(a, b)
+}
+
+// rangeContains walks the range's events in version order, tracking
+// whether version is inside an affected span. "0" as an introduced
+// version means the beginning of time.
+func rangeContains(cmp semantics.Comparer, events []osv.Event, version string) bool {
+	sorted := make([]osv.Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := eventVersion(sorted[i]), eventVersion(sorted[j])
+		if a == "0" || b == "0" {
+			return a == "0" && b != "0"
+		}
+
+		return cmp.Compare(a, b) < 0
+	})
+
+	affected := false
+	for _, event := range sorted {
+		switch {
+		case event.Introduced == "0":
+			affected = true
+		case event.Introduced != "":
+			if cmp.Compare(version, event.Introduced) >= 0 {
+				affected = true
+			}
+		case event.Fixed != "":
+			if cmp.Compare(version, event.Fixed) >= 0 {
+				affected = false
+			}
+		case event.LastAffected != "":
+			if cmp.Compare(version, event.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+
+	return affected
+}
+
+func eventVersion(event osv.Event) string {
+	switch {
+	case event.Introduced != "":
+		return event.Introduced
+	case event.Fixed != "":
+		return event.Fixed
+	case event.LastAffected != "":
+		return event.LastAffected
+	default:
+		return event.Limit
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localmatcher
+
+import (
+	"testing"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// fixtureDB builds a database with one advisory per matching mechanism:
+// a SEMVER range, an ECOSYSTEM range, an explicit versions list, a GIT
+// range and an artifact hash.
+func fixtureDB() *DB {
+	db := New()
+	db.Add(&osv.Vulnerability{
+		ID: "GHSA-semver-range",
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: "npm", Name: "lodash"},
+			Ranges: []osv.Range{{
+				Type: "SEMVER",
+				Events: []osv.Event{
+					{Introduced: "0"},
+					{Fixed: "4.17.21"},
+				},
+			}},
+		}},
+	})
+	db.Add(&osv.Vulnerability{
+		ID: "DSA-ecosystem-range",
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: "Debian:12", Name: "curl"},
+			Ranges: []osv.Range{{
+				Type: "ECOSYSTEM",
+				Events: []osv.Event{
+					{Introduced: "7.88.1-1"},
+					{LastAffected: "7.88.1-10"},
+				},
+			}},
+		}},
+	})
+	db.Add(&osv.Vulnerability{
+		ID: "PYSEC-versions-list",
+		Affected: []osv.Affected{{
+			Package:  osv.Package{Ecosystem: "PyPI", Name: "requests"},
+			Versions: []string{"2.30.0", "2.31.0"},
+		}},
+	})
+	db.Add(&osv.Vulnerability{
+		ID: "OSV-git-range",
+		Affected: []osv.Affected{{
+			Ranges: []osv.Range{{
+				Type: "GIT",
+				Repo: "https://example.com/repo",
+				Events: []osv.Event{
+					{Introduced: "aaaa000000000000000000000000000000000000"},
+				},
+			}},
+		}},
+	})
+	db.Add(&osv.Vulnerability{
+		ID: "MAL-artifact-hash",
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: "npm", Name: "evil-package"},
+			DatabaseSpecific: map[string]interface{}{
+				"sha256": "aa00000000000000000000000000000000000000000000000000000000000000",
+			},
+		}},
+	})
+
+	return db
+}
+
+func queryIDs(db *DB, query *osv.Query) []string {
+	var ids []string
+	for _, vuln := range db.Query(query) {
+		ids = append(ids, vuln.ID)
+	}
+
+	return ids
+}
+
+func TestQueryRanges(t *testing.T) {
+	db := fixtureDB()
+	tests := []struct {
+		name  string
+		query osv.Query
+		want  []string
+	}{
+		{
+			"semver range hit",
+			osv.Query{Package: osv.Package{Ecosystem: "npm", Name: "lodash"}, Version: "4.17.20"},
+			[]string{"GHSA-semver-range"},
+		},
+		{
+			"semver fixed version excluded",
+			osv.Query{Package: osv.Package{Ecosystem: "npm", Name: "lodash"}, Version: "4.17.21"},
+			nil,
+		},
+		{
+			"ecosystem range includes last_affected",
+			osv.Query{Package: osv.Package{Ecosystem: "Debian:12", Name: "curl"}, Version: "7.88.1-10"},
+			[]string{"DSA-ecosystem-range"},
+		},
+		{
+			"ecosystem range excludes beyond last_affected",
+			osv.Query{Package: osv.Package{Ecosystem: "Debian:12", Name: "curl"}, Version: "7.88.1-11"},
+			nil,
+		},
+		{
+			"ecosystem range excludes before introduced",
+			osv.Query{Package: osv.Package{Ecosystem: "Debian:12", Name: "curl"}, Version: "7.87.0-1"},
+			nil,
+		},
+		{
+			"release suffix stripped from index key",
+			osv.Query{Package: osv.Package{Ecosystem: "Debian", Name: "curl"}, Version: "7.88.1-5"},
+			[]string{"DSA-ecosystem-range"},
+		},
+		{
+			"versions list hit",
+			osv.Query{Package: osv.Package{Ecosystem: "PyPI", Name: "requests"}, Version: "2.31.0"},
+			[]string{"PYSEC-versions-list"},
+		},
+		{
+			"versions list equivalent spelling",
+			osv.Query{Package: osv.Package{Ecosystem: "PyPI", Name: "requests"}, Version: "2.31"},
+			[]string{"PYSEC-versions-list"},
+		},
+		{
+			"versions list miss",
+			osv.Query{Package: osv.Package{Ecosystem: "PyPI", Name: "requests"}, Version: "2.32.0"},
+			nil,
+		},
+		{
+			"unknown package",
+			osv.Query{Package: osv.Package{Ecosystem: "npm", Name: "left-pad"}, Version: "1.0.0"},
+			nil,
+		},
+		{
+			"commit appearing as an event",
+			osv.Query{Commit: "aaaa000000000000000000000000000000000000"},
+			[]string{"OSV-git-range"},
+		},
+		{
+			"unknown commit",
+			osv.Query{Commit: "ffff000000000000000000000000000000000000"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := queryIDs(db, &tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Query(%+v) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Query(%+v) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryHash(t *testing.T) {
+	db := fixtureDB()
+	hash := "AA00000000000000000000000000000000000000000000000000000000000000"
+	// Hashes match hex case-insensitively.
+	vulns := db.QueryHash(hash)
+	if len(vulns) != 1 || vulns[0].ID != "MAL-artifact-hash" {
+		t.Errorf("QueryHash(%q) = %v, want [MAL-artifact-hash]", hash, vulns)
+	}
+	if got := db.QueryHash("0000000000000000000000000000000000000000"); len(got) != 0 {
+		t.Errorf("QueryHash on unknown hash = %v, want none", got)
+	}
+}
+
+func TestQuerySortedByID(t *testing.T) {
+	db := New()
+	for _, id := range []string{"GHSA-zzzz", "CVE-2023-0001", "GHSA-aaaa"} {
+		db.Add(&osv.Vulnerability{
+			ID: id,
+			Affected: []osv.Affected{{
+				Package:  osv.Package{Ecosystem: "npm", Name: "lodash"},
+				Versions: []string{"1.0.0"},
+			}},
+		})
+	}
+	got := queryIDs(db, &osv.Query{
+		Package: osv.Package{Ecosystem: "npm", Name: "lodash"},
+		Version: "1.0.0",
+	})
+	want := []string{"CVE-2023-0001", "GHSA-aaaa", "GHSA-zzzz"}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("Query returned %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lockfile provides extractors for dependency lockfiles, registered
+// with the extractor registry under "lockfile/<filename>".
+package lockfile
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/google/osv/tools/osv-scanner/internal/extractor"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// ParseFunc parses a single lockfile into the packages it declares.
+type ParseFunc func(r io.Reader) ([]models.PackageInfo, error)
+
+// fileExtractor adapts a ParseFunc for a fixed lockfile base name to the
+// extractor.Extractor interface.
+type fileExtractor struct {
+	filename string
+	parse    ParseFunc
+}
+
+func (e fileExtractor) Name() string {
+	return "lockfile/" + e.filename
+}
+
+func (e fileExtractor) Detect(path string) bool {
+	return filepath.Base(path) == e.filename
+}
+
+func (e fileExtractor) Extract(_ string, r io.Reader) ([]models.PackageInfo, error) {
+	return e.parse(r)
+}
+
+func init() {
+	extractor.Register(fileExtractor{"package-lock.json", ParseNpmLock})
+	extractor.Register(fileExtractor{"requirements.txt", ParseRequirementsTxt})
+}
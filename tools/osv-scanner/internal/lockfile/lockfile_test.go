@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/osv/tools/osv-scanner/internal/extractor"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// sorted orders packages by name for comparison; map-backed lockfile
+// formats do not guarantee an extraction order.
+func sorted(pkgs []models.PackageInfo) []models.PackageInfo {
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Name < pkgs[j].Name })
+
+	return pkgs
+}
+
+func TestParseNpmLockV3(t *testing.T) {
+	lock := `{
+		"name": "example",
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "example", "version": "1.0.0"},
+			"node_modules/lodash": {"version": "4.17.20"},
+			"node_modules/lodash/node_modules/@babel/core": {"version": "7.23.0"},
+			"node_modules/unresolved-git-dep": {}
+		}
+	}`
+	got, err := ParseNpmLock(strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("ParseNpmLock: %v", err)
+	}
+	want := []models.PackageInfo{
+		{Name: "@babel/core", Version: "7.23.0", Ecosystem: "npm"},
+		{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+	}
+	if got = sorted(got); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNpmLock = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseNpmLockV1(t *testing.T) {
+	lock := `{
+		"name": "example",
+		"lockfileVersion": 1,
+		"dependencies": {
+			"lodash": {"version": "4.17.20"},
+			"@angular/core": {"version": "12.0.0"}
+		}
+	}`
+	got, err := ParseNpmLock(strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("ParseNpmLock: %v", err)
+	}
+	want := []models.PackageInfo{
+		{Name: "@angular/core", Version: "12.0.0", Ecosystem: "npm"},
+		{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+	}
+	if got = sorted(got); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNpmLock = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseNpmLockInvalid(t *testing.T) {
+	if _, err := ParseNpmLock(strings.NewReader("not json")); err == nil {
+		t.Errorf("ParseNpmLock on invalid input succeeded, want error")
+	}
+}
+
+func TestParseRequirementsTxt(t *testing.T) {
+	requirements := `# a comment
+requests==2.31.0
+flask == 3.0.0
+urllib3==2.1.0 ; python_version >= "3.8"
+cryptography[ssh]==42.0.0
+django>=4.0
+-r other-requirements.txt
+
+certifi==2024.2.2 # inline comment
+`
+	got, err := ParseRequirementsTxt(strings.NewReader(requirements))
+	if err != nil {
+		t.Fatalf("ParseRequirementsTxt: %v", err)
+	}
+	// Only the "==" pins identify a queryable version; django and the
+	// -r include are skipped.
+	want := []models.PackageInfo{
+		{Name: "requests", Version: "2.31.0", Ecosystem: "PyPI"},
+		{Name: "flask", Version: "3.0.0", Ecosystem: "PyPI"},
+		{Name: "urllib3", Version: "2.1.0", Ecosystem: "PyPI"},
+		{Name: "cryptography", Version: "42.0.0", Ecosystem: "PyPI"},
+		{Name: "certifi", Version: "2024.2.2", Ecosystem: "PyPI"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRequirementsTxt = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractorRegistration(t *testing.T) {
+	for _, path := range []string{
+		"package-lock.json",
+		"/some/project/package-lock.json",
+		"requirements.txt",
+	} {
+		if _, ok := extractor.ForPath(path); !ok {
+			t.Errorf("ForPath(%q) found no extractor", path)
+		}
+	}
+	if _, ok := extractor.ForPath("/some/project/README.md"); ok {
+		t.Errorf("ForPath matched a file no extractor handles")
+	}
+}
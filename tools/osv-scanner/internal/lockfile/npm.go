@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+type npmLockPackage struct {
+	Version  string `json:"version"`
+	Resolved string `json:"resolved"`
+}
+
+type npmLockfile struct {
+	// Packages is used by lockfileVersion >= 2, keyed by install path.
+	Packages map[string]npmLockPackage `json:"packages,omitempty"`
+	// Dependencies is used by lockfileVersion 1, keyed by package name.
+	Dependencies map[string]npmLockPackage `json:"dependencies,omitempty"`
+}
+
+// ParseNpmLock parses an npm package-lock.json (v1, v2 or v3).
+func ParseNpmLock(r io.Reader) ([]models.PackageInfo, error) {
+	var lock npmLockfile
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+
+	var pkgs []models.PackageInfo
+	if lock.Packages != nil {
+		for installPath, pkg := range lock.Packages {
+			// The root project is keyed by "".
+			if installPath == "" || pkg.Version == "" {
+				continue
+			}
+			// Install paths look like "node_modules/foo/node_modules/@bar/baz";
+			// the name is everything after the last "node_modules/".
+			name := installPath
+			if i := strings.LastIndex(installPath, "node_modules/"); i != -1 {
+				name = installPath[i+len("node_modules/"):]
+			}
+			pkgs = append(pkgs, models.PackageInfo{
+				Name:      name,
+				Version:   pkg.Version,
+				Ecosystem: "npm",
+			})
+		}
+
+		return pkgs, nil
+	}
+
+	for name, pkg := range lock.Dependencies {
+		if pkg.Version == "" {
+			continue
+		}
+		pkgs = append(pkgs, models.PackageInfo{
+			Name:      name,
+			Version:   pkg.Version,
+			Ecosystem: "npm",
+		})
+	}
+
+	return pkgs, nil
+}
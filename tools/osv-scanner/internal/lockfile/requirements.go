@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// ParseRequirementsTxt parses a pip requirements.txt, extracting only the
+// exactly pinned ("==") requirements, which are the only ones that identify
+// a queryable version.
+func ParseRequirementsTxt(r io.Reader) ([]models.PackageInfo, error) {
+	var pkgs []models.PackageInfo
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		// Strip environment markers and inline comments.
+		if i := strings.IndexAny(line, ";#"); i != -1 {
+			line = strings.TrimSpace(line[:i])
+		}
+		name, version, ok := strings.Cut(line, "==")
+		if !ok {
+			continue
+		}
+		// Strip extras, e.g. "requests[security]".
+		if i := strings.Index(name, "["); i != -1 {
+			name = name[:i]
+		}
+		pkgs = append(pkgs, models.PackageInfo{
+			Name:      strings.TrimSpace(name),
+			Version:   strings.TrimSpace(version),
+			Ecosystem: "PyPI",
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pkgs, nil
+}
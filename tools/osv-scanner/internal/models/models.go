@@ -0,0 +1,231 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package models holds the scan result types shared between the scanning,
+// storage and reporting layers of the scanner.
+package models
+
+import (
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// VulnerabilityResults is the top-level result of a scan.
+type VulnerabilityResults struct {
+	// Date is the time the scan started. It is always recorded in UTC so
+	// that machine formats serialize it as an RFC3339 UTC timestamp;
+	// human-readable output renders it in the configured timezone.
+	Date    time.Time       `json:"date"`
+	Results []PackageSource `json:"results"`
+	// Counts aggregates findings by severity across all sources. It is
+	// filled in by ComputeCounts before reporting.
+	Counts *SeverityCounts `json:"counts,omitempty"`
+	// Manifest records how the scan was produced, for auditing and
+	// reproduction.
+	Manifest *ScanManifest `json:"manifest,omitempty"`
+	// Skipped records scan targets (or parts of them) that could not be
+	// inventoried, so unsupported content shows up in the report rather
+	// than silently producing an empty result.
+	Skipped []SkippedSource `json:"skipped,omitempty"`
+	// Warnings records inputs that were scanned but look inconsistent,
+	// such as stale lockfiles.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// SkippedSource is one scan target that could not be inventoried.
+type SkippedSource struct {
+	Path   string `json:"path"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Warning flags a scan input that was scanned but looks inconsistent —
+// e.g. a lockfile that is stale relative to its manifest — so readers
+// know the findings for it may be misleading.
+type Warning struct {
+	Path   string `json:"path"`
+	Detail string `json:"detail"`
+}
+
+// ScanManifest describes the inputs and environment of a scan precisely
+// enough to audit or reproduce its results later.
+type ScanManifest struct {
+	// ToolVersion is the osv-scanner version that ran the scan.
+	ToolVersion string `json:"tool_version"`
+	// Database identifies what answered the queries: "api" or the path
+	// of a local database.
+	Database string `json:"database"`
+	// DatabaseTimestamp approximates the advisory snapshot the scan saw:
+	// the latest modified time across all returned records.
+	DatabaseTimestamp time.Time `json:"database_timestamp,omitempty"`
+	// Flags lists the command line flags the scan was invoked with.
+	Flags []string `json:"flags,omitempty"`
+	// Inputs digests every scanned input file.
+	Inputs []InputDigest `json:"inputs,omitempty"`
+}
+
+// InputDigest is the SHA-256 digest of one scanned input file.
+type InputDigest struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// SourceInfo identifies where a set of packages was found.
+type SourceInfo struct {
+	// Path is the filesystem path (or image reference) that was scanned.
+	Path string `json:"path"`
+	// Type describes the kind of source, e.g. "lockfile", "sbom", "git".
+	Type string `json:"type"`
+	// Member is the workspace member project the source belongs to, when
+	// the scan root is a monorepo workspace.
+	Member string `json:"member,omitempty"`
+}
+
+// String implements fmt.Stringer.
+func (s SourceInfo) String() string {
+	return s.Type + ":" + s.Path
+}
+
+// PackageSource groups the vulnerable packages found in a single source.
+type PackageSource struct {
+	Source   SourceInfo     `json:"source"`
+	Packages []PackageVulns `json:"packages"`
+	// Counts aggregates this source's findings by severity. It is filled
+	// in by ComputeCounts before reporting.
+	Counts *SeverityCounts `json:"counts,omitempty"`
+}
+
+// PackageInfo identifies a single scanned package.
+type PackageInfo struct {
+	Name      string `json:"name,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Ecosystem string `json:"ecosystem,omitempty"`
+	// PURL may be set by extractors that identify packages by package URL
+	// rather than by name and ecosystem.
+	PURL string `json:"purl,omitempty"`
+	// Commit is set instead of Name/Version/Ecosystem for git sources.
+	Commit string `json:"commit,omitempty"`
+	// SourceName is the source package a binary OS package was built
+	// from, when it differs from Name. OS advisories are keyed by source
+	// package, so queries use it while reports keep the binary name.
+	SourceName string `json:"source_name,omitempty"`
+}
+
+// PackageVulns pairs a package with the vulnerabilities that affect it.
+type PackageVulns struct {
+	Package         PackageInfo         `json:"package"`
+	Vulnerabilities []osv.Vulnerability `json:"vulnerabilities"`
+	// Groups partitions Vulnerabilities into sets of aliased records, one
+	// entry per distinct weakness.
+	Groups []GroupInfo `json:"groups,omitempty"`
+	// DepsDev carries ecosystem health data for the package, when
+	// deps.dev enrichment is enabled.
+	DepsDev *DepsDevInfo `json:"depsdev,omitempty"`
+}
+
+// DepsDevInfo annotates a vulnerable package with deps.dev data that
+// helps prioritize a fix: how many packages depend on it (blast
+// radius), the health of its upstream project, and what an upgrade
+// would target.
+type DepsDevInfo struct {
+	// DependentCount is the number of packages depending on this version.
+	DependentCount int64 `json:"dependent_count,omitempty"`
+	// ScorecardScore is the project's overall OpenSSF Scorecard score.
+	ScorecardScore float64 `json:"scorecard_score,omitempty"`
+	// LatestVersion is the registry's current default version.
+	LatestVersion string `json:"latest_version,omitempty"`
+}
+
+// SeverityCounts tallies findings by qualitative severity. One finding
+// is one group of aliased records, i.e. one distinct weakness.
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown"`
+	Total    int `json:"total"`
+}
+
+// add records one finding of the given level.
+func (c *SeverityCounts) add(level severity.Level) {
+	switch level {
+	case severity.LevelCritical:
+		c.Critical++
+	case severity.LevelHigh:
+		c.High++
+	case severity.LevelMedium:
+		c.Medium++
+	case severity.LevelLow:
+		c.Low++
+	default:
+		c.Unknown++
+	}
+	c.Total++
+}
+
+// ComputeCounts fills in the per-source and overall severity counts.
+// Each finding group counts once, at the highest severity across its
+// aliased records.
+func (r *VulnerabilityResults) ComputeCounts() {
+	overall := &SeverityCounts{}
+	for i := range r.Results {
+		counts := &SeverityCounts{}
+		for _, pkg := range r.Results[i].Packages {
+			byID := make(map[string]osv.Vulnerability, len(pkg.Vulnerabilities))
+			for _, vuln := range pkg.Vulnerabilities {
+				byID[vuln.ID] = vuln
+			}
+			for _, group := range pkg.Groups {
+				var vulns []osv.Vulnerability
+				for _, id := range group.IDs {
+					if vuln, ok := byID[id]; ok {
+						vulns = append(vulns, vuln)
+					}
+				}
+				_, level := severity.MaxOf(vulns)
+				counts.add(level)
+				overall.add(level)
+			}
+		}
+		r.Results[i].Counts = counts
+	}
+	r.Counts = overall
+}
+
+// GroupInfo describes one group of aliased vulnerabilities. IDs is sorted
+// with the canonical ID first.
+type GroupInfo struct {
+	IDs []string `json:"ids"`
+	// EPSS is the highest FIRST EPSS exploit probability across the
+	// group's CVEs, when enrichment is enabled.
+	EPSS float64 `json:"epss,omitempty"`
+	// KEV reports whether any of the group's CVEs is in the CISA Known
+	// Exploited Vulnerabilities catalog, when enrichment is enabled.
+	KEV bool `json:"kev,omitempty"`
+	// Annotation is the organizational triage state the effective config
+	// declares for one of the group's IDs, when it declares any.
+	Annotation *Annotation `json:"annotation,omitempty"`
+}
+
+// Annotation is tracking metadata for a finding, mapped from a
+// vulnerability ID by the config file, so reports reflect triage state:
+// the ticket it is tracked under, who owns it and when the fix is due.
+type Annotation struct {
+	Ticket string    `json:"ticket,omitempty"`
+	Owner  string    `json:"owner,omitempty"`
+	Due    time.Time `json:"due,omitempty"`
+}
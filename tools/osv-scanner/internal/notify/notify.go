@@ -0,0 +1,220 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify delivers alerts about new findings to configurable
+// sinks: Slack, generic webhooks and email. Sinks are declared in a
+// TOML file so a daemon deployment can fan one event out to several
+// channels without code changes.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// sendTimeout bounds one delivery attempt to one sink.
+const sendTimeout = 30 * time.Second
+
+// Event is one alert: a rescan or scan found vulnerabilities affecting
+// an artifact that its previous scan did not.
+type Event struct {
+	// Digest identifies the artifact; Ref is the name it was submitted
+	// under.
+	Digest string    `json:"digest"`
+	Ref    string    `json:"ref,omitempty"`
+	Time   time.Time `json:"time"`
+	// New lists the newly affecting finding groups (canonical IDs).
+	New []string `json:"new"`
+	// WasClean reports whether the artifact had no findings at all
+	// before this event.
+	WasClean bool `json:"was_clean"`
+}
+
+// summary renders the event as one human-readable line.
+func (e Event) summary() string {
+	subject := fmt.Sprintf("artifact %s", e.Ref)
+	if e.Ref == "" {
+		subject = fmt.Sprintf("artifact %s", e.Digest)
+	}
+	if e.WasClean {
+		return fmt.Sprintf("osv-scanner: previously clean %s is now affected by %s", subject, strings.Join(e.New, ", "))
+	}
+
+	return fmt.Sprintf("osv-scanner: %s has new findings: %s", subject, strings.Join(e.New, ", "))
+}
+
+// Sink delivers one event to one destination.
+type Sink interface {
+	// Name identifies the sink in logs.
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// sinkConfig is one [[sink]] table in the configuration file.
+type sinkConfig struct {
+	// Type is "slack", "webhook" or "email".
+	Type string `toml:"type"`
+	// URL is the Slack incoming-webhook or generic webhook endpoint.
+	URL string `toml:"url"`
+	// Server is the SMTP host:port for email sinks.
+	Server string   `toml:"server"`
+	From   string   `toml:"from"`
+	To     []string `toml:"to"`
+	// Username and Password authenticate to the SMTP server when set.
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+}
+
+type sinksFile struct {
+	Sinks []sinkConfig `toml:"sink"`
+}
+
+// Load reads the sink declarations at path.
+func Load(path string) ([]Sink, error) {
+	var file sinksFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, fmt.Errorf("failed to load notification config %s: %w", path, err)
+	}
+	if len(file.Sinks) == 0 {
+		return nil, fmt.Errorf("notification config %s declares no sinks", path)
+	}
+
+	sinks := make([]Sink, 0, len(file.Sinks))
+	for i, cfg := range file.Sinks {
+		switch cfg.Type {
+		case "slack":
+			if cfg.URL == "" {
+				return nil, fmt.Errorf("sink %d: slack sink needs a url", i)
+			}
+			sinks = append(sinks, &slackSink{url: cfg.URL})
+		case "webhook":
+			if cfg.URL == "" {
+				return nil, fmt.Errorf("sink %d: webhook sink needs a url", i)
+			}
+			sinks = append(sinks, &webhookSink{url: cfg.URL})
+		case "email":
+			if cfg.Server == "" || cfg.From == "" || len(cfg.To) == 0 {
+				return nil, fmt.Errorf("sink %d: email sink needs server, from and to", i)
+			}
+			sinks = append(sinks, &emailSink{cfg: cfg})
+		default:
+			return nil, fmt.Errorf("sink %d: unknown type %q", i, cfg.Type)
+		}
+	}
+
+	return sinks, nil
+}
+
+// Dispatch delivers the event to every sink. Failures are logged and
+// the remaining sinks still get the event; alerting should degrade per
+// channel, not all or nothing.
+func Dispatch(ctx context.Context, sinks []Sink, event Event) {
+	for _, sink := range sinks {
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		if err := sink.Send(sendCtx, event); err != nil {
+			log.Printf("Failed to notify %s: %v", sink.Name(), err)
+		}
+		cancel()
+	}
+}
+
+// slackSink posts the event summary to a Slack incoming webhook.
+type slackSink struct {
+	url string
+}
+
+func (s *slackSink) Name() string { return "slack" }
+
+func (s *slackSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(map[string]string{"text": event.summary()})
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, s.url, payload)
+}
+
+// webhookSink posts the full event as JSON to an arbitrary endpoint.
+type webhookSink struct {
+	url string
+}
+
+func (w *webhookSink) Name() string { return "webhook" }
+
+func (w *webhookSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, w.url, payload)
+}
+
+// post sends one JSON payload and treats any non-2xx response as an
+// error.
+func post(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	return nil
+}
+
+// emailSink sends the event as a plain-text message over SMTP.
+type emailSink struct {
+	cfg sinkConfig
+}
+
+func (e *emailSink) Name() string { return "email" }
+
+func (e *emailSink) Send(ctx context.Context, event Event) error {
+	var auth smtp.Auth
+	if e.cfg.Username != "" {
+		host, _, _ := strings.Cut(e.cfg.Server, ":")
+		auth = smtp.PlainAuth("", e.cfg.Username, e.cfg.Password, host)
+	}
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		e.cfg.From, strings.Join(e.cfg.To, ", "), event.summary(), event.summary())
+	// net/smtp has no context support; run the send on the side so the
+	// dispatcher's timeout still bounds how long we wait on it.
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(e.cfg.Server, auth, e.cfg.From, e.cfg.To, []byte(body))
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
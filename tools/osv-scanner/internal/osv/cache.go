@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// EnableCache routes this package's requests through a disk cache at
+// dir honoring the server's validation headers (ETag and
+// Last-Modified). Individual record fetches during hydration then cost
+// a conditional request — answered 304 without a body for unchanged
+// records — which makes hydrating thousands of records nearly free on
+// repeat runs. An empty dir uses the osv-scanner directory under the
+// user cache directory (XDG_CACHE_HOME on Linux).
+//
+// Call after any transport changes such as UseClientCertificate, so the
+// cache wraps the transport actually in use.
+func EnableCache(dir string) error {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return fmt.Errorf("no cache directory available: %w", err)
+		}
+		dir = filepath.Join(base, "osv-scanner", "http")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient = &http.Client{
+		Transport: &cachingTransport{base: base, dir: dir},
+	}
+
+	return nil
+}
+
+// cacheEntry is one stored response.
+type cacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// cachingTransport is a RoundTripper that revalidates cached GET
+// responses with conditional requests. Cache read or write failures
+// degrade to plain network requests; caching is an optimization, never
+// a reason to fail.
+type cachingTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only idempotent detail fetches are cacheable; queries are POSTs
+	// and pass straight through.
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	entry := t.load(req.URL.String())
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK (cached)"
+		resp.Body = io.NopCloser(bytes.NewReader(entry.Body))
+		resp.ContentLength = int64(len(entry.Body))
+
+		return resp, nil
+	}
+
+	// Only store responses the server gave validators for; without one
+	// there is nothing to revalidate against next time.
+	if resp.StatusCode == http.StatusOK &&
+		(resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "") {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.store(&cacheEntry{
+			URL:          req.URL.String(),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+	}
+
+	return resp, nil
+}
+
+// path returns the cache file for a URL.
+func (t *cachingTransport) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(t.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads the cached entry for url, if any.
+func (t *cachingTransport) load(url string) *cacheEntry {
+	data, err := os.ReadFile(t.path(url))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return nil
+	}
+
+	return &entry
+}
+
+// store writes an entry atomically, via a temporary file and rename, so
+// a concurrent scan never reads a half-written cache file.
+func (t *cachingTransport) store(entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(t.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), t.path(entry.URL)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// DetermineVersionEndpoint is the URL of the experimental endpoint that
+// estimates a C/C++ library's version from its file hashes.
+var DetermineVersionEndpoint = "https://api.osv.dev/v1experimental/determineversion"
+
+// DetermineVersionHash is the MD5 digest of one file of the library.
+// The hash serializes as base64, as the API expects.
+type DetermineVersionHash struct {
+	Path string `json:"file_path"`
+	Hash []byte `json:"hash"`
+}
+
+// DetermineVersionQuery is a request to the determineversion endpoint.
+type DetermineVersionQuery struct {
+	// Name is an optional hint of the library's name.
+	Name       string                 `json:"name,omitempty"`
+	FileHashes []DetermineVersionHash `json:"file_hashes"`
+}
+
+// DetermineVersionMatch is one candidate library version, scored by the
+// fraction of file hashes that matched.
+type DetermineVersionMatch struct {
+	Score    float64 `json:"score"`
+	RepoInfo struct {
+		Type    string `json:"type"`
+		Address string `json:"address"`
+		Tag     string `json:"tag"`
+		Version string `json:"version"`
+	} `json:"repo_info"`
+}
+
+// DetermineVersionResponse lists candidate matches, best first.
+type DetermineVersionResponse struct {
+	Matches []DetermineVersionMatch `json:"matches"`
+}
+
+// DetermineVersion estimates which library version a set of file hashes
+// was taken from.
+func DetermineVersion(ctx context.Context, query *DetermineVersionQuery) (*DetermineVersionResponse, error) {
+	requestBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, DetermineVersionEndpoint, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("determineversion", resp)
+	}
+	var versionResp DetermineVersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&versionResp); err != nil {
+		return nil, err
+	}
+
+	return &versionResp, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DownloadEndpoint is the base URL of the bucket serving the database
+// exports, one all.zip per ecosystem.
+var DownloadEndpoint = "https://osv-vulnerabilities.storage.googleapis.com"
+
+// downloadMeta is the sidecar recording what version of an export is on
+// disk, for delta detection on the next sync.
+type downloadMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// DownloadEcosystem syncs the advisory export for one ecosystem into
+// dir, returning the path of the zip (dir/<ecosystem>/all.zip, loadable
+// with the local matcher). Repeat calls are incremental: the export is
+// revalidated with a conditional request and only re-downloaded when
+// the bucket has a newer version. Downloads are verified against the
+// bucket's MD5 content hash and written atomically, so an interrupted
+// sync never leaves a corrupt or half-written export behind.
+func DownloadEcosystem(ctx context.Context, ecosystem, dir string) (string, error) {
+	destDir := filepath.Join(dir, ecosystem)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", err
+	}
+	dest := filepath.Join(destDir, "all.zip")
+	metaPath := dest + ".meta"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		DownloadEndpoint+"/"+url.PathEscape(ecosystem)+"/all.zip", nil)
+	if err != nil {
+		return "", err
+	}
+	var meta downloadMeta
+	if data, err := os.ReadFile(metaPath); err == nil && json.Unmarshal(data, &meta) == nil {
+		if _, err := os.Stat(dest); err == nil {
+			if meta.ETag != "" {
+				req.Header.Set("If-None-Match", meta.ETag)
+			}
+			if meta.LastModified != "" {
+				req.Header.Set("If-Modified-Since", meta.LastModified)
+			}
+		}
+	}
+
+	resp, err := do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return dest, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError("download "+ecosystem, resp)
+	}
+
+	tmp, err := os.CreateTemp(destDir, ".all-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to download %s export: %w", ecosystem, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if want := bucketMD5(resp.Header); want != "" {
+		got := base64.StdEncoding.EncodeToString(hash.Sum(nil))
+		if got != want {
+			return "", fmt.Errorf("%s export checksum mismatch: got %s, bucket says %s", ecosystem, got, want)
+		}
+	}
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", err
+	}
+	meta = downloadMeta{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if data, err := json.Marshal(meta); err == nil {
+		// A failed sidecar write only costs a re-download next time.
+		os.WriteFile(metaPath, data, 0644)
+	}
+
+	return dest, nil
+}
+
+// bucketMD5 extracts the base64 MD5 from the bucket's x-goog-hash
+// header, which carries comma-separated algorithm=value pairs.
+func bucketMD5(header http.Header) string {
+	for _, value := range header.Values("x-goog-hash") {
+		for _, pair := range strings.Split(value, ",") {
+			if hash, ok := strings.CutPrefix(strings.TrimSpace(pair), "md5="); ok {
+				return hash
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import "strings"
+
+// coveredEcosystems are the ecosystems the OSV database has records
+// for, per https://ossf.github.io/osv-schema/#defined-ecosystems.
+// Queries in other ecosystems come back empty, which is not the same
+// as "no vulnerabilities".
+var coveredEcosystems = map[string]bool{
+	"AlmaLinux":      true,
+	"Alpine":         true,
+	"Android":        true,
+	"Bioconductor":   true,
+	"Bitnami":        true,
+	"CRAN":           true,
+	"Chainguard":     true,
+	"ConanCenter":    true,
+	"Debian":         true,
+	"GHC":            true,
+	"GitHub Actions": true,
+	"Go":             true,
+	"Hackage":        true,
+	"Hex":            true,
+	"Linux":          true,
+	"Mageia":         true,
+	"Maven":          true,
+	"NuGet":          true,
+	"OSS-Fuzz":       true,
+	"Packagist":      true,
+	"Pub":            true,
+	"PyPI":           true,
+	"Red Hat":        true,
+	"Rocky Linux":    true,
+	"RubyGems":       true,
+	"SUSE":           true,
+	"SwiftURL":       true,
+	"Ubuntu":         true,
+	"Wolfi":          true,
+	"crates.io":      true,
+	"npm":            true,
+	"openSUSE":       true,
+}
+
+// CoveredEcosystem reports whether OSV has records for the given
+// ecosystem, ignoring release suffixes like "Debian:12".
+func CoveredEcosystem(ecosystem string) bool {
+	base, _, _ := strings.Cut(ecosystem, ":")
+
+	return coveredEcosystems[base]
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors callers can test with errors.Is to differentiate
+// handling: backing off on rate limits, retrying on outages, and
+// dropping (not retrying) invalid queries.
+var (
+	// ErrRateLimited matches 429 responses.
+	ErrRateLimited = errors.New("rate limited by the OSV API")
+	// ErrServerUnavailable matches 5xx responses.
+	ErrServerUnavailable = errors.New("OSV API unavailable")
+	// ErrInvalidQuery matches 400 responses.
+	ErrInvalidQuery = errors.New("invalid OSV query")
+)
+
+// APIError is a non-OK response from the OSV API. It matches the
+// sentinel errors above via errors.Is according to its status code.
+type APIError struct {
+	// Op names the failed operation, e.g. "batch query" or "get
+	// GHSA-xxxx".
+	Op         string
+	StatusCode int
+	Status     string
+	// Body is the start of the response body, which the API uses for
+	// human-readable detail.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s failed: %s", e.Op, e.Status)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+
+	return msg
+}
+
+// Is reports whether the error matches one of the package's sentinel
+// errors, keyed off the status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerUnavailable:
+		return e.StatusCode >= 500
+	case ErrInvalidQuery:
+		return e.StatusCode == http.StatusBadRequest
+	}
+
+	return false
+}
+
+// InvalidQueryError pinpoints the query within a batch that the API
+// rejected, so callers can report the offending source and continue
+// with the remainder. It matches ErrInvalidQuery via errors.Is.
+type InvalidQueryError struct {
+	// Index is the position of the rejected query in the submitted
+	// batch.
+	Index int
+	Cause *APIError
+}
+
+func (e *InvalidQueryError) Error() string {
+	return fmt.Sprintf("query %d: %v", e.Index, e.Cause)
+}
+
+func (e *InvalidQueryError) Unwrap() error {
+	return e.Cause
+}
+
+// BatchError reports the invalid queries of a batch that otherwise
+// succeeded. MakeRequest returns it alongside the partial response;
+// callers attribute the rejected queries to their sources and continue.
+type BatchError struct {
+	Invalid []*InvalidQueryError
+}
+
+func (e *BatchError) Error() string {
+	if len(e.Invalid) == 1 {
+		return e.Invalid[0].Error()
+	}
+
+	return fmt.Sprintf("%d of the batched queries were invalid (first: %v)", len(e.Invalid), e.Invalid[0])
+}
+
+// responseError builds the APIError for a non-OK response, consuming up
+// to a few KB of body for detail.
+func responseError(op string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	return &APIError{
+		Op:         op,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
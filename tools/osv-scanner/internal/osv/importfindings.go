@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ImportFindingsEndpoint is the URL of the experimental endpoint
+// reporting data-quality findings for a home database's records.
+var ImportFindingsEndpoint = "https://api.osv.dev/v1experimental/importfindings"
+
+// ImportFinding describes why one record of a home database failed
+// import-time quality checks.
+type ImportFinding struct {
+	// BugID is the record the findings are about.
+	BugID string `json:"bug_id"`
+	// Source is the home database the record came from.
+	Source string `json:"source"`
+	// Findings lists the quality check failures, e.g.
+	// "IMPORT_FINDING_TYPE_INVALID_JSON".
+	Findings []string `json:"findings"`
+	// FirstSeen is when the record first failed a check; LastAttempt is
+	// the most recent import attempt.
+	FirstSeen   time.Time `json:"first_seen"`
+	LastAttempt time.Time `json:"last_attempt"`
+}
+
+// ImportFindingsResponse lists the records of a source that currently
+// fail import.
+type ImportFindingsResponse struct {
+	InvalidRecords []ImportFinding `json:"invalid_records"`
+}
+
+// ImportFindings returns the data-quality findings for the given home
+// database source (e.g. "uvi"), so database operators can check the
+// import health of their records programmatically.
+func ImportFindings(ctx context.Context, source string) (*ImportFindingsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ImportFindingsEndpoint+"/"+source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("importfindings "+source, resp)
+	}
+	var findingsResp ImportFindingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&findingsResp); err != nil {
+		return nil, err
+	}
+
+	return &findingsResp, nil
+}
+
+// RecordStatus returns the import status of a single record of a home
+// database: its current quality findings, or ok when the record has
+// none.
+func RecordStatus(ctx context.Context, source, bugID string) (finding *ImportFinding, ok bool, err error) {
+	resp, err := ImportFindings(ctx, source)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range resp.InvalidRecords {
+		if resp.InvalidRecords[i].BugID == bugID {
+			return &resp.InvalidRecords[i], false, nil
+		}
+	}
+
+	return nil, true, nil
+}
@@ -0,0 +1,424 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osv implements the client for the OSV API
+// (https://osv.dev/docs/), including batched queries and hydration of the
+// minimal vulnerability entries the batch endpoint returns.
+package osv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// The endpoint URLs are variables so tests can point the client at a
+// fake server (see the osvtest package).
+var (
+	// QueryEndpoint is the URL for posting single queries to OSV.
+	QueryEndpoint = "https://api.osv.dev/v1/query"
+	// BatchQueryEndpoint is the URL for posting batched queries to OSV.
+	BatchQueryEndpoint = "https://api.osv.dev/v1/querybatch"
+	// GetEndpoint is the URL for getting vulnerabilities from OSV by ID.
+	GetEndpoint = "https://api.osv.dev/v1/vulns"
+)
+
+// maxQueriesPerRequest is the maximum number of queries the batch
+// endpoint accepts in a single request.
+const maxQueriesPerRequest = 1000
+
+// Package represents a package identifier for OSV.
+type Package struct {
+	PURL      string `json:"purl,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Ecosystem string `json:"ecosystem,omitempty"`
+}
+
+// Query represents a query to OSV.
+type Query struct {
+	Commit  string  `json:"commit,omitempty"`
+	Package Package `json:"package,omitempty"`
+	Version string  `json:"version,omitempty"`
+	// PageToken continues a query whose previous response was
+	// truncated; the client sets it when following pagination.
+	PageToken string `json:"page_token,omitempty"`
+}
+
+// BatchedQuery represents a batched query to OSV.
+type BatchedQuery struct {
+	Queries []*Query `json:"queries"`
+}
+
+// MinimalVulnerability represents an unhydrated vulnerability entry from OSV.
+type MinimalVulnerability struct {
+	ID string `json:"id"`
+}
+
+// Severity represents a severity entry of an OSV record.
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Event represents an event in an OSV affected range.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Range represents an affected range of an OSV record.
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Affected represents an affected entry of an OSV record.
+type Affected struct {
+	Package           Package                `json:"package,omitempty"`
+	Ranges            []Range                `json:"ranges,omitempty"`
+	Versions          []string               `json:"versions,omitempty"`
+	EcosystemSpecific map[string]interface{} `json:"ecosystem_specific,omitempty"`
+	DatabaseSpecific  map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Reference represents a reference of an OSV record.
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Vulnerability represents a hydrated OSV record.
+type Vulnerability struct {
+	SchemaVersion    string                 `json:"schema_version,omitempty"`
+	ID               string                 `json:"id"`
+	Modified         time.Time              `json:"modified"`
+	Published        time.Time              `json:"published,omitempty"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Related          []string               `json:"related,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Severity         []Severity             `json:"severity,omitempty"`
+	Affected         []Affected             `json:"affected,omitempty"`
+	References       []Reference            `json:"references,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Response represents a full response from the query endpoint. A
+// non-empty NextPageToken means the response was truncated; the client
+// functions follow it, so callers never see a partial result.
+type Response struct {
+	Vulns         []Vulnerability `json:"vulns"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+// MinimalResponse represents an unhydrated entry of a batched response.
+type MinimalResponse struct {
+	Vulns         []MinimalVulnerability `json:"vulns"`
+	NextPageToken string                 `json:"next_page_token,omitempty"`
+}
+
+// BatchedResponse represents a response from the batched query endpoint.
+type BatchedResponse struct {
+	Results []MinimalResponse `json:"results"`
+}
+
+// HydratedBatchedResponse represents a hydrated batched response, with the
+// i-th result corresponding to the i-th query of the request.
+type HydratedBatchedResponse struct {
+	Results []Response `json:"results"`
+}
+
+// MakeRequest sends a batched query to the OSV API, chunking it into
+// requests of at most maxQueriesPerRequest queries each. Cancelling ctx
+// aborts any in-flight request.
+//
+// The batch endpoint rejects a whole chunk when any query in it is
+// invalid, so on a 400 the chunk is retried one query at a time to
+// isolate the offenders. Rejected queries get an empty result — keeping
+// the i-th result aligned with the i-th query — and are reported
+// together as a BatchError alongside the partial response, so callers
+// can attribute them and continue with the valid remainder.
+func MakeRequest(ctx context.Context, request BatchedQuery) (*BatchedResponse, error) {
+	var osvResp BatchedResponse
+	var invalid []*InvalidQueryError
+	for i := 0; i < len(request.Queries); i += maxQueriesPerRequest {
+		end := i + maxQueriesPerRequest
+		if end > len(request.Queries) {
+			end = len(request.Queries)
+		}
+		chunk := request.Queries[i:end]
+		chunkResp, err := postBatch(ctx, chunk)
+		if err == nil {
+			osvResp.Results = append(osvResp.Results, chunkResp.Results...)
+			continue
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(chunk) == 1 {
+			if apiErr != nil && apiErr.StatusCode == http.StatusBadRequest {
+				invalid = append(invalid, &InvalidQueryError{Index: i, Cause: apiErr})
+				osvResp.Results = append(osvResp.Results, MinimalResponse{})
+
+				continue
+			}
+
+			return nil, err
+		}
+		// Some query in this chunk is invalid; isolate it so the rest of
+		// the chunk still gets answered.
+		for j, query := range chunk {
+			singleResp, err := postBatch(ctx, []*Query{query})
+			if err != nil {
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+					invalid = append(invalid, &InvalidQueryError{Index: i + j, Cause: apiErr})
+					osvResp.Results = append(osvResp.Results, MinimalResponse{})
+
+					continue
+				}
+
+				return nil, err
+			}
+			osvResp.Results = append(osvResp.Results, singleResp.Results...)
+		}
+	}
+	if err := followPagination(ctx, request.Queries, &osvResp); err != nil {
+		return nil, err
+	}
+	if len(invalid) > 0 {
+		return &osvResp, &BatchError{Invalid: invalid}
+	}
+
+	return &osvResp, nil
+}
+
+// followPagination re-submits the queries whose results were truncated,
+// with their page tokens set, until every result is complete. Results
+// are merged in place so the i-th result stays matched to the i-th
+// query.
+func followPagination(ctx context.Context, queries []*Query, resp *BatchedResponse) error {
+	for {
+		var continued []*Query
+		var indices []int
+		for i, result := range resp.Results {
+			if result.NextPageToken == "" {
+				continue
+			}
+			// Copy the query so the caller's batch is not mutated.
+			query := *queries[i]
+			query.PageToken = result.NextPageToken
+			continued = append(continued, &query)
+			indices = append(indices, i)
+		}
+		if len(continued) == 0 {
+			return nil
+		}
+
+		for start := 0; start < len(continued); start += maxQueriesPerRequest {
+			end := start + maxQueriesPerRequest
+			if end > len(continued) {
+				end = len(continued)
+			}
+			pageResp, err := postBatch(ctx, continued[start:end])
+			if err != nil {
+				return err
+			}
+			for j, page := range pageResp.Results {
+				idx := indices[start+j]
+				resp.Results[idx].Vulns = append(resp.Results[idx].Vulns, page.Vulns...)
+				resp.Results[idx].NextPageToken = page.NextPageToken
+			}
+		}
+	}
+}
+
+// postBatch sends one chunk to the batch endpoint.
+func postBatch(ctx context.Context, queries []*Query) (*BatchedResponse, error) {
+	requestBytes, err := json.Marshal(BatchedQuery{Queries: queries})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, BatchQueryEndpoint, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("batch query", resp)
+	}
+	var chunkResp BatchedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chunkResp); err != nil {
+		return nil, err
+	}
+
+	return &chunkResp, nil
+}
+
+// QueryAffected sends a single query to the OSV API and returns the
+// hydrated vulnerabilities affecting it, following pagination so the
+// result is always complete.
+func QueryAffected(ctx context.Context, query *Query) (*Response, error) {
+	var full Response
+	// Copy the query so following pagination does not mutate the
+	// caller's.
+	next := *query
+	for {
+		page, err := postQuery(ctx, &next)
+		if err != nil {
+			return nil, err
+		}
+		full.Vulns = append(full.Vulns, page.Vulns...)
+		if page.NextPageToken == "" {
+			return &full, nil
+		}
+		next.PageToken = page.NextPageToken
+	}
+}
+
+// postQuery sends one request to the query endpoint.
+func postQuery(ctx context.Context, query *Query) (*Response, error) {
+	requestBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, QueryEndpoint, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("query", resp)
+	}
+	var queryResp Response
+	if err := json.NewDecoder(resp.Body).Decode(&queryResp); err != nil {
+		return nil, err
+	}
+
+	return &queryResp, nil
+}
+
+// Get returns the hydrated vulnerability for the given OSV ID.
+func Get(ctx context.Context, id string) (*Vulnerability, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GetEndpoint+"/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("get "+id, resp)
+	}
+	var vuln Vulnerability
+	if err := json.NewDecoder(resp.Body).Decode(&vuln); err != nil {
+		return nil, err
+	}
+
+	return &vuln, nil
+}
+
+// HydrationWorkers is how many record fetches Hydrate issues
+// concurrently. The default is a mild parallelism the API comfortably
+// serves; raise it for result sets with thousands of findings — in
+// combination with SetConnectionLimits and SetRateLimit to stay inside
+// quota — or set it to 1 for strictly serial fetching.
+var HydrationWorkers = 4
+
+// Hydrate fills in the full records for all entries of an unhydrated
+// batched response, fetching up to HydrationWorkers records at a time.
+func Hydrate(ctx context.Context, resp *BatchedResponse) (*HydratedBatchedResponse, error) {
+	hydrated := HydratedBatchedResponse{
+		Results: make([]Response, len(resp.Results)),
+	}
+	// Each fetch lands at its own (result, entry) slot, so concurrent
+	// workers preserve the response's ordering.
+	type task struct {
+		result, entry int
+		id            string
+	}
+	var tasks []task
+	for i, result := range resp.Results {
+		if len(result.Vulns) == 0 {
+			continue
+		}
+		hydrated.Results[i].Vulns = make([]Vulnerability, len(result.Vulns))
+		for j, minimal := range result.Vulns {
+			tasks = append(tasks, task{result: i, entry: j, id: minimal.ID})
+		}
+	}
+	if len(tasks) == 0 {
+		return &hydrated, nil
+	}
+	workers := HydrationWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	queue := make(chan task)
+	firstErr := make(chan error, workers)
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				vuln, err := Get(ctx, t.id)
+				if err != nil {
+					firstErr <- err
+					cancel()
+
+					return
+				}
+				hydrated.Results[t.result].Vulns[t.entry] = *vuln
+			}
+		}()
+	}
+	for _, t := range tasks {
+		select {
+		case queue <- t:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+	select {
+	case err := <-firstErr:
+		return nil, err
+	default:
+	}
+
+	return &hydrated, nil
+}
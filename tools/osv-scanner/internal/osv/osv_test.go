@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/osvtest"
+)
+
+func TestQueryAffectedFollowsPagination(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query osv.Query
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, query.PageToken)
+		resp := osv.Response{Vulns: []osv.Vulnerability{{ID: "OSV-1"}}, NextPageToken: "page2"}
+		if query.PageToken == "page2" {
+			resp = osv.Response{Vulns: []osv.Vulnerability{{ID: "OSV-2"}}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	prev := osv.QueryEndpoint
+	osv.QueryEndpoint = server.URL
+	defer func() { osv.QueryEndpoint = prev }()
+
+	query := osv.Query{Package: osv.Package{Name: "lodash", Ecosystem: "npm"}, Version: "1.0.0"}
+	resp, err := osv.QueryAffected(context.Background(), &query)
+	if err != nil {
+		t.Fatalf("QueryAffected: %v", err)
+	}
+	if len(resp.Vulns) != 2 || resp.Vulns[0].ID != "OSV-1" || resp.Vulns[1].ID != "OSV-2" {
+		t.Errorf("QueryAffected returned %+v, want OSV-1 then OSV-2", resp.Vulns)
+	}
+	if len(requests) != 2 || requests[1] != "page2" {
+		t.Errorf("server saw page tokens %q, want a second request with page2", requests)
+	}
+	if query.PageToken != "" {
+		t.Errorf("QueryAffected mutated the caller's query: PageToken = %q", query.PageToken)
+	}
+}
+
+func TestMakeRequestChunksLargeBatches(t *testing.T) {
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch osv.BatchedQuery
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		batchSizes = append(batchSizes, len(batch.Queries))
+		resp := osv.BatchedResponse{Results: make([]osv.MinimalResponse, len(batch.Queries))}
+		for i, query := range batch.Queries {
+			resp.Results[i].Vulns = []osv.MinimalVulnerability{{ID: "OSV-" + query.Package.Name}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	prev := osv.BatchQueryEndpoint
+	osv.BatchQueryEndpoint = server.URL
+	defer func() { osv.BatchQueryEndpoint = prev }()
+
+	// One query over the per-request limit forces a second chunk.
+	var request osv.BatchedQuery
+	for i := 0; i < 1001; i++ {
+		request.Queries = append(request.Queries, &osv.Query{
+			Package: osv.Package{Name: fmt.Sprintf("pkg%d", i), Ecosystem: "npm"},
+			Version: "1.0.0",
+		})
+	}
+	resp, err := osv.MakeRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("MakeRequest: %v", err)
+	}
+	if len(batchSizes) != 2 || batchSizes[0] != 1000 || batchSizes[1] != 1 {
+		t.Errorf("chunk sizes = %v, want [1000 1]", batchSizes)
+	}
+	if len(resp.Results) != 1001 {
+		t.Fatalf("got %d results, want 1001", len(resp.Results))
+	}
+	// Results must stay aligned with queries across the chunk boundary.
+	for _, i := range []int{0, 999, 1000} {
+		want := "OSV-" + request.Queries[i].Package.Name
+		if got := resp.Results[i].Vulns[0].ID; got != want {
+			t.Errorf("result %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestMakeRequestIsolatesInvalidQueries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch osv.BatchedQuery
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, query := range batch.Queries {
+			if query.Package.Name == "bad" {
+				http.Error(w, "invalid query", http.StatusBadRequest)
+				return
+			}
+		}
+		resp := osv.BatchedResponse{Results: make([]osv.MinimalResponse, len(batch.Queries))}
+		for i, query := range batch.Queries {
+			resp.Results[i].Vulns = []osv.MinimalVulnerability{{ID: "OSV-" + query.Package.Name}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	prev := osv.BatchQueryEndpoint
+	osv.BatchQueryEndpoint = server.URL
+	defer func() { osv.BatchQueryEndpoint = prev }()
+
+	request := osv.BatchedQuery{Queries: []*osv.Query{
+		{Package: osv.Package{Name: "good1", Ecosystem: "npm"}, Version: "1.0.0"},
+		{Package: osv.Package{Name: "bad", Ecosystem: "npm"}, Version: "1.0.0"},
+		{Package: osv.Package{Name: "good2", Ecosystem: "npm"}, Version: "1.0.0"},
+	}}
+	resp, err := osv.MakeRequest(context.Background(), request)
+	var batchErr *osv.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("MakeRequest error = %v, want a BatchError", err)
+	}
+	if len(batchErr.Invalid) != 1 || batchErr.Invalid[0].Index != 1 {
+		t.Errorf("BatchError.Invalid = %+v, want exactly query 1", batchErr.Invalid)
+	}
+	if !errors.Is(batchErr.Invalid[0], osv.ErrInvalidQuery) {
+		t.Errorf("invalid query error does not match ErrInvalidQuery")
+	}
+	// The valid remainder is still answered, with the rejected query's
+	// slot left empty so results stay aligned.
+	if len(resp.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.Results))
+	}
+	if got := resp.Results[0].Vulns[0].ID; got != "OSV-good1" {
+		t.Errorf("result 0 = %q, want OSV-good1", got)
+	}
+	if len(resp.Results[1].Vulns) != 0 {
+		t.Errorf("rejected query's result = %+v, want empty", resp.Results[1])
+	}
+	if got := resp.Results[2].Vulns[0].ID; got != "OSV-good2" {
+		t.Errorf("result 2 = %q, want OSV-good2", got)
+	}
+}
+
+func TestMakeRequestAndHydrate(t *testing.T) {
+	defer osvtest.NewServer(
+		osv.Vulnerability{
+			ID:      "GHSA-aaaa-aaaa-aaaa",
+			Summary: "prototype pollution in lodash",
+			Affected: []osv.Affected{{
+				Package:  osv.Package{Ecosystem: "npm", Name: "lodash"},
+				Versions: []string{"4.17.20"},
+			}},
+		},
+		osv.Vulnerability{
+			ID:      "CVE-2023-0001",
+			Summary: "also lodash",
+			Affected: []osv.Affected{{
+				Package:  osv.Package{Ecosystem: "npm", Name: "lodash"},
+				Versions: []string{"4.17.20"},
+			}},
+		},
+	).Install()()
+
+	request := osv.BatchedQuery{Queries: []*osv.Query{
+		{Package: osv.Package{Name: "lodash", Ecosystem: "npm"}, Version: "4.17.20"},
+		{Package: osv.Package{Name: "left-pad", Ecosystem: "npm"}, Version: "1.0.0"},
+	}}
+	resp, err := osv.MakeRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("MakeRequest: %v", err)
+	}
+	hydrated, err := osv.Hydrate(context.Background(), resp)
+	if err != nil {
+		t.Fatalf("Hydrate: %v", err)
+	}
+	if len(hydrated.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(hydrated.Results))
+	}
+	vulns := hydrated.Results[0].Vulns
+	if len(vulns) != 2 || vulns[0].ID != "CVE-2023-0001" || vulns[1].ID != "GHSA-aaaa-aaaa-aaaa" {
+		t.Fatalf("hydrated vulns = %+v, want CVE-2023-0001 and GHSA-aaaa-aaaa-aaaa", vulns)
+	}
+	// Hydration fills in the full record, not just the ID.
+	if vulns[1].Summary != "prototype pollution in lodash" {
+		t.Errorf("hydrated summary = %q, want the record's", vulns[1].Summary)
+	}
+	if len(hydrated.Results[1].Vulns) != 0 {
+		t.Errorf("unaffected package has vulns: %+v", hydrated.Results[1].Vulns)
+	}
+}
+
+func TestGet(t *testing.T) {
+	defer osvtest.NewServer(
+		osv.Vulnerability{ID: "OSV-2023-1", Summary: "a record"},
+	).Install()()
+
+	vuln, err := osv.Get(context.Background(), "OSV-2023-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if vuln.Summary != "a record" {
+		t.Errorf("Get returned %+v, want the fixture record", vuln)
+	}
+	if _, err := osv.Get(context.Background(), "OSV-0000-0"); err == nil {
+		t.Errorf("Get on an unknown ID succeeded, want error")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// MakeRequestStream sends a batched query like MakeRequest, but decodes
+// the response incrementally and calls handle once per result, in query
+// order, instead of buffering the full response in memory. This keeps
+// memory use flat on scans returning tens of thousands of findings.
+// Returning an error from handle aborts the remaining requests.
+func MakeRequestStream(ctx context.Context, request BatchedQuery, handle func(MinimalResponse) error) error {
+	for i := 0; i < len(request.Queries); i += maxQueriesPerRequest {
+		end := i + maxQueriesPerRequest
+		if end > len(request.Queries) {
+			end = len(request.Queries)
+		}
+		chunk := BatchedQuery{Queries: request.Queries[i:end]}
+		if err := streamChunk(ctx, chunk, handle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamChunk posts one chunk and walks the results array of the response
+// token by token, decoding one result at a time.
+func streamChunk(ctx context.Context, chunk BatchedQuery, handle func(MinimalResponse) error) error {
+	requestBytes, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, BatchQueryEndpoint, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("batch query failed: %s", resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token == "results" {
+			break
+		}
+	}
+	// Consume the opening bracket of the results array.
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		var result MinimalResponse
+		if err := decoder.Decode(&result); err != nil {
+			return err
+		}
+		if err := handle(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
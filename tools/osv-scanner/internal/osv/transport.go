@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestModifier mutates an outgoing API request before it is sent, for
+// example to add authentication headers or trace IDs required by a
+// corporate gateway.
+type RequestModifier func(*http.Request)
+
+// httpClient issues all API requests; UseClientCertificate replaces it
+// with one presenting an mTLS client certificate.
+var httpClient = http.DefaultClient
+
+var requestModifiers []RequestModifier
+
+// AddRequestModifier registers a modifier applied, in registration order,
+// to every request this package sends.
+func AddRequestModifier(m RequestModifier) {
+	requestModifiers = append(requestModifiers, m)
+}
+
+// AddHeader registers a modifier setting the given header on every
+// request.
+func AddHeader(name, value string) {
+	AddRequestModifier(func(req *http.Request) {
+		req.Header.Set(name, value)
+	})
+}
+
+// UseClientCertificate makes all requests present the client certificate
+// in the given PEM-encoded certificate and key files, as required by
+// gateways enforcing mTLS.
+func UseClientCertificate(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	pooledTransport().TLSClientConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	return nil
+}
+
+// SetConnectionLimits tunes the connection pool this package's requests
+// go out on: maxConnsPerHost caps concurrent connections to each host,
+// maxIdleConns sizes the pool kept for reuse across requests. A zero
+// leaves the corresponding setting unchanged. Call before EnableCache,
+// so the cache wraps the tuned transport.
+func SetConnectionLimits(maxConnsPerHost, maxIdleConns int) {
+	t := pooledTransport()
+	if maxConnsPerHost > 0 {
+		t.MaxConnsPerHost = maxConnsPerHost
+	}
+	if maxIdleConns > 0 {
+		t.MaxIdleConns = maxIdleConns
+		// The per-host idle default (2) would discard most of a larger
+		// pool: every request here goes to the same API host.
+		t.MaxIdleConnsPerHost = maxIdleConns
+	}
+}
+
+// pooledTransport returns the http.Transport requests are sent on,
+// cloning the default transport into a private one on first use so its
+// settings can be changed without affecting other packages' clients.
+func pooledTransport() *http.Transport {
+	if httpClient != http.DefaultClient {
+		if t, ok := httpClient.Transport.(*http.Transport); ok {
+			return t
+		}
+	}
+	t, ok := http.DefaultTransport.(*http.Transport)
+	if ok {
+		t = t.Clone()
+	} else {
+		t = &http.Transport{}
+	}
+	httpClient = &http.Client{Transport: t}
+
+	return t
+}
+
+// rateInterval is the enforced minimum time between requests to the
+// same host; zero disables rate limiting.
+var (
+	rateInterval time.Duration
+	rateMu       sync.Mutex
+	rateNext     map[string]time.Time
+)
+
+// SetRateLimit caps how many requests per second this package sends to
+// any one host, so large scans stay under API quotas instead of
+// tripping 429s. Zero or negative disables the limit.
+func SetRateLimit(perSecond float64) {
+	if perSecond <= 0 {
+		rateInterval = 0
+		return
+	}
+	rateInterval = time.Duration(float64(time.Second) / perSecond)
+	rateNext = make(map[string]time.Time)
+}
+
+// waitTurn blocks until the request's host is due another request under
+// the configured rate limit, or its context is cancelled.
+func waitTurn(req *http.Request) error {
+	if rateInterval == 0 {
+		return nil
+	}
+	rateMu.Lock()
+	at := rateNext[req.URL.Host]
+	if now := time.Now(); at.Before(now) {
+		at = now
+	}
+	rateNext[req.URL.Host] = at.Add(rateInterval)
+	rateMu.Unlock()
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// do applies the registered modifiers, waits out the rate limit and
+// sends the request.
+func do(req *http.Request) (*http.Response, error) {
+	for _, m := range requestModifiers {
+		m(req)
+	}
+	if err := waitTurn(req); err != nil {
+		return nil, err
+	}
+
+	return httpClient.Do(req)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osvtest runs an in-process fake of the OSV API (/v1/query,
+// /v1/querybatch and /v1/vulns) backed by fixture advisories, so scanner
+// features and downstream consumers can be exercised hermetically.
+// Matching uses the same range evaluation as the local matcher.
+package osvtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/localmatcher"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// Server is a fake OSV API serving a fixed set of advisories.
+type Server struct {
+	*httptest.Server
+
+	db    *localmatcher.DB
+	vulns map[string]osv.Vulnerability
+}
+
+// NewServer starts a fake API serving the given advisories. Callers own
+// the server and must Close it; Install additionally redirects the osv
+// client at it.
+func NewServer(vulns ...osv.Vulnerability) *Server {
+	s := &Server{
+		db:    localmatcher.New(),
+		vulns: map[string]osv.Vulnerability{},
+	}
+	for i := range vulns {
+		s.db.Add(&vulns[i])
+		s.vulns[vulns[i].ID] = vulns[i]
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/query", s.handleQuery)
+	mux.HandleFunc("/v1/querybatch", s.handleQueryBatch)
+	mux.HandleFunc("/v1/vulns/", s.handleGet)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Install points the osv client's endpoints at this server and returns a
+// function restoring the previous endpoints. Typical use:
+//
+//	defer osvtest.NewServer(fixtures...).Install()()
+func (s *Server) Install() func() {
+	prevQuery := osv.QueryEndpoint
+	prevBatch := osv.BatchQueryEndpoint
+	prevGet := osv.GetEndpoint
+	osv.QueryEndpoint = s.URL + "/v1/query"
+	osv.BatchQueryEndpoint = s.URL + "/v1/querybatch"
+	osv.GetEndpoint = s.URL + "/v1/vulns"
+
+	return func() {
+		osv.QueryEndpoint = prevQuery
+		osv.BatchQueryEndpoint = prevBatch
+		osv.GetEndpoint = prevGet
+		s.Close()
+	}
+}
+
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var query osv.Query
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, osv.Response{Vulns: s.db.Query(&query)})
+}
+
+func (s *Server) handleQueryBatch(w http.ResponseWriter, r *http.Request) {
+	var batch osv.BatchedQuery
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := osv.BatchedResponse{Results: make([]osv.MinimalResponse, len(batch.Queries))}
+	for i, query := range batch.Queries {
+		for _, vuln := range s.db.Query(query) {
+			resp.Results[i].Vulns = append(resp.Results[i].Vulns, osv.MinimalVulnerability{ID: vuln.ID})
+		}
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/v1/vulns/")
+	vuln, ok := s.vulns[id]
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, vuln)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
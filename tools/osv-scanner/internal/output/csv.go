@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// PrintCSVResults writes one flat row per package/vulnerability pair,
+// for triage in spreadsheets and loading into data warehouses.
+func PrintCSVResults(results *models.VulnerabilityResults, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"source", "ecosystem", "package", "version", "id", "severity", "fixed_version", "ticket", "owner", "due"}); err != nil {
+		return err
+	}
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			for _, vuln := range pkg.Vulnerabilities {
+				score, level := severity.MaxOf([]osv.Vulnerability{vuln})
+				rating := level.String()
+				if score > 0 {
+					rating = fmt.Sprintf("%s (%.1f)", level, score)
+				}
+				ticket, owner, due := "", "", ""
+				if ann := annotationFor(pkg.Groups, vuln.ID); ann != nil {
+					ticket, owner = ann.Ticket, ann.Owner
+					if !ann.Due.IsZero() {
+						due = ann.Due.UTC().Format("2006-01-02")
+					}
+				}
+				row := []string{
+					source.Source.Path,
+					pkg.Package.Ecosystem,
+					pkg.Package.Name,
+					pkg.Package.Version,
+					vuln.ID,
+					rating,
+					fixedVersions(vuln, pkg.Package),
+					ticket,
+					owner,
+					due,
+				}
+				if err := cw.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// annotationFor finds the tracking metadata of the group a record
+// belongs to.
+func annotationFor(groups []models.GroupInfo, id string) *models.Annotation {
+	for _, group := range groups {
+		for _, groupID := range group.IDs {
+			if groupID == id {
+				return group.Annotation
+			}
+		}
+	}
+
+	return nil
+}
+
+// fixedVersions collects the fixed versions a record lists for the
+// given package, semicolon-separated when several ranges fix it at
+// different points.
+func fixedVersions(vuln osv.Vulnerability, pkg models.PackageInfo) string {
+	var fixed []string
+	seen := make(map[string]bool)
+	for _, affected := range vuln.Affected {
+		if affected.Package.Name != "" && pkg.Name != "" && affected.Package.Name != pkg.Name {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed != "" && !seen[event.Fixed] {
+					seen[event.Fixed] = true
+					fixed = append(fixed, event.Fixed)
+				}
+			}
+		}
+	}
+
+	return strings.Join(fixed, ";")
+}
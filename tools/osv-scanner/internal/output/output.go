@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output renders scan results for human and machine consumption.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// PrintJSONResults writes results as indented JSON.
+func PrintJSONResults(results *models.VulnerabilityResults, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(results)
+}
+
+// PrintSummaryResults writes only the aggregate finding counts: one row
+// per scanned source and an overall total, for dashboards that track
+// numbers rather than individual findings.
+func PrintSummaryResults(results *models.VulnerabilityResults, w io.Writer) error {
+	if results.Counts == nil {
+		results.ComputeCounts()
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOURCE\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN\tTOTAL")
+	for _, source := range results.Results {
+		c := source.Counts
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			source.Source.Path, c.Critical, c.High, c.Medium, c.Low, c.Unknown, c.Total)
+	}
+	c := results.Counts
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%d\t%d\t%d\t%d\n",
+		c.Critical, c.High, c.Medium, c.Low, c.Unknown, c.Total)
+
+	return tw.Flush()
+}
+
+// PrintTableResults writes results as a human readable table. Timestamps
+// are rendered in loc; machine formats always use RFC3339 UTC instead.
+func PrintTableResults(results *models.VulnerabilityResults, w io.Writer, loc *time.Location) error {
+	if loc == nil {
+		loc = time.Local
+	}
+	if !results.Date.IsZero() {
+		fmt.Fprintf(w, "Scanned at %s\n", results.Date.In(loc).Format("2006-01-02 15:04:05 MST"))
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOURCE\tPACKAGE\tVERSION\tSEVERITY\tVULNERABILITIES")
+	for _, source := range results.Results {
+		// Most severe packages first within each source.
+		pkgs := make([]models.PackageVulns, len(source.Packages))
+		copy(pkgs, source.Packages)
+		sort.SliceStable(pkgs, func(i, j int) bool {
+			si, li := severity.MaxOf(pkgs[i].Vulnerabilities)
+			sj, lj := severity.MaxOf(pkgs[j].Vulnerabilities)
+			if li != lj {
+				return li > lj
+			}
+
+			return si > sj
+		})
+		for _, pkg := range pkgs {
+			// One row per group of aliased records, canonical ID first.
+			ids := ""
+			for i, group := range pkg.Groups {
+				if i > 0 {
+					ids += "; "
+				}
+				ids += group.IDs[0]
+				if len(group.IDs) > 1 {
+					ids += " (" + strings.Join(group.IDs[1:], ", ") + ")"
+				}
+				if ann := group.Annotation; ann != nil {
+					ids += " [" + annotationText(ann, loc) + "]"
+				}
+			}
+			score, level := severity.MaxOf(pkg.Vulnerabilities)
+			rating := level.String()
+			if score > 0 {
+				rating = fmt.Sprintf("%s (%.1f)", level, score)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", source.Source.Path, pkg.Package.Name, pkg.Package.Version, rating, ids)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, skipped := range results.Skipped {
+		fmt.Fprintf(w, "Skipped %s (%s): %s\n", skipped.Path, skipped.Type, skipped.Reason)
+	}
+	for _, warning := range results.Warnings {
+		fmt.Fprintf(w, "Warning: %s: %s\n", warning.Path, warning.Detail)
+	}
+
+	return nil
+}
+
+// annotationText renders a finding's tracking metadata for the table.
+func annotationText(ann *models.Annotation, loc *time.Location) string {
+	var parts []string
+	if ann.Ticket != "" {
+		parts = append(parts, ann.Ticket)
+	}
+	if ann.Owner != "" {
+		parts = append(parts, "owner: "+ann.Owner)
+	}
+	if !ann.Due.IsZero() {
+		parts = append(parts, "due "+ann.Due.In(loc).Format("2006-01-02"))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/tui"
+)
+
+// Reporter renders results to w in one format.
+type Reporter func(results *models.VulnerabilityResults, w io.Writer, loc *time.Location) error
+
+// formats maps format names to their reporter.
+var formats = map[string]Reporter{
+	"table": PrintTableResults,
+	"json": func(results *models.VulnerabilityResults, w io.Writer, _ *time.Location) error {
+		return PrintJSONResults(results, w)
+	},
+	"sarif": func(results *models.VulnerabilityResults, w io.Writer, _ *time.Location) error {
+		return PrintSARIFResults(results, w)
+	},
+	"summary": func(results *models.VulnerabilityResults, w io.Writer, _ *time.Location) error {
+		return PrintSummaryResults(results, w)
+	},
+	"csv": func(results *models.VulnerabilityResults, w io.Writer, _ *time.Location) error {
+		return PrintCSVResults(results, w)
+	},
+	"template": PrintTemplateResults,
+	// tui takes over the terminal rather than writing to w, so it only
+	// works on stdout.
+	"tui": func(results *models.VulnerabilityResults, w io.Writer, _ *time.Location) error {
+		if w != io.Writer(os.Stdout) {
+			return fmt.Errorf("the tui format cannot write to a file")
+		}
+
+		return tui.Run(results)
+	},
+}
+
+// Destination is one parsed --output value: a path (or "-" for stdout) and
+// the format to write there.
+type Destination struct {
+	Path   string
+	Format string
+}
+
+// ParseDestination parses an --output value of the form "path" or
+// "path:format". When no explicit format is given it is inferred from the
+// file extension, defaulting to "table" for stdout.
+func ParseDestination(s string) (Destination, error) {
+	dest := Destination{Path: s}
+	if path, format, ok := strings.Cut(s, ":"); ok {
+		dest = Destination{Path: path, Format: format}
+	}
+	if dest.Format == "" {
+		switch filepath.Ext(dest.Path) {
+		case ".json":
+			dest.Format = "json"
+		case ".sarif":
+			dest.Format = "sarif"
+		case ".csv":
+			dest.Format = "csv"
+		default:
+			dest.Format = "table"
+		}
+	}
+	if _, ok := formats[dest.Format]; !ok {
+		return Destination{}, fmt.Errorf("unsupported output format: %q", dest.Format)
+	}
+
+	return dest, nil
+}
+
+// Write renders results to every destination from a single scan, so users
+// no longer run the scan twice to get two formats.
+func Write(dests []Destination, results *models.VulnerabilityResults, loc *time.Location) error {
+	for _, dest := range dests {
+		w := io.Writer(os.Stdout)
+		if dest.Path != "-" {
+			f, err := os.Create(dest.Path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := formats[dest.Format](results, w, loc); err != nil {
+			return fmt.Errorf("failed to write %s output to %s: %w", dest.Format, dest.Path, err)
+		}
+	}
+
+	return nil
+}
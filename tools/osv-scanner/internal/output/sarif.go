@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// SARIF 2.1.0 subset, sufficient for code scanning upload.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	HelpURI          string            `json:"helpUri,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// PrintSARIFResults writes results in SARIF 2.1.0 format, one rule per
+// vulnerability group and one result per affected package.
+func PrintSARIFResults(results *models.VulnerabilityResults, w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "osv-scanner",
+			InformationURI: "https://github.com/google/osv",
+		}},
+	}
+	seenRules := map[string]bool{}
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			_, level := severity.MaxOf(pkg.Vulnerabilities)
+			for _, group := range pkg.Groups {
+				canonical := group.IDs[0]
+				if !seenRules[canonical] {
+					seenRules[canonical] = true
+					run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+						ID:               canonical,
+						ShortDescription: sarifMessage{Text: canonical},
+						HelpURI:          "https://osv.dev/vulnerability/" + canonical,
+					})
+				}
+				run.Results = append(run.Results, sarifResult{
+					RuleID: canonical,
+					Level:  sarifLevel(level),
+					Message: sarifMessage{Text: fmt.Sprintf(
+						"Package %s@%s is vulnerable to %s", pkg.Package.Name, pkg.Package.Version, canonical)},
+					Locations: []sarifLocation{{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{URI: source.Source.Path},
+						},
+					}},
+				})
+			}
+		}
+	}
+
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(log)
+}
+
+func sarifLevel(level severity.Level) string {
+	switch level {
+	case severity.LevelCritical, severity.LevelHigh:
+		return "error"
+	case severity.LevelMedium:
+		return "warning"
+	case severity.LevelLow:
+		return "note"
+	default:
+		return "warning"
+	}
+}
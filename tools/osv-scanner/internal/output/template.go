@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// TemplatePath is the Go template file the "template" format renders,
+// set from the --template flag. The template executes over the
+// structured report (models.VulnerabilityResults), so custom report
+// shapes need no JSON post-processing.
+var TemplatePath string
+
+// PrintTemplateResults renders results through the user's template.
+// Timestamps formatted with the "local" helper use loc.
+func PrintTemplateResults(results *models.VulnerabilityResults, w io.Writer, loc *time.Location) error {
+	if TemplatePath == "" {
+		return errors.New("the template format requires --template")
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	tmpl, err := template.New(filepath.Base(TemplatePath)).Funcs(templateFuncs(loc)).ParseFiles(TemplatePath)
+	if err != nil {
+		return fmt.Errorf("invalid template %s: %w", TemplatePath, err)
+	}
+
+	return tmpl.Execute(w, results)
+}
+
+// templateFuncs are the helpers available inside report templates, on
+// top of the standard template actions.
+func templateFuncs(loc *time.Location) template.FuncMap {
+	return template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		// severity renders the worst rating of a set of records, the way
+		// the table format does.
+		"severity": func(vulns []osv.Vulnerability) string {
+			score, level := severity.MaxOf(vulns)
+			if score > 0 {
+				return fmt.Sprintf("%s (%.1f)", level, score)
+			}
+
+			return level.String()
+		},
+		// local formats a timestamp in the timezone selected by
+		// --timezone.
+		"local": func(t time.Time) string {
+			return t.In(loc).Format("2006-01-02 15:04:05 MST")
+		},
+	}
+}
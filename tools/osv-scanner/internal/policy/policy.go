@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy evaluates user-supplied gating rules against scan
+// results. Rules are CEL expressions over the structured report, so
+// teams can encode policies like "no HIGH finding may stay unfixed for
+// more than 30 days" instead of being limited to the fixed --fail-on
+// thresholds.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/cel-go/cel"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// Rule is one [[rule]] entry of a policy file. Expr is a CEL expression
+// over the variables `report` (the structured scan report, as in the
+// JSON output) and `now` (the evaluation time); it must evaluate to a
+// boolean, where true means the rule is violated.
+type Rule struct {
+	Name string `toml:"name"`
+	Expr string `toml:"expr"`
+	// Message is shown when the rule is violated; Name is used when
+	// empty.
+	Message string `toml:"message"`
+}
+
+type policyFile struct {
+	Rules []Rule `toml:"rule"`
+}
+
+// Violation is one failed rule.
+type Violation struct {
+	Name    string
+	Message string
+}
+
+// Load reads the rules of the TOML policy file at path.
+func Load(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file policyFile
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("policy file %s contains no rules", path)
+	}
+	for i, rule := range file.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d of %s has no name", i+1, path)
+		}
+		if rule.Expr == "" {
+			return nil, fmt.Errorf("rule %q has no expr", rule.Name)
+		}
+	}
+
+	return file.Rules, nil
+}
+
+// Evaluate runs every rule against the results and returns the
+// violations. Expressions see the report exactly as the JSON output
+// serializes it, so policies can be developed against saved reports.
+func Evaluate(rules []Rule, results *models.VulnerabilityResults) ([]Violation, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("report", cel.DynType),
+		cel.Variable("now", cel.TimestampType),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip through JSON so expressions address fields by their
+	// serialized names.
+	data, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	input := map[string]interface{}{
+		"report": report,
+		"now":    time.Now(),
+	}
+
+	var violations []Violation
+	for _, rule := range rules {
+		ast, issues := env.Compile(rule.Expr)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("rule %q does not compile: %w", rule.Name, issues.Err())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		out, _, err := program.Eval(input)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q failed to evaluate: %w", rule.Name, err)
+		}
+		violated, ok := out.Value().(bool)
+		if !ok {
+			return nil, fmt.Errorf("rule %q does not evaluate to a boolean", rule.Name)
+		}
+		if !violated {
+			continue
+		}
+		message := rule.Message
+		if message == "" {
+			message = rule.Name
+		}
+		violations = append(violations, Violation{Name: rule.Name, Message: message})
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provenance reads SLSA provenance attestations and turns the
+// dependencies they enumerate into scan sources. A trusted provenance
+// document already lists every resolved input of a build, so querying
+// it directly avoids re-extracting the sources it was built from.
+package provenance
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// envelope is a DSSE envelope, the usual wrapper around a signed
+// statement. Signatures are not verified here; pairing the scan with
+// signature verification is the job of the attestation tooling that
+// produced the document.
+type envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
+
+// statement is the in-toto statement layer of an attestation.
+type statement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// predicate holds the dependency lists of both SLSA provenance
+// generations: resolvedDependencies (v1) and materials (v0.x).
+type predicate struct {
+	BuildDefinition struct {
+		ResolvedDependencies []dependency `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	Materials []dependency `json:"materials"`
+}
+
+// dependency is one resolved build input.
+type dependency struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Parse reads the SLSA provenance attestation at path — a DSSE envelope
+// or a bare in-toto statement — and returns its dependencies as one
+// scan source. Package URLs become purl queries and git URIs commit
+// queries; dependencies identified neither way are logged and skipped.
+func Parse(path string) (scanner.ScannedSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return scanner.ScannedSource{}, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err == nil && env.Payload != "" {
+		decoded, err := base64.StdEncoding.DecodeString(env.Payload)
+		if err != nil {
+			return scanner.ScannedSource{}, fmt.Errorf("invalid DSSE payload in %s: %w", path, err)
+		}
+		data = decoded
+	}
+
+	var stmt statement
+	if err := json.Unmarshal(data, &stmt); err != nil {
+		return scanner.ScannedSource{}, fmt.Errorf("invalid attestation %s: %w", path, err)
+	}
+	if !strings.Contains(stmt.PredicateType, "slsa.dev/provenance") {
+		return scanner.ScannedSource{}, fmt.Errorf("%s is not SLSA provenance (predicate type %q)", path, stmt.PredicateType)
+	}
+	var pred predicate
+	if err := json.Unmarshal(stmt.Predicate, &pred); err != nil {
+		return scanner.ScannedSource{}, fmt.Errorf("invalid predicate in %s: %w", path, err)
+	}
+
+	deps := pred.BuildDefinition.ResolvedDependencies
+	if len(deps) == 0 {
+		deps = pred.Materials
+	}
+	if len(deps) == 0 {
+		return scanner.ScannedSource{}, fmt.Errorf("%s lists no dependencies or materials", path)
+	}
+
+	var pkgs []models.PackageInfo
+	for _, dep := range deps {
+		pkg, ok := depToPackage(dep)
+		if !ok {
+			log.Printf("Skipping unidentifiable dependency %q in %s", dep.URI, path)
+			continue
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	if len(pkgs) == 0 {
+		return scanner.ScannedSource{}, fmt.Errorf("no queryable dependencies in %s", path)
+	}
+
+	return scanner.ScannedSource{
+		Source:   models.SourceInfo{Path: path, Type: "provenance"},
+		Packages: pkgs,
+	}, nil
+}
+
+// depToPackage maps one resolved dependency to a queryable package:
+// pkg: URIs as-is, git URIs via their commit digest.
+func depToPackage(dep dependency) (models.PackageInfo, bool) {
+	if strings.HasPrefix(dep.URI, "pkg:") {
+		pkg := models.PackageInfo{PURL: dep.URI}
+		// Pull the version out of the purl for human-readable output;
+		// the query uses the purl itself.
+		if i := strings.LastIndex(dep.URI, "@"); i > strings.Index(dep.URI, "/") {
+			pkg.Name = dep.URI[len("pkg:"):i]
+			pkg.Version = dep.URI[i+1:]
+		}
+
+		return pkg, true
+	}
+	if strings.HasPrefix(dep.URI, "git+") || strings.HasSuffix(dep.URI, ".git") {
+		for _, alg := range []string{"gitCommit", "sha1"} {
+			if commit := dep.Digest[alg]; commit != "" {
+				return models.PackageInfo{Commit: commit}, true
+			}
+		}
+	}
+
+	return models.PackageInfo{}, false
+}
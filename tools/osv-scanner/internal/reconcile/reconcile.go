@@ -0,0 +1,214 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reconcile checks scanned lockfiles against the manifests they
+// were generated from. A lockfile that has fallen behind its manifest
+// still parses fine, but scanning it reports the dependency set of some
+// earlier commit — results that look authoritative and are not. The
+// checks here are heuristics that flag likely staleness; they never
+// block the scan.
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// manifestFor maps a lockfile name to the manifest it is generated
+// from, for the generic freshness check.
+var manifestFor = map[string]string{
+	"package-lock.json": "package.json",
+	"yarn.lock":         "package.json",
+	"pnpm-lock.yaml":    "package.json",
+	"requirements.txt":  "requirements.in",
+	"Cargo.lock":        "Cargo.toml",
+	"Gemfile.lock":      "Gemfile",
+	"composer.lock":     "composer.json",
+}
+
+// Check inspects the lockfile at path and returns warnings about
+// apparent staleness relative to its manifest. A missing manifest is
+// not a finding: not every requirements.txt has a requirements.in.
+func Check(path string) []models.Warning {
+	name := filepath.Base(path)
+	manifestName, ok := manifestFor[name]
+	if !ok {
+		return nil
+	}
+	manifestPath := filepath.Join(filepath.Dir(path), manifestName)
+	if _, err := os.Stat(manifestPath); err != nil {
+		return nil
+	}
+
+	var warnings []models.Warning
+	if warning, ok := checkFreshness(path, manifestPath); ok {
+		warnings = append(warnings, warning)
+	}
+	var missing []string
+	switch name {
+	case "package-lock.json":
+		missing = missingNpmDeps(path, manifestPath)
+	case "requirements.txt":
+		missing = missingRequirements(path, manifestPath)
+	}
+	if len(missing) > 0 {
+		warnings = append(warnings, models.Warning{
+			Path: path,
+			Detail: fmt.Sprintf("%s declares dependencies missing from the lockfile (%s); regenerate it before trusting these results",
+				manifestName, strings.Join(missing, ", ")),
+		})
+	}
+
+	return warnings
+}
+
+// checkFreshness warns when the manifest was modified after the
+// lockfile, the usual sign of an edit without a reinstall.
+func checkFreshness(lockPath, manifestPath string) (models.Warning, bool) {
+	lockInfo, err := os.Stat(lockPath)
+	if err != nil {
+		return models.Warning{}, false
+	}
+	manifestInfo, err := os.Stat(manifestPath)
+	if err != nil {
+		return models.Warning{}, false
+	}
+	if !manifestInfo.ModTime().After(lockInfo.ModTime()) {
+		return models.Warning{}, false
+	}
+
+	return models.Warning{
+		Path: lockPath,
+		Detail: fmt.Sprintf("%s was modified after the lockfile; the lockfile may be stale",
+			filepath.Base(manifestPath)),
+	}, true
+}
+
+// missingNpmDeps returns the dependencies package.json declares that
+// package-lock.json does not lock, covering both the v1 (dependencies)
+// and v2/v3 (packages) lockfile layouts.
+func missingNpmDeps(lockPath, manifestPath string) []string {
+	manifestData, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil
+	}
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil
+	}
+
+	lockData, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil
+	}
+	var lock struct {
+		Packages     map[string]json.RawMessage `json:"packages"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(lockData, &lock); err != nil {
+		return nil
+	}
+	locked := func(name string) bool {
+		if _, ok := lock.Packages["node_modules/"+name]; ok {
+			return true
+		}
+		_, ok := lock.Dependencies[name]
+
+		return ok
+	}
+
+	var missing []string
+	for _, deps := range []map[string]string{manifest.Dependencies, manifest.DevDependencies} {
+		for name, requirement := range deps {
+			// Path and link requirements are not registry packages and
+			// may legitimately be absent.
+			if strings.HasPrefix(requirement, "file:") || strings.HasPrefix(requirement, "link:") {
+				continue
+			}
+			if !locked(name) {
+				missing = append(missing, name)
+			}
+		}
+	}
+
+	return missing
+}
+
+// missingRequirements returns the packages requirements.in names that
+// requirements.txt does not pin.
+func missingRequirements(lockPath, manifestPath string) []string {
+	declared := requirementNames(manifestPath)
+	if len(declared) == 0 {
+		return nil
+	}
+	pinned := make(map[string]bool)
+	for _, name := range requirementNames(lockPath) {
+		pinned[name] = true
+	}
+
+	var missing []string
+	for _, name := range declared {
+		if !pinned[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// requirementNames extracts the normalized package names from a
+// requirements file, skipping options, comments and local paths.
+func requirementNames(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "-") ||
+			strings.Contains(line, "/") || strings.Contains(line, "@") {
+			continue
+		}
+		if i := strings.IndexAny(line, "=<>!~ ["); i >= 0 {
+			line = line[:i]
+		}
+		if line != "" {
+			names = append(names, normalizeRequirement(line))
+		}
+	}
+
+	return names
+}
+
+// normalizeRequirement applies PEP 503 name normalization so the same
+// package spelled differently in the two files still matches.
+func normalizeRequirement(name string) string {
+	name = strings.ToLower(name)
+	name = strings.ReplaceAll(name, "_", "-")
+	name = strings.ReplaceAll(name, ".", "-")
+
+	return name
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// parsePackageJSON extracts the direct dependencies of an npm package.json.
+// Requirements that pin a concrete version (optionally with a leading
+// "^", "~" or "=") keep it; range requirements like "*" or ">=1.0" are
+// left unpinned for resolveTransitive to resolve against the registry.
+// Workspace, git and file requirements cannot be resolved server-side
+// and are skipped.
+func parsePackageJSON(path string) ([]models.PackageInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var pkgs []models.PackageInfo
+	for _, deps := range []map[string]string{manifest.Dependencies, manifest.DevDependencies} {
+		for name, requirement := range deps {
+			if strings.ContainsAny(requirement, "/:") {
+				continue
+			}
+			version := strings.TrimLeft(requirement, "^~=v")
+			if strings.ContainsAny(version, "*<>| ") {
+				version = ""
+			}
+			pkgs = append(pkgs, models.PackageInfo{
+				Name:      name,
+				Version:   version,
+				Ecosystem: "npm",
+			})
+		}
+	}
+
+	return pkgs, nil
+}
+
+// parseRequirementsTxt extracts the declared dependencies of a pip
+// requirements.txt. "==" pins keep their version; any other requirement
+// is left unpinned for resolveTransitive to resolve against the
+// registry, so scans are not limited to pinned declarations.
+func parseRequirementsTxt(path string) ([]models.PackageInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []models.PackageInfo
+	for _, line := range strings.Split(string(data), "\n") {
+		// Strip comments and environment markers.
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		// Skip blanks, pip options and non-registry requirements.
+		if line == "" || strings.HasPrefix(line, "-") || strings.ContainsAny(line, "/@") {
+			continue
+		}
+		name, version := line, ""
+		if i := strings.IndexAny(line, "=<>!~"); i >= 0 {
+			name = strings.TrimSpace(line[:i])
+			if requirement := line[i:]; strings.HasPrefix(requirement, "==") {
+				version = strings.TrimSuffix(strings.TrimSpace(requirement[2:]), ".*")
+			}
+		}
+		// Extras do not affect the queried package identity.
+		if i := strings.Index(name, "["); i >= 0 {
+			name = name[:i]
+		}
+		if name == "" {
+			continue
+		}
+		pkgs = append(pkgs, models.PackageInfo{
+			Name:      name,
+			Version:   version,
+			Ecosystem: "PyPI",
+		})
+	}
+
+	return pkgs, nil
+}
+
+// parsePomXML extracts the direct dependencies of a Maven pom.xml.
+// Dependencies with property-interpolated or managed (absent) versions are
+// skipped.
+func parsePomXML(path string) ([]models.PackageInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pom struct {
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+		} `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return nil, err
+	}
+
+	var pkgs []models.PackageInfo
+	for _, dep := range pom.Dependencies {
+		if dep.Version == "" || strings.Contains(dep.Version, "${") {
+			continue
+		}
+		pkgs = append(pkgs, models.PackageInfo{
+			Name:      dep.GroupID + ":" + dep.ArtifactID,
+			Version:   dep.Version,
+			Ecosystem: "Maven",
+		})
+	}
+
+	return pkgs, nil
+}
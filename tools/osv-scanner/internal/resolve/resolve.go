@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolve implements experimental transitive dependency resolution
+// for manifests without lockfiles (pom.xml, package.json,
+// requirements.txt) using the deps.dev API, so the full resolved graph
+// can be queried against OSV rather than only the direct dependencies.
+// Unpinned requirements are first resolved to the registry's current
+// default version before their subgraph is expanded.
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// depsDevAPI is the base URL of the deps.dev HTTP API.
+const depsDevAPI = "https://api.deps.dev/v3alpha"
+
+// ScanDir walks dir for resolvable manifests (pom.xml, package.json) and
+// resolves each one's transitive dependency graph via deps.dev. When the
+// API is unreachable, resolution falls back to the manifest's direct
+// dependencies with an explicit notice, so offline scans still produce
+// (partial) results.
+func ScanDir(ctx context.Context, dir string, recursive bool) ([]scanner.ScannedSource, error) {
+	var sources []scanner.ScannedSource
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || d.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			if !recursive && path != dir {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		var parse func(string) ([]models.PackageInfo, error)
+		switch d.Name() {
+		case "package.json":
+			parse = parsePackageJSON
+		case "pom.xml":
+			parse = parsePomXML
+		case "requirements.txt":
+			parse = parseRequirementsTxt
+		default:
+			return nil
+		}
+		direct, err := parse(path)
+		if err != nil {
+			log.Printf("Failed to parse %s: %v", path, err)
+			return nil
+		}
+		sources = append(sources, scanner.ScannedSource{
+			Source:   models.SourceInfo{Path: path, Type: "manifest"},
+			Packages: resolveTransitive(ctx, path, direct),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// resolveTransitive expands direct dependencies into the full resolved
+// graph using deps.dev, deduplicating across the direct dependencies'
+// subgraphs.
+func resolveTransitive(ctx context.Context, path string, direct []models.PackageInfo) []models.PackageInfo {
+	seen := make(map[string]struct{})
+	var resolved []models.PackageInfo
+	add := func(pkg models.PackageInfo) {
+		key := pkg.Ecosystem + "/" + pkg.Name + "@" + pkg.Version
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		resolved = append(resolved, pkg)
+	}
+
+	for _, pkg := range direct {
+		if pkg.Version == "" {
+			version, err := defaultVersion(ctx, pkg)
+			if err != nil {
+				log.Printf("Cannot resolve unpinned %s in %s: %v", pkg.Name, path, err)
+				continue
+			}
+			pkg.Version = version
+		}
+		add(pkg)
+		deps, err := dependencies(ctx, pkg)
+		if err != nil {
+			log.Printf("deps.dev resolution unavailable for %s@%s in %s: %v; "+
+				"results include direct dependencies only", pkg.Name, pkg.Version, path, err)
+			continue
+		}
+		for _, dep := range deps {
+			add(dep)
+		}
+	}
+
+	return resolved
+}
+
+// system maps an OSV ecosystem to its deps.dev system name.
+func system(ecosystem string) (string, error) {
+	name := map[string]string{"npm": "npm", "Maven": "maven", "PyPI": "pypi"}[ecosystem]
+	if name == "" {
+		return "", fmt.Errorf("unsupported ecosystem %q", ecosystem)
+	}
+
+	return name, nil
+}
+
+// defaultVersion returns the registry's current default version of an
+// unpinned package — what an unconstrained install would pick today.
+func defaultVersion(ctx context.Context, pkg models.PackageInfo) (string, error) {
+	sys, err := system(pkg.Ecosystem)
+	if err != nil {
+		return "", err
+	}
+	reqURL := fmt.Sprintf("%s/systems/%s/packages/%s", depsDevAPI, sys, url.PathEscape(pkg.Name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("deps.dev returned %s", resp.Status)
+	}
+
+	var info struct {
+		Versions []struct {
+			VersionKey struct {
+				Version string `json:"version"`
+			} `json:"versionKey"`
+			IsDefault bool `json:"isDefault"`
+		} `json:"versions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", err
+	}
+	for _, version := range info.Versions {
+		if version.IsDefault {
+			return version.VersionKey.Version, nil
+		}
+	}
+
+	return "", fmt.Errorf("no default version for %s", pkg.Name)
+}
+
+// dependencies returns the resolved transitive dependency graph of a
+// single package version from deps.dev.
+func dependencies(ctx context.Context, pkg models.PackageInfo) ([]models.PackageInfo, error) {
+	sys, err := system(pkg.Ecosystem)
+	if err != nil {
+		return nil, err
+	}
+	reqURL := fmt.Sprintf("%s/systems/%s/packages/%s/versions/%s:dependencies",
+		depsDevAPI, sys, url.PathEscape(pkg.Name), url.PathEscape(pkg.Version))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("deps.dev returned %s", resp.Status)
+	}
+
+	var graph struct {
+		Nodes []struct {
+			VersionKey struct {
+				System  string `json:"system"`
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"versionKey"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&graph); err != nil {
+		return nil, err
+	}
+
+	var deps []models.PackageInfo
+	for _, node := range graph.Nodes {
+		ecosystem := map[string]string{"NPM": "npm", "MAVEN": "Maven", "PYPI": "PyPI"}[strings.ToUpper(node.VersionKey.System)]
+		if ecosystem == "" {
+			ecosystem = pkg.Ecosystem
+		}
+		deps = append(deps, models.PackageInfo{
+			Name:      node.VersionKey.Name,
+			Version:   node.VersionKey.Version,
+			Ecosystem: ecosystem,
+		})
+	}
+
+	return deps, nil
+}
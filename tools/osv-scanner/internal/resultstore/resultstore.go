@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resultstore persists scan results on disk so that organization
+// wide scans producing millions of findings can be merged, diffed and
+// reported on without holding everything in memory.
+//
+// Results are stored in a bbolt database, one record per scanned source,
+// keyed by the SHA-256 of the record's canonical JSON encoding. Because keys
+// are content addressed, re-storing an unchanged source is a no-op and
+// merging databases from parallel scan shards is a plain key union.
+package resultstore
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+var resultsBucket = []byte("results")
+
+// Store is a disk-backed store of per-source scan results.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (creating if necessary) the result store at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result store %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(resultsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add stores the results for a single source, returning the content key it
+// was stored under. Storing an identical record again is a no-op.
+func (s *Store) Add(source models.PackageSource) ([]byte, error) {
+	encoded, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256(encoded)
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(resultsBucket).Put(key[:], encoded)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key[:], nil
+}
+
+// AddAll stores every source of results in a single transaction.
+func (s *Store) AddAll(results *models.VulnerabilityResults) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(resultsBucket)
+		for _, source := range results.Results {
+			encoded, err := json.Marshal(source)
+			if err != nil {
+				return err
+			}
+			key := sha256.Sum256(encoded)
+			if err := bucket.Put(key[:], encoded); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Walk streams every stored source to fn in key order, decoding one record
+// at a time. Returning an error from fn stops the walk.
+func (s *Store) Walk(fn func(source models.PackageSource) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(resultsBucket).ForEach(func(_, v []byte) error {
+			var source models.PackageSource
+			if err := json.Unmarshal(v, &source); err != nil {
+				return err
+			}
+
+			return fn(source)
+		})
+	})
+}
+
+// Len returns the number of stored source records.
+func (s *Store) Len() (int, error) {
+	var n int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(resultsBucket).Stats().KeyN
+		return nil
+	})
+
+	return n, err
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rustbinary extracts the crate dependencies embedded in binaries
+// built with `cargo auditable`, which stores a zlib-compressed JSON
+// dependency list in a dedicated section, so Rust release artifacts can be
+// scanned without source.
+package rustbinary
+
+import (
+	"bytes"
+	"compress/zlib"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// auditData is the cargo auditable VersionInfo format.
+type auditData struct {
+	Packages []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Source  string `json:"source"`
+	} `json:"packages"`
+}
+
+// Scan reads the cargo auditable dependency list of the binary at path.
+func Scan(path string) (scanner.ScannedSource, error) {
+	section, err := auditSection(path)
+	if err != nil {
+		return scanner.ScannedSource{}, err
+	}
+	zr, err := zlib.NewReader(section)
+	if err != nil {
+		return scanner.ScannedSource{}, fmt.Errorf("%s: invalid audit data: %w", path, err)
+	}
+	defer zr.Close()
+	var data auditData
+	if err := json.NewDecoder(zr).Decode(&data); err != nil {
+		return scanner.ScannedSource{}, fmt.Errorf("%s: invalid audit data: %w", path, err)
+	}
+
+	var pkgs []models.PackageInfo
+	for _, pkg := range data.Packages {
+		// Only registry crates are queryable against crates.io; path and
+		// git dependencies have no published version.
+		if pkg.Source != "registry" && pkg.Source != "crates.io" {
+			continue
+		}
+		pkgs = append(pkgs, models.PackageInfo{
+			Name:      pkg.Name,
+			Version:   pkg.Version,
+			Ecosystem: "crates.io",
+		})
+	}
+
+	return scanner.ScannedSource{
+		Source:   models.SourceInfo{Path: path, Type: "rust-binary"},
+		Packages: pkgs,
+	}, nil
+}
+
+// auditSection returns a reader over the cargo auditable section of an
+// ELF, Mach-O or PE binary.
+func auditSection(path string) (io.Reader, error) {
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		if s := f.Section(".dep-v0"); s != nil {
+			data, err := s.Data()
+			if err != nil {
+				return nil, err
+			}
+
+			return bytes.NewReader(data), nil
+		}
+	}
+	if f, err := macho.Open(path); err == nil {
+		defer f.Close()
+		if s := f.Section("rust-deps-v0"); s != nil {
+			data, err := s.Data()
+			if err != nil {
+				return nil, err
+			}
+
+			return bytes.NewReader(data), nil
+		}
+	}
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		if s := f.Section(".dep-v0"); s != nil {
+			data, err := s.Data()
+			if err != nil {
+				return nil, err
+			}
+
+			return bytes.NewReader(data), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s has no cargo auditable data", path)
+}
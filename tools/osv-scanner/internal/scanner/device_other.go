@@ -0,0 +1,31 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !unix
+
+package scanner
+
+import "os"
+
+// deviceOf has no portable answer off unix, so the one-filesystem walk
+// option degrades to scanning everything.
+func deviceOf(_ os.FileInfo) (uint64, bool) {
+	return 0, false
+}
+
+// fileIdentity has no portable answer off unix; cycle detection falls
+// back to comparing resolved paths.
+func fileIdentity(_ os.FileInfo) (fileID, bool) {
+	return fileID{}, false
+}
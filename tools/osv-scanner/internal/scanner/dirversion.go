@@ -0,0 +1,111 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// cppExtensions are the file types the determineversion index is built
+// from.
+var cppExtensions = map[string]bool{
+	".c":   true,
+	".cc":  true,
+	".cpp": true,
+	".cxx": true,
+	".h":   true,
+	".hh":  true,
+	".hpp": true,
+	".hxx": true,
+}
+
+// ScanDirVersion identifies the vendored C/C++ library in dir by hashing
+// its source files and asking the experimental determineversion endpoint
+// for the best match, which then flows through the normal vulnerability
+// query as a package version.
+func ScanDirVersion(ctx context.Context, dir string) (ScannedSource, error) {
+	var hashes []osv.DetermineVersionHash
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if !cppExtensions[filepath.Ext(path)] {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		digest := md5.Sum(data)
+		hashes = append(hashes, osv.DetermineVersionHash{
+			Path: filepath.ToSlash(rel),
+			Hash: digest[:],
+		})
+
+		return nil
+	})
+	if err != nil {
+		return ScannedSource{}, err
+	}
+	if len(hashes) == 0 {
+		return ScannedSource{}, fmt.Errorf("%s contains no C/C++ source files", dir)
+	}
+
+	resp, err := osv.DetermineVersion(ctx, &osv.DetermineVersionQuery{
+		Name:       filepath.Base(dir),
+		FileHashes: hashes,
+	})
+	if err != nil {
+		return ScannedSource{}, err
+	}
+	if len(resp.Matches) == 0 {
+		return ScannedSource{}, fmt.Errorf("no library matches %s", dir)
+	}
+	best := resp.Matches[0]
+	name := strings.TrimSuffix(filepath.Base(best.RepoInfo.Address), ".git")
+	log.Printf("Determined %s to be %s %s (score %.2f)", dir, name, best.RepoInfo.Version, best.Score)
+
+	return ScannedSource{
+		Source: models.SourceInfo{Path: dir, Type: "dir-version"},
+		Packages: []models.PackageInfo{{
+			Name:    name,
+			Version: best.RepoInfo.Version,
+		}},
+	}, nil
+}
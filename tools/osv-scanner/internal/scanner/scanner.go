@@ -0,0 +1,495 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scanner walks scan targets, extracts the packages they contain
+// and queries OSV for vulnerabilities affecting them.
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/osv/go/purl"
+
+	"github.com/google/osv/tools/osv-scanner/internal/extractor"
+	"github.com/google/osv/tools/osv-scanner/internal/grouper"
+	"github.com/google/osv/tools/osv-scanner/internal/localmatcher"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// WalkOptions controls how ScanDir walks a tree. The zero value scans
+// only the top level.
+type WalkOptions struct {
+	// Recursive descends into subdirectories.
+	Recursive bool
+	// MaxDepth bounds how many directory levels below the root are
+	// entered; 0 means no bound. A monorepo's manifests usually sit in
+	// the first few levels, while its depth is dominated by vendored
+	// trees.
+	MaxDepth int
+	// OneFilesystem skips directories on a different filesystem than
+	// the root, the way find -xdev does, so bind mounts and network
+	// mounts do not pull a whole other tree into the scan.
+	OneFilesystem bool
+	// FollowSymlinks descends into symlinked directories and extracts
+	// symlinked files. Directories already walked — including through a
+	// different link — are skipped, so link cycles terminate and a tree
+	// reachable twice is only scanned once.
+	FollowSymlinks bool
+}
+
+// ScanDir walks dir looking for files a registered extractor can handle and
+// returns the scanned sources. If recursive is false only the top level of
+// dir is considered. Cancelling ctx stops the walk.
+func ScanDir(ctx context.Context, dir string, recursive bool) ([]ScannedSource, error) {
+	sources, _, err := ScanDirWithOptions(ctx, dir, WalkOptions{Recursive: recursive})
+
+	return sources, err
+}
+
+// ScanDirWithOptions is ScanDir with the walk bounded by opts. Paths
+// the walk could not read — a permission-protected directory, a
+// manifest the scanning user cannot open — do not abort it: they come
+// back as skipped sources so the report says what was not scanned,
+// while the rest of the tree is still covered. Only a failure on the
+// root itself is an error.
+func ScanDirWithOptions(ctx context.Context, dir string, opts WalkOptions) ([]ScannedSource, []models.SkippedSource, error) {
+	w := &dirWalker{
+		opts:    opts,
+		visited: make(map[fileID]bool),
+		// visitedPaths backs cycle detection where file identities are
+		// unavailable, keyed by resolved path.
+		visitedPaths: make(map[string]bool),
+	}
+	if opts.OneFilesystem {
+		if info, err := os.Stat(dir); err == nil {
+			w.rootDevice, w.haveRootDevice = deviceOf(info)
+		}
+	}
+	if err := w.walkTree(ctx, dir, dir, 0); err != nil {
+		return nil, nil, err
+	}
+
+	return w.sources, w.skipped, nil
+}
+
+// dirWalker carries the state of one ScanDirWithOptions walk.
+type dirWalker struct {
+	opts           WalkOptions
+	rootDevice     uint64
+	haveRootDevice bool
+	visited        map[fileID]bool
+	visitedPaths   map[string]bool
+	sources        []ScannedSource
+	skipped        []models.SkippedSource
+}
+
+// fileID identifies a file independent of the path it was reached by.
+type fileID struct {
+	dev, ino uint64
+}
+
+// walkTree walks the tree rooted at root. logical is the path findings
+// under root are reported as, which differs from root inside followed
+// symlinks; depth is how many levels root itself sits below the scan
+// root.
+func (w *dirWalker) walkTree(ctx context.Context, root, logical string, depth int) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			rel = "."
+		}
+		if err != nil {
+			// An unreadable entry below the root doesn't kill the scan;
+			// it is recorded so the report can say what was not covered.
+			if path == root {
+				return err
+			}
+			w.skip(w.logicalPath(logical, rel), err)
+
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		entryDepth := depth
+		if rel != "." {
+			entryDepth += strings.Count(rel, string(filepath.Separator)) + 1
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if !w.opts.Recursive && entryDepth > 0 {
+				return filepath.SkipDir
+			}
+			if w.opts.MaxDepth > 0 && entryDepth > w.opts.MaxDepth {
+				return filepath.SkipDir
+			}
+			if w.haveRootDevice && entryDepth > 0 {
+				if info, err := d.Info(); err == nil {
+					if device, ok := deviceOf(info); ok && device != w.rootDevice {
+						return filepath.SkipDir
+					}
+				}
+			}
+			// The root of a followed link subtree was already marked by
+			// followLink; re-checking it here would skip the very subtree
+			// being walked, so only non-root directories can be cycles.
+			if info, err := d.Info(); err == nil && w.alreadyVisited(path, info) && path != root {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			if !w.opts.FollowSymlinks {
+				return nil
+			}
+
+			return w.followLink(ctx, path, w.logicalPath(logical, rel), entryDepth)
+		}
+		w.scanPath(path, w.logicalPath(logical, rel))
+
+		return nil
+	})
+}
+
+// followLink resolves one symlink entry: symlinked directories are
+// walked as a subtree reported under the link's path, symlinked regular
+// files are extracted, anything else (sockets, dangling links) is
+// skipped.
+func (w *dirWalker) followLink(ctx context.Context, path, logical string, depth int) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			w.skip(logical, err)
+		} else {
+			log.Printf("Skipping symlink %s: %v", path, err)
+		}
+
+		return nil
+	}
+	if !info.IsDir() {
+		if info.Mode().IsRegular() {
+			w.scanPath(path, logical)
+		}
+
+		return nil
+	}
+	if !w.opts.Recursive && depth > 0 {
+		return nil
+	}
+	if w.opts.MaxDepth > 0 && depth > w.opts.MaxDepth {
+		return nil
+	}
+	if w.haveRootDevice {
+		if device, ok := deviceOf(info); ok && device != w.rootDevice {
+			return nil
+		}
+	}
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		log.Printf("Skipping symlink %s: %v", path, err)
+		return nil
+	}
+	if w.alreadyVisited(target, info) {
+		return nil
+	}
+
+	return w.walkTree(ctx, target, logical, depth)
+}
+
+// alreadyVisited marks a directory as walked and reports whether it had
+// been before, under any path. Identity is the (device, inode) pair
+// where the platform exposes one, the resolved path otherwise.
+func (w *dirWalker) alreadyVisited(path string, info os.FileInfo) bool {
+	if id, ok := fileIdentity(info); ok {
+		if w.visited[id] {
+			return true
+		}
+		w.visited[id] = true
+
+		return false
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = filepath.Clean(path)
+	}
+	if w.visitedPaths[resolved] {
+		return true
+	}
+	w.visitedPaths[resolved] = true
+
+	return false
+}
+
+// logicalPath is the path a file found at rel below a walked root is
+// reported as.
+func (w *dirWalker) logicalPath(logical, rel string) string {
+	if rel == "." {
+		return logical
+	}
+
+	return filepath.Join(logical, rel)
+}
+
+// scanPath extracts path if a registered extractor handles it,
+// reporting it as logical, the path it was reached by.
+func (w *dirWalker) scanPath(path, logical string) {
+	ext, ok := extractor.ForPath(path)
+	if !ok {
+		return
+	}
+	source, err := ScanFile(path, ext)
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			w.skip(logical, err)
+			return
+		}
+		log.Printf("Failed to extract %s: %v", path, err)
+
+		return
+	}
+	source.Source.Path = logical
+	w.sources = append(w.sources, source)
+}
+
+// skip records a path the walk could not read, both in the log and as a
+// skipped source for the report.
+func (w *dirWalker) skip(logical string, err error) {
+	log.Printf("Skipping %s: %v", logical, err)
+	w.skipped = append(w.skipped, models.SkippedSource{
+		Path:   logical,
+		Type:   "unreadable",
+		Reason: err.Error(),
+	})
+}
+
+// ScannedSource is a source together with the packages extracted from it,
+// before vulnerabilities have been attached.
+type ScannedSource struct {
+	Source   models.SourceInfo
+	Packages []models.PackageInfo
+}
+
+// ScanFile extracts a single file into a scanned source using ext.
+func ScanFile(path string, ext extractor.Extractor) (ScannedSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ScannedSource{}, err
+	}
+	defer f.Close()
+	pkgs, err := ext.Extract(path, f)
+	if err != nil {
+		return ScannedSource{}, err
+	}
+
+	return ScannedSource{
+		Source:   models.SourceInfo{Path: path, Type: "lockfile"},
+		Packages: pkgs,
+	}, nil
+}
+
+// queryWindow is how many queries are in flight at once: one batch
+// request's worth is built, sent and hydrated before the next is
+// assembled, so memory stays flat however many packages a tree holds.
+const queryWindow = 1000
+
+// QuerySources queries OSV for all packages of the given sources and
+// assembles the full results.
+func QuerySources(ctx context.Context, sources []ScannedSource) (*models.VulnerabilityResults, error) {
+	// positions maps each query of the current window back to its
+	// (source, package) position.
+	type position struct{ source, pkg int }
+	var window osv.BatchedQuery
+	var positions []position
+	packageVulns := make([][]models.PackageVulns, len(sources))
+
+	flush := func() error {
+		if len(window.Queries) == 0 {
+			return nil
+		}
+		resp, err := osv.MakeRequest(ctx, window)
+		if err != nil {
+			// Invalid queries (a malformed PURL, an unknown ecosystem) come
+			// back attributed per query with the valid remainder answered;
+			// report which package produced each and keep going.
+			var batchErr *osv.BatchError
+			if !errors.As(err, &batchErr) {
+				return fmt.Errorf("scan failed: %w", err)
+			}
+			for _, invalid := range batchErr.Invalid {
+				pos := positions[invalid.Index]
+				pkg := sources[pos.source].Packages[pos.pkg]
+				log.Printf("Skipping %s %s from %s: %v", pkg.Name, pkg.Version, sources[pos.source].Source.Path, invalid.Cause)
+			}
+		}
+		hydrated, err := osv.Hydrate(ctx, resp)
+		if err != nil {
+			return fmt.Errorf("failed to hydrate OSV response: %w", err)
+		}
+		for i, result := range hydrated.Results {
+			if len(result.Vulns) == 0 {
+				continue
+			}
+			pos := positions[i]
+			packageVulns[pos.source] = append(packageVulns[pos.source], models.PackageVulns{
+				Package:         sources[pos.source].Packages[pos.pkg],
+				Vulnerabilities: result.Vulns,
+				Groups:          grouper.Group(result.Vulns),
+			})
+		}
+		window.Queries = nil
+		positions = nil
+
+		return nil
+	}
+
+	for i, source := range sources {
+		for j, pkg := range source.Packages {
+			window.Queries = append(window.Queries, pkgToQuery(pkg))
+			positions = append(positions, position{i, j})
+			if len(window.Queries) == queryWindow {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	results := &models.VulnerabilityResults{Date: time.Now().UTC()}
+	for i, source := range sources {
+		if len(packageVulns[i]) == 0 {
+			continue
+		}
+		results.Results = append(results.Results, models.PackageSource{
+			Source:   source.Source,
+			Packages: packageVulns[i],
+		})
+	}
+
+	return results, nil
+}
+
+// QuerySourcesLocal is QuerySources answered from a local advisory
+// database instead of the OSV API; no network requests are made.
+func QuerySourcesLocal(sources []ScannedSource, db *localmatcher.DB) *models.VulnerabilityResults {
+	results := &models.VulnerabilityResults{Date: time.Now().UTC()}
+	for _, source := range sources {
+		var pkgs []models.PackageVulns
+		for _, pkg := range source.Packages {
+			// The local index is keyed by name and ecosystem, so query by
+			// those rather than by PURL, translating purl-identified
+			// packages first.
+			name, ecosystem, version := queryName(pkg), pkg.Ecosystem, pkg.Version
+			if pkg.Commit == "" && pkg.PURL != "" {
+				if n, e, v, ok := purlQuery(pkg); ok {
+					name, ecosystem, version = n, e, v
+				}
+			}
+			vulns := db.Query(&osv.Query{
+				Commit:  pkg.Commit,
+				Package: osv.Package{Name: name, Ecosystem: ecosystem},
+				Version: version,
+			})
+			if len(vulns) == 0 {
+				continue
+			}
+			pkgs = append(pkgs, models.PackageVulns{
+				Package:         pkg,
+				Vulnerabilities: vulns,
+				Groups:          grouper.Group(vulns),
+			})
+		}
+		if len(pkgs) == 0 {
+			continue
+		}
+		results.Results = append(results.Results, models.PackageSource{
+			Source:   source.Source,
+			Packages: pkgs,
+		})
+	}
+
+	return results
+}
+
+func pkgToQuery(pkg models.PackageInfo) *osv.Query {
+	if pkg.Commit != "" {
+		return &osv.Query{Commit: pkg.Commit}
+	}
+	if pkg.PURL != "" {
+		// Querying by name and ecosystem rather than by raw purl lets the
+		// distro qualifier select the versioned ecosystem ("Debian:12"),
+		// which release-specific advisories are keyed by.
+		if name, ecosystem, version, ok := purlQuery(pkg); ok {
+			return &osv.Query{
+				Package: osv.Package{Name: name, Ecosystem: ecosystem},
+				Version: version,
+			}
+		}
+
+		return &osv.Query{
+			Package: osv.Package{PURL: pkg.PURL},
+			Version: pkg.Version,
+		}
+	}
+
+	return &osv.Query{
+		Package: osv.Package{Name: queryName(pkg), Ecosystem: pkg.Ecosystem},
+		Version: pkg.Version,
+	}
+}
+
+// queryName is the name a package's advisories are keyed by: the source
+// package for binary OS packages, the package name otherwise.
+func queryName(pkg models.PackageInfo) string {
+	if pkg.SourceName != "" {
+		return pkg.SourceName
+	}
+
+	return pkg.Name
+}
+
+// purlQuery translates a package identified by purl into the name,
+// ecosystem and version OSV keys its advisories by. ok is false when the
+// purl does not parse or its type has no OSV ecosystem; such packages
+// fall back to a raw purl query.
+func purlQuery(pkg models.PackageInfo) (name, ecosystem, version string, ok bool) {
+	p, err := purl.Parse(pkg.PURL)
+	if err != nil {
+		return "", "", "", false
+	}
+	ecosystem, ok = purl.OSVEcosystem(p)
+	if !ok {
+		return "", "", "", false
+	}
+	version = p.Version
+	if version == "" {
+		version = pkg.Version
+	}
+
+	return p.OSVName(), ecosystem, version, true
+}
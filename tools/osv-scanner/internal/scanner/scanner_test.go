@@ -0,0 +1,242 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"testing"
+
+	// Register the lockfile extractors the walk tests rely on.
+	_ "github.com/google/osv/tools/osv-scanner/internal/lockfile"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+const lockContents = `{"lockfileVersion": 3, "packages": {"node_modules/lodash": {"version": "4.17.20"}}}`
+
+// writeLock drops a minimal package-lock.json at dir/name, creating
+// intermediate directories.
+func writeLock(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(lockContents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func sourcePaths(sources []ScannedSource) []string {
+	var paths []string
+	for _, source := range sources {
+		paths = append(paths, source.Source.Path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func TestScanDirRecursive(t *testing.T) {
+	dir := t.TempDir()
+	writeLock(t, dir, "package-lock.json")
+	writeLock(t, dir, filepath.Join("sub", "package-lock.json"))
+
+	sources, err := ScanDir(context.Background(), dir, false)
+	if err != nil {
+		t.Fatalf("ScanDir: %v", err)
+	}
+	want := []string{filepath.Join(dir, "package-lock.json")}
+	if got := sourcePaths(sources); !reflect.DeepEqual(got, want) {
+		t.Errorf("non-recursive ScanDir found %v, want %v", got, want)
+	}
+
+	sources, err = ScanDir(context.Background(), dir, true)
+	if err != nil {
+		t.Fatalf("ScanDir: %v", err)
+	}
+	want = append(want, filepath.Join(dir, "sub", "package-lock.json"))
+	if got := sourcePaths(sources); !reflect.DeepEqual(got, want) {
+		t.Errorf("recursive ScanDir found %v, want %v", got, want)
+	}
+}
+
+func TestScanDirMaxDepth(t *testing.T) {
+	dir := t.TempDir()
+	writeLock(t, dir, filepath.Join("a", "b", "package-lock.json"))
+
+	for _, tt := range []struct {
+		maxDepth int
+		found    int
+	}{
+		{1, 0},
+		{2, 1},
+		{0, 1}, // no bound
+	} {
+		opts := WalkOptions{Recursive: true, MaxDepth: tt.maxDepth}
+		sources, _, err := ScanDirWithOptions(context.Background(), dir, opts)
+		if err != nil {
+			t.Fatalf("ScanDirWithOptions(MaxDepth=%d): %v", tt.maxDepth, err)
+		}
+		if len(sources) != tt.found {
+			t.Errorf("MaxDepth=%d found %d sources, want %d", tt.maxDepth, len(sources), tt.found)
+		}
+	}
+}
+
+func TestScanDirFollowSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink creation requires privileges on Windows")
+	}
+	root := t.TempDir()
+	target := t.TempDir()
+	writeLock(t, target, "package-lock.json")
+	if err := os.Symlink(target, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	sources, _, err := ScanDirWithOptions(context.Background(), root, WalkOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions: %v", err)
+	}
+	if len(sources) != 0 {
+		t.Errorf("walk without FollowSymlinks scanned %v", sourcePaths(sources))
+	}
+
+	opts := WalkOptions{Recursive: true, FollowSymlinks: true}
+	sources, _, err = ScanDirWithOptions(context.Background(), root, opts)
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions: %v", err)
+	}
+	// The linked tree is scanned and reported under the link's path.
+	want := []string{filepath.Join(root, "link", "package-lock.json")}
+	if got := sourcePaths(sources); !reflect.DeepEqual(got, want) {
+		t.Errorf("walk with FollowSymlinks found %v, want %v", got, want)
+	}
+}
+
+func TestScanDirSymlinkCycle(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink creation requires privileges on Windows")
+	}
+	root := t.TempDir()
+	writeLock(t, root, filepath.Join("sub", "package-lock.json"))
+	// A link back to the root, and a second route to the same subtree;
+	// the walk must terminate and scan the lockfile once.
+	if err := os.Symlink(root, filepath.Join(root, "sub", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "sub"), filepath.Join(root, "twice")); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := WalkOptions{Recursive: true, FollowSymlinks: true}
+	sources, _, err := ScanDirWithOptions(context.Background(), root, opts)
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Errorf("cyclic walk found %d sources, want 1: %v", len(sources), sourcePaths(sources))
+	}
+}
+
+func TestScanDirUnreadable(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permission bits are not enforced for this user")
+	}
+	dir := t.TempDir()
+	writeLock(t, dir, "package-lock.json")
+	sealed := filepath.Join(dir, "sealed")
+	if err := os.Mkdir(sealed, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeLock(t, sealed, "package-lock.json")
+	if err := os.Chmod(sealed, 0o000); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(sealed, 0o755) })
+
+	sources, skipped, err := ScanDirWithOptions(context.Background(), dir, WalkOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions: %v", err)
+	}
+	// The unreadable directory is reported, not fatal, and the rest of
+	// the tree is still scanned.
+	want := []string{filepath.Join(dir, "package-lock.json")}
+	if got := sourcePaths(sources); !reflect.DeepEqual(got, want) {
+		t.Errorf("walk found %v, want %v", got, want)
+	}
+	if len(skipped) != 1 || skipped[0].Path != sealed || skipped[0].Type != "unreadable" {
+		t.Errorf("skipped = %+v, want the sealed directory as unreadable", skipped)
+	}
+}
+
+func TestPkgToQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  models.PackageInfo
+		want string
+	}{
+		{
+			"commit",
+			models.PackageInfo{Commit: "aaaa000000000000000000000000000000000000"},
+			`commit aaaa000000000000000000000000000000000000`,
+		},
+		{
+			"name and ecosystem",
+			models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+			`lodash npm 4.17.20`,
+		},
+		{
+			"binary package queried by source name",
+			models.PackageInfo{Name: "libcurl4", SourceName: "curl", Version: "7.88.1-10", Ecosystem: "Debian:12"},
+			`curl Debian:12 7.88.1-10`,
+		},
+		{
+			"purl with distro qualifier selects the versioned ecosystem",
+			models.PackageInfo{PURL: "pkg:deb/debian/curl@7.88.1-10?distro=debian-12"},
+			`curl Debian:12 7.88.1-10`,
+		},
+		{
+			"purl of a type OSV does not cover falls back to raw purl",
+			models.PackageInfo{PURL: "pkg:otherregistry/widget@1.0.0", Version: "1.0.0"},
+			`purl pkg:otherregistry/widget@1.0.0 1.0.0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := pkgToQuery(tt.pkg)
+			var got string
+			switch {
+			case query.Commit != "":
+				got = "commit " + query.Commit
+			case query.Package.PURL != "":
+				got = "purl " + query.Package.PURL + " " + query.Version
+			default:
+				got = query.Package.Name + " " + query.Package.Ecosystem + " " + query.Version
+			}
+			if got != tt.want {
+				t.Errorf("pkgToQuery(%+v) = %q, want %q", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
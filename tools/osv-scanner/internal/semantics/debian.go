@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// debianComparer implements the Debian policy ordering of
+// [epoch:]upstream-version[-debian-revision] strings, including the "~"
+// sorts-before-everything rule used for pre-releases.
+type debianComparer struct{}
+
+func (debianComparer) Compare(a, b string) int {
+	aEpoch, aUpstream, aRev := splitEVR(a)
+	bEpoch, bUpstream, bRev := splitEVR(b)
+	if c := compareInt(aEpoch, bEpoch); c != 0 {
+		return c
+	}
+	if c := verrevcmp(aUpstream, bUpstream); c != 0 {
+		return c
+	}
+
+	return verrevcmp(aRev, bRev)
+}
+
+// splitEVR splits [epoch:]version[-revision]; the revision is everything
+// after the last hyphen, as upstream versions may themselves contain
+// hyphens.
+func splitEVR(s string) (int, string, string) {
+	epoch := 0
+	if e, rest, ok := strings.Cut(s, ":"); ok && isDigits(e) {
+		epoch = atoi(e)
+		s = rest
+	}
+	revision := ""
+	if i := strings.LastIndex(s, "-"); i >= 0 {
+		s, revision = s[:i], s[i+1:]
+	}
+
+	return epoch, s, revision
+}
+
+// verrevcmp is the Debian version comparison loop: alternating non-digit
+// and digit runs, with non-digits compared character-wise (letters before
+// other characters, "~" before everything including end of string) and
+// digit runs compared numerically.
+func verrevcmp(a, b string) int {
+	for a != "" || b != "" {
+		for hasNonDigitPrefix(a) || hasNonDigitPrefix(b) {
+			ac, bc := charOrder(a), charOrder(b)
+			if ac != bc {
+				return compareInt(ac, bc)
+			}
+			a, b = consume(a), consume(b)
+		}
+		var aNum, bNum string
+		aNum, a = digitPrefix(a)
+		bNum, b = digitPrefix(b)
+		if c := compareInt(atoi(aNum), atoi(bNum)); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func hasNonDigitPrefix(s string) bool {
+	return s != "" && (s[0] < '0' || s[0] > '9')
+}
+
+// charOrder ranks the first character: "~" before end of string, end of
+// string before letters, letters before everything else.
+func charOrder(s string) int {
+	switch {
+	case s == "":
+		return 0
+	case s[0] == '~':
+		return -1
+	case (s[0] >= 'a' && s[0] <= 'z') || (s[0] >= 'A' && s[0] <= 'Z'):
+		return int(s[0])
+	default:
+		return int(s[0]) + 256
+	}
+}
+
+func consume(s string) string {
+	if hasNonDigitPrefix(s) {
+		return s[1:]
+	}
+
+	return s
+}
+
+func digitPrefix(s string) (string, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// gemComparer implements RubyGems' Gem::Version ordering: dot-separated
+// segments that split at letter/digit boundaries, with string segments
+// (pre-releases such as "1.0.beta") sorting before numeric ones and
+// trailing zero segments ignored.
+type gemComparer struct{}
+
+func (gemComparer) Compare(a, b string) int {
+	aSegs := gemSegments(a)
+	bSegs := gemSegments(b)
+	for i := 0; i < len(aSegs) || i < len(bSegs); i++ {
+		av, bv := "0", "0"
+		if i < len(aSegs) {
+			av = aSegs[i]
+		}
+		if i < len(bSegs) {
+			bv = bSegs[i]
+		}
+		aNum, bNum := isDigits(av), isDigits(bv)
+		switch {
+		case aNum && bNum:
+			if c := compareInt(atoi(av), atoi(bv)); c != 0 {
+				return c
+			}
+		case aNum:
+			return 1
+		case bNum:
+			return -1
+		default:
+			if c := strings.Compare(av, bv); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// gemSegments canonicalizes a gem version: lower-case, split on "." and
+// "-" and at letter/digit boundaries, trailing zeros dropped.
+func gemSegments(s string) []string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	// A "-" introduces a pre-release, equivalent to ".pre.".
+	s = strings.ReplaceAll(s, "-", ".pre.")
+	var segs []string
+	for _, part := range strings.Split(s, ".") {
+		start := 0
+		for i := 1; i <= len(part); i++ {
+			if i == len(part) || isDigitByte(part[i]) != isDigitByte(part[i-1]) {
+				segs = append(segs, part[start:i])
+				start = i
+			}
+		}
+	}
+	for len(segs) > 0 && segs[len(segs)-1] == "0" {
+		segs = segs[:len(segs)-1]
+	}
+
+	return segs
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// mavenComparer implements Maven's ComparableVersion ordering: versions
+// tokenize on "." and "-" and at letter/digit boundaries, numeric tokens
+// outrank qualifiers, and the well-known qualifiers order
+// alpha < beta < milestone < rc < snapshot < release < sp.
+type mavenComparer struct{}
+
+// qualifierRank orders the well-known Maven qualifiers. The empty
+// qualifier is the release itself; unknown qualifiers sort after all
+// known ones, lexically among themselves.
+var qualifierRank = map[string]int{
+	"alpha":     0,
+	"a":         0,
+	"beta":      1,
+	"b":         1,
+	"milestone": 2,
+	"m":         2,
+	"rc":        3,
+	"cr":        3,
+	"snapshot":  4,
+	"":          5,
+	"final":     5,
+	"ga":        5,
+	"release":   5,
+	"sp":        6,
+}
+
+const unknownQualifierRank = 7
+
+func (mavenComparer) Compare(a, b string) int {
+	aTokens := mavenTokens(a)
+	bTokens := mavenTokens(b)
+	for i := 0; i < len(aTokens) || i < len(bTokens); i++ {
+		// The shorter version pads with the null token: 0 against numbers,
+		// the release qualifier against qualifiers.
+		av, bv := "", ""
+		if i < len(aTokens) {
+			av = aTokens[i]
+		}
+		if i < len(bTokens) {
+			bv = bTokens[i]
+		}
+		if c := compareMavenToken(av, bv); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func compareMavenToken(a, b string) int {
+	aNum, bNum := isDigits(a), isDigits(b)
+	switch {
+	case aNum && bNum:
+		return compareInt(atoi(a), atoi(b))
+	case aNum:
+		// A zero against the null padding token is the null token itself:
+		// equal, leaving later tokens (e.g. a trailing qualifier) to
+		// decide. Otherwise a number, including the 0 padding token,
+		// outranks qualifiers unless the qualifier is a post-release one
+		// (sp).
+		if atoi(a) == 0 {
+			if b == "" {
+				return 0
+			}
+			if mavenRank(b) > qualifierRank[""] {
+				return -1
+			}
+		}
+
+		return 1
+	case bNum:
+		if atoi(b) == 0 {
+			if a == "" {
+				return 0
+			}
+			if mavenRank(a) > qualifierRank[""] {
+				return 1
+			}
+		}
+
+		return -1
+	default:
+		if c := compareInt(mavenRank(a), mavenRank(b)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a, b)
+	}
+}
+
+func mavenRank(qualifier string) int {
+	if rank, ok := qualifierRank[qualifier]; ok {
+		return rank
+	}
+
+	return unknownQualifierRank
+}
+
+// mavenTokens lower-cases and splits a version on ".", "-" and
+// letter/digit transitions. Trailing null tokens (zeros and release
+// qualifiers) are trimmed so "1.0" equals "1" and "1-ga" equals "1".
+func mavenTokens(s string) []string {
+	s = strings.ToLower(s)
+	var tokens []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		boundary := i == len(s) || s[i] == '.' || s[i] == '-'
+		if !boundary && i > start {
+			prevDigit := s[i-1] >= '0' && s[i-1] <= '9'
+			curDigit := s[i] >= '0' && s[i] <= '9'
+			boundary = prevDigit != curDigit
+		}
+		if !boundary {
+			continue
+		}
+		if i > start {
+			tokens = append(tokens, s[start:i])
+		}
+		start = i
+		if i < len(s) && (s[i] == '.' || s[i] == '-') {
+			start = i + 1
+		}
+	}
+	for len(tokens) > 0 {
+		last := tokens[len(tokens)-1]
+		if (isDigits(last) && atoi(last) == 0) || mavenRank(last) == qualifierRank[""] {
+			tokens = tokens[:len(tokens)-1]
+			continue
+		}
+		break
+	}
+
+	return tokens
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// nugetComparer implements NuGet's SemVer 2.0.0 variant: up to four
+// numeric parts (the fourth being the legacy revision) and
+// case-insensitive pre-release comparison.
+type nugetComparer struct{}
+
+func (nugetComparer) Compare(a, b string) int {
+	aNums, aPre := parseSemver(strings.ToLower(a))
+	bNums, bPre := parseSemver(strings.ToLower(b))
+	if c := compareIntSlices(aNums, bNums); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(aPre, bPre)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// pep440Comparer implements PEP 440 ordering for PyPI versions:
+// epoch!release[{a|b|rc}N][.postN][.devN], with the normalization rules
+// for separator and spelling variants applied first.
+type pep440Comparer struct{}
+
+// noSegment marks an absent post/dev segment; absent post sorts before
+// any post release, absent dev sorts after any dev release.
+const noSegment = -1
+
+type pep440Version struct {
+	epoch   int
+	release []int
+	// preRank is 0 (a), 1 (b), 2 (rc) or 3 (final, no pre-release).
+	preRank int
+	preNum  int
+	post    int
+	dev     int
+}
+
+func (pep440Comparer) Compare(a, b string) int {
+	av := parsePEP440(a)
+	bv := parsePEP440(b)
+	if c := compareInt(av.epoch, bv.epoch); c != 0 {
+		return c
+	}
+	if c := compareIntSlices(av.release, bv.release); c != 0 {
+		return c
+	}
+	if c := compareInt(av.effectivePreRank(), bv.effectivePreRank()); c != 0 {
+		return c
+	}
+	if c := compareInt(av.preNum, bv.preNum); c != 0 {
+		return c
+	}
+	if c := compareInt(av.post, bv.post); c != 0 {
+		return c
+	}
+	// Dev releases sort before the corresponding non-dev release.
+	aDev, bDev := av.dev != noSegment, bv.dev != noSegment
+	switch {
+	case aDev && !bDev:
+		return -1
+	case !aDev && bDev:
+		return 1
+	default:
+		return compareInt(av.dev, bv.dev)
+	}
+}
+
+// effectivePreRank is the rank the pre-release slot compares with. A
+// bare dev release ("1.0.dev1") sorts before every pre-release of its
+// version, not with the final release its absent pre-segment would
+// otherwise rank as.
+func (v pep440Version) effectivePreRank() int {
+	if v.preRank == 3 && v.post == noSegment && v.dev != noSegment {
+		return -1
+	}
+
+	return v.preRank
+}
+
+func parsePEP440(s string) pep440Version {
+	v := pep440Version{preRank: 3, post: noSegment, dev: noSegment}
+	// Normalize: case, separator variants and the "-"/"_" spellings all
+	// collapse to the canonical dotted lower-case form.
+	s = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "v"))
+	s = strings.NewReplacer("-", ".", "_", ".").Replace(s)
+	if epoch, rest, ok := strings.Cut(s, "!"); ok && isDigits(epoch) {
+		v.epoch = atoi(epoch)
+		s = rest
+	}
+
+	parts := strings.Split(s, ".")
+	i := 0
+	for ; i < len(parts); i++ {
+		// Release segments may run directly into a pre-release marker
+		// ("1.2rc1"); split the digit prefix off.
+		digits := parts[i]
+		rest := ""
+		for j, r := range parts[i] {
+			if r < '0' || r > '9' {
+				digits, rest = parts[i][:j], parts[i][j:]
+				break
+			}
+		}
+		if digits == "" {
+			break
+		}
+		v.release = append(v.release, atoi(digits))
+		if rest != "" {
+			parts[i] = rest
+			break
+		}
+	}
+	for ; i < len(parts); i++ {
+		name, num := splitLetters(parts[i])
+		switch name {
+		case "a", "alpha":
+			v.preRank, v.preNum = 0, num
+		case "b", "beta":
+			v.preRank, v.preNum = 1, num
+		case "rc", "c", "pre", "preview":
+			v.preRank, v.preNum = 2, num
+		case "post", "r", "rev":
+			v.post = num
+		case "dev":
+			v.dev = num
+		}
+	}
+
+	return v
+}
+
+// splitLetters splits "rc1" into its letter prefix and trailing number.
+func splitLetters(s string) (string, int) {
+	for i, r := range s {
+		if r >= '0' && r <= '9' {
+			return s[:i], atoi(s[i:])
+		}
+	}
+
+	return s, 0
+}
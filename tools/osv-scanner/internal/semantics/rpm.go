@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// rpmComparer implements rpm's EVR ordering of
+// [epoch:]version[-release] strings using the rpmvercmp algorithm.
+type rpmComparer struct{}
+
+func (rpmComparer) Compare(a, b string) int {
+	aEpoch, aVer, aRel := splitEVR(a)
+	bEpoch, bVer, bRel := splitEVR(b)
+	if c := compareInt(aEpoch, bEpoch); c != 0 {
+		return c
+	}
+	if c := rpmvercmp(aVer, bVer); c != 0 {
+		return c
+	}
+
+	return rpmvercmp(aRel, bRel)
+}
+
+// rpmvercmp compares alternating alphabetic and numeric segments,
+// skipping other characters. Numeric segments outrank alphabetic ones,
+// and a "~" sorts the rest of the version before everything else.
+func rpmvercmp(a, b string) int {
+	for a != "" || b != "" {
+		aTilde := strings.HasPrefix(a, "~")
+		bTilde := strings.HasPrefix(b, "~")
+		if aTilde || bTilde {
+			if !bTilde {
+				return -1
+			}
+			if !aTilde {
+				return 1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		a = trimSeparators(a)
+		b = trimSeparators(b)
+		if a == "" || b == "" {
+			return compareInt(len(a), len(b))
+		}
+		var aSeg, bSeg string
+		var aNum bool
+		aSeg, a, aNum = rpmSegment(a)
+		bSeg, b, _ = rpmSegment(b)
+		bNum := isDigits(bSeg)
+		switch {
+		case aNum && bNum:
+			if c := compareInt(atoi(aSeg), atoi(bSeg)); c != 0 {
+				return c
+			}
+		case aNum != bNum:
+			// A numeric segment is newer than an alphabetic one.
+			if aNum {
+				return 1
+			}
+
+			return -1
+		default:
+			if c := strings.Compare(aSeg, bSeg); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// trimSeparators drops leading characters that are neither alphanumeric
+// nor "~".
+func trimSeparators(s string) string {
+	for s != "" && !isAlnum(s[0]) && s[0] != '~' {
+		s = s[1:]
+	}
+
+	return s
+}
+
+// rpmSegment takes the leading run of digits or letters off s.
+func rpmSegment(s string) (string, string, bool) {
+	numeric := s[0] >= '0' && s[0] <= '9'
+	i := 0
+	for i < len(s) {
+		digit := s[i] >= '0' && s[i] <= '9'
+		if !isAlnum(s[i]) || digit != numeric {
+			break
+		}
+		i++
+	}
+
+	return s[:i], s[i:], numeric
+}
+
+func isAlnum(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package semantics orders version strings according to the versioning
+// rules of each OSV ecosystem (SemVer, PEP 440, Maven, Debian and RPM
+// EVR, NuGet, RubyGems). It is the foundation for matching advisories
+// without the OSV API.
+package semantics
+
+import "strings"
+
+// Comparer orders two version strings of one ecosystem.
+type Comparer interface {
+	// Compare returns -1, 0 or 1 if a orders before, equal to or after b.
+	// Unparseable versions compare on a best-effort basis rather than
+	// erroring, matching how the ecosystems' own tools behave.
+	Compare(a, b string) int
+}
+
+// comparers maps OSV ecosystem names to their ordering rules.
+var comparers = map[string]Comparer{
+	"crates.io":   semverComparer{},
+	"Go":          semverComparer{},
+	"npm":         semverComparer{},
+	"Hex":         semverComparer{},
+	"PyPI":        pep440Comparer{},
+	"Maven":       mavenComparer{},
+	"Debian":      debianComparer{},
+	"Ubuntu":      debianComparer{},
+	"AlmaLinux":   rpmComparer{},
+	"Rocky Linux": rpmComparer{},
+	"Red Hat":     rpmComparer{},
+	"Fedora":      rpmComparer{},
+	"openSUSE":    rpmComparer{},
+	"SUSE":        rpmComparer{},
+	"Mageia":      rpmComparer{},
+	"NuGet":       nugetComparer{},
+	"RubyGems":    gemComparer{},
+}
+
+// ForEcosystem returns the comparer for an OSV ecosystem name, which may
+// carry a release suffix ("Debian:12"). The second return is false for
+// ecosystems without implemented ordering rules.
+func ForEcosystem(ecosystem string) (Comparer, bool) {
+	name, _, _ := strings.Cut(ecosystem, ":")
+	c, ok := comparers[name]
+
+	return c, ok
+}
+
+// compareInt is a three-way comparison of ints.
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIntSlices compares two numeric segment lists, padding the
+// shorter with zeros.
+func compareIntSlices(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var av, bv int
+		if i < len(a) {
+			av = a[i]
+		}
+		if i < len(b) {
+			bv = b[i]
+		}
+		if c := compareInt(av, bv); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// atoi parses a digit run without error handling; segments are already
+// known to be numeric.
+func atoi(s string) int {
+	n := 0
+	for _, r := range s {
+		n = n*10 + int(r-'0')
+	}
+
+	return n
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "testing"
+
+// compareCase is one ordering assertion: Compare(a, b) must return want,
+// and the mirrored comparison must return -want.
+type compareCase struct {
+	a, b string
+	want int
+}
+
+func runCompareCases(t *testing.T, ecosystem string, cases []compareCase) {
+	t.Helper()
+	cmp, ok := ForEcosystem(ecosystem)
+	if !ok {
+		t.Fatalf("ForEcosystem(%q) has no comparer", ecosystem)
+	}
+	for _, tt := range cases {
+		if got := cmp.Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: Compare(%q, %q) = %d, want %d", ecosystem, tt.a, tt.b, got, tt.want)
+		}
+		if got := cmp.Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("%s: Compare(%q, %q) = %d, want %d", ecosystem, tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	runCompareCases(t, "npm", []compareCase{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.9.0", "1.10.0", -1},
+		{"2.0.0", "10.0.0", -1},
+		// Pre-releases order before the release and among themselves.
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-beta", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		// Build metadata does not affect ordering.
+		{"1.0.0+build.1", "1.0.0", 0},
+	})
+}
+
+func TestMavenCompare(t *testing.T) {
+	runCompareCases(t, "Maven", []compareCase{
+		{"1.0", "1.0.0", 0},
+		{"1", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.2", "1.10", -1},
+		// Qualifiers order before the release they qualify...
+		{"1.0-alpha-1", "1.0-beta-1", -1},
+		{"1.0-beta-1", "1.0-rc1", -1},
+		{"1.0-rc1", "1.0-SNAPSHOT", -1},
+		{"1.0-SNAPSHOT", "1.0", -1},
+		{"1.0-alpha-1", "1.0", -1},
+		// ...except sp, which orders after.
+		{"1.0-sp1", "1.0", 1},
+		// The release aliases are the release.
+		{"1.0-ga", "1.0", 0},
+		{"1.0-final", "1.0", 0},
+		{"1.0-RELEASE", "1.0-ga", 0},
+		// Unknown qualifiers order after known ones, lexically among
+		// themselves.
+		{"1.0-xyz", "1.0-sp1", 1},
+		{"1.0-abc", "1.0-xyz", -1},
+		// Numbers outrank qualifiers at the same position.
+		{"1.0-alpha-1", "1.0-1", -1},
+		// Letter/digit boundaries tokenize: alpha1 == alpha-1.
+		{"1.0-alpha1", "1.0-alpha-1", 0},
+	})
+}
+
+func TestDebianCompare(t *testing.T) {
+	runCompareCases(t, "Debian", []compareCase{
+		{"1.0-1", "1.0-1", 0},
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-1", "1.0.1-1", -1},
+		// Epochs dominate everything after them.
+		{"1:0.9", "2.0", 1},
+		{"0:1.0", "1.0", 0},
+		// Tilde orders before anything, including the empty string.
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1-1", "1.0-1", -1},
+		// Letters order before the end of a part being reached.
+		{"1.0a", "1.0", 1},
+		{"7.88.1-10+deb12u4", "7.88.1-10", 1},
+	})
+}
+
+func TestPEP440Compare(t *testing.T) {
+	runCompareCases(t, "PyPI", []compareCase{
+		{"1.0", "1.0.0", 0},
+		{"1.0", "1.0.1", -1},
+		{"1.9", "1.10", -1},
+		// Pre-, post- and dev-releases.
+		{"1.0a1", "1.0b1", -1},
+		{"1.0b1", "1.0rc1", -1},
+		{"1.0rc1", "1.0", -1},
+		{"1.0", "1.0.post1", -1},
+		{"1.0.dev1", "1.0a1", -1},
+		{"1.0.post1.dev1", "1.0.post1", -1},
+		// Spelling variants normalize.
+		{"1.0alpha1", "1.0a1", 0},
+		{"1.0-post1", "1.0.post1", 0},
+	})
+}
+
+func TestRPMCompare(t *testing.T) {
+	runCompareCases(t, "Red Hat", []compareCase{
+		{"1.0-1", "1.0-1", 0},
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-1.el8", "1.0-1.el9", -1},
+		{"1:1.0", "2.0", 1},
+		// Segments compare numerically, not lexically.
+		{"1.9", "1.10", -1},
+		// Tilde is a pre-release marker as in Debian.
+		{"1.0~rc1", "1.0", -1},
+	})
+}
+
+func TestNuGetCompare(t *testing.T) {
+	runCompareCases(t, "NuGet", []compareCase{
+		{"1.0.0", "1.0.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.0.0-beta", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+		// Release labels compare case-insensitively.
+		{"1.0.0-BETA", "1.0.0-beta", 0},
+	})
+}
+
+func TestGemCompare(t *testing.T) {
+	runCompareCases(t, "RubyGems", []compareCase{
+		{"1.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.9", "1.10", -1},
+		// Letter segments mark pre-releases.
+		{"1.0.0.beta1", "1.0.0", -1},
+		{"1.0.0.alpha", "1.0.0.beta", -1},
+	})
+}
+
+func TestForEcosystem(t *testing.T) {
+	// Release suffixes select the base ecosystem's rules.
+	if _, ok := ForEcosystem("Debian:12"); !ok {
+		t.Errorf("ForEcosystem(\"Debian:12\") not found")
+	}
+	if _, ok := ForEcosystem("Alpine:v3.18"); ok {
+		t.Errorf("ForEcosystem(\"Alpine:v3.18\") found, but Alpine ordering is not implemented")
+	}
+	if _, ok := ForEcosystem("no-such-ecosystem"); ok {
+		t.Errorf("ForEcosystem(\"no-such-ecosystem\") found a comparer")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import "strings"
+
+// semverComparer implements SemVer 2.0.0 precedence. It also covers Go
+// module versions (same precedence with a "v" prefix) and npm versions.
+type semverComparer struct{}
+
+func (semverComparer) Compare(a, b string) int {
+	aNums, aPre := parseSemver(a)
+	bNums, bPre := parseSemver(b)
+	if c := compareIntSlices(aNums, bNums); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(aPre, bPre)
+}
+
+// parseSemver splits a version into its numeric core and pre-release
+// identifiers. Build metadata is ignored, as the spec requires.
+func parseSemver(s string) ([]int, []string) {
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+	var nums []int
+	for _, part := range strings.Split(core, ".") {
+		if !isDigits(part) {
+			break
+		}
+		nums = append(nums, atoi(part))
+	}
+	if !hasPre {
+		return nums, nil
+	}
+
+	return nums, strings.Split(pre, ".")
+}
+
+// comparePrerelease orders pre-release identifier lists: a release
+// outranks any pre-release, numeric identifiers order numerically and
+// below alphanumeric ones, and a shorter list that is a prefix of a
+// longer one orders first.
+func comparePrerelease(a, b []string) int {
+	if len(a) == 0 || len(b) == 0 {
+		// No pre-release sorts after any pre-release.
+		return compareInt(len(b), len(a))
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		av, bv := a[i], b[i]
+		aNum, bNum := isDigits(av), isDigits(bv)
+		switch {
+		case aNum && bNum:
+			if c := compareInt(atoi(av), atoi(bv)); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(av, bv); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInt(len(a), len(b))
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	servepb "github.com/google/osv/tools/osv-scanner/internal/serve/proto"
+)
+
+// serveGRPC starts the gRPC frontend on s.GRPCAddr, forwarding fatal
+// serve errors to errc, and returns a function that stops it
+// gracefully.
+func (s *Server) serveGRPC(errc chan<- error) (func(), error) {
+	lis, err := net.Listen("tcp", s.GRPCAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.GRPCAddr, err)
+	}
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.authUnary),
+		grpc.ChainStreamInterceptor(s.authStream),
+	)
+	servepb.RegisterScanServiceServer(srv, &grpcService{s: s})
+	go func() {
+		errc <- srv.Serve(lis)
+	}()
+	log.Printf("Serving gRPC scan API on %s", s.GRPCAddr)
+
+	return srv.GracefulStop, nil
+}
+
+// authUnary enforces the bearer token on unary calls, when one is
+// configured.
+func (s *Server) authUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.checkAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// authStream enforces the bearer token on streaming calls, when one is
+// configured.
+func (s *Server) authStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.checkAuth(ss.Context()); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// checkAuth validates the authorization metadata against the configured
+// token.
+func (s *Server) checkAuth(ctx context.Context) error {
+	if s.AuthToken == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if value == "Bearer "+s.AuthToken {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+}
+
+// grpcService adapts the daemon's job queue to the generated service
+// interface.
+type grpcService struct {
+	servepb.UnimplementedScanServiceServer
+	s *Server
+}
+
+// SubmitScan queues a scan and returns the ID to poll.
+func (g *grpcService) SubmitScan(ctx context.Context, req *servepb.SubmitScanRequest) (*servepb.SubmitScanResponse, error) {
+	var scanReq *ScanRequest
+	switch {
+	case req.GetFile() != nil:
+		file := req.GetFile()
+		if file.GetFilename() == "" {
+			return nil, status.Error(codes.InvalidArgument, "file upload needs a filename")
+		}
+		scanReq = &ScanRequest{Filename: file.GetFilename(), Content: file.GetContent()}
+	case req.GetImage() != nil:
+		ref := req.GetImage()
+		if ref.GetType() != "docker-archive" && ref.GetType() != "oci-dir" && ref.GetType() != "registry" {
+			return nil, status.Error(codes.InvalidArgument, "image type must be docker-archive, oci-dir or registry")
+		}
+		scanReq = &ScanRequest{Image: ref.GetReference(), ImageType: ref.GetType()}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "request names neither an upload nor an image")
+	}
+
+	job, err := g.s.submit(scanReq)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return &servepb.SubmitScanResponse{Id: job.ID}, nil
+}
+
+// GetReport returns the job's status, including the report once done.
+func (g *grpcService) GetReport(ctx context.Context, req *servepb.GetReportRequest) (*servepb.GetReportResponse, error) {
+	job, ok := g.s.get(req.GetId())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no such scan")
+	}
+
+	g.s.mu.Lock()
+	defer g.s.mu.Unlock()
+	resp := &servepb.GetReportResponse{
+		Id:     job.ID,
+		Status: job.Status,
+		Error:  job.Error,
+	}
+	if job.Status == StatusDone {
+		report, err := json.Marshal(job.Results)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode report: %v", err)
+		}
+		resp.ReportJson = report
+	}
+
+	return resp, nil
+}
+
+// StreamFindings waits for the job to finish — bounded by the client's
+// deadline — and streams one message per finding group.
+func (g *grpcService) StreamFindings(req *servepb.GetReportRequest, stream servepb.ScanService_StreamFindingsServer) error {
+	job, ok := g.s.get(req.GetId())
+	if !ok {
+		return status.Error(codes.NotFound, "no such scan")
+	}
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		g.s.mu.Lock()
+		jobStatus, jobErr := job.Status, job.Error
+		g.s.mu.Unlock()
+		if jobStatus == StatusFailed {
+			return status.Error(codes.Internal, jobErr)
+		}
+		if jobStatus == StatusDone {
+			break
+		}
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-ticker.C:
+		}
+	}
+
+	for _, source := range job.Results.Results {
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				finding := &servepb.Finding{
+					Source:    source.Source.Path,
+					Package:   pkg.Package.Name,
+					Version:   pkg.Package.Version,
+					Ecosystem: pkg.Package.Ecosystem,
+					Ids:       group.IDs,
+				}
+				if err := stream.Send(finding); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/history"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+)
+
+// rescanLoop periodically re-queries OSV for every tracked artifact so
+// newly published advisories surface without anyone resubmitting. The
+// package inventory of each artifact's latest record is reused as-is —
+// the artifact does not change between scans, only the advisory
+// database does — so a rescan is pure queries, no re-extraction.
+func (s *Server) rescanLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.RescanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.rescanAll(ctx); err != nil {
+			log.Printf("Rescan pass failed: %v", err)
+		}
+	}
+}
+
+// rescanAll re-queries every tracked artifact once. Per-artifact
+// failures are logged and the pass continues; one artifact's flaky
+// queries should not starve the others.
+func (s *Server) rescanAll(ctx context.Context) error {
+	digests, err := s.History.Digests()
+	if err != nil {
+		return err
+	}
+	log.Printf("Rescanning %d tracked artifacts", len(digests))
+	for _, digest := range digests {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.rescan(ctx, digest); err != nil {
+			log.Printf("Failed to rescan %s: %v", digest, err)
+		}
+	}
+
+	return nil
+}
+
+// rescan re-queries one artifact from its latest stored record and
+// notifies when the new record has findings its predecessor did not.
+func (s *Server) rescan(ctx context.Context, digest string) error {
+	latest, ok, err := s.History.Latest(digest)
+	if err != nil || !ok {
+		return err
+	}
+
+	var sources []scanner.ScannedSource
+	for _, source := range latest.Results.Results {
+		scanned := scanner.ScannedSource{Source: source.Source}
+		for _, pkg := range source.Packages {
+			scanned.Packages = append(scanned.Packages, pkg.Package)
+		}
+		sources = append(sources, scanned)
+	}
+
+	results, err := scanner.QuerySources(ctx, sources)
+	if err != nil {
+		return err
+	}
+	results.Skipped = latest.Results.Skipped
+	results.ComputeCounts()
+
+	record, err := s.History.Add(digest, latest.Ref, results)
+	if err != nil {
+		return err
+	}
+	if len(record.New) > 0 {
+		s.notify(latest, record)
+	}
+
+	return nil
+}
+
+// notify reports that a rescan found something new, flagging artifacts
+// that were clean until now. The default sink is the log; deployments
+// can point Notify somewhere more visible.
+func (s *Server) notify(previous, record *history.Record) {
+	wasClean := countFindings(previous.Results) == 0
+	if s.Notify != nil {
+		s.Notify(previous, record, wasClean)
+		return
+	}
+	if wasClean {
+		log.Printf("Previously clean artifact %s (%s) is now affected by %v", record.Digest, record.Ref, record.New)
+		return
+	}
+	log.Printf("Artifact %s (%s) has new findings: %v", record.Digest, record.Ref, record.New)
+}
+
+// countFindings tallies the finding groups in a report.
+func countFindings(results *models.VulnerabilityResults) int {
+	if results == nil {
+		return 0
+	}
+	n := 0
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			n += len(pkg.Groups)
+		}
+	}
+
+	return n
+}
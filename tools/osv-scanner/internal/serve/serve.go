@@ -0,0 +1,421 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package serve runs the scanner as a long-lived HTTP service. Clients
+// submit a lockfile or SBOM upload (or an image reference reachable from
+// the daemon) and poll for the JSON report; scans run on a bounded
+// worker pool so a burst of submissions queues instead of overwhelming
+// the host. How a request is actually scanned is injected by the caller,
+// keeping this package to the API and queue plumbing.
+package serve
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/history"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// maxUploadBytes bounds the size of a submitted lockfile or SBOM.
+const maxUploadBytes = 32 << 20
+
+// ScanRequest is one submitted scan target: either an uploaded file
+// (lockfile or SBOM, identified by its filename) or a container image
+// reference the daemon can reach.
+type ScanRequest struct {
+	// Filename is the client-side name of the uploaded file, which
+	// selects the extractor; Content is its bytes.
+	Filename string `json:"filename,omitempty"`
+	Content  []byte `json:"-"`
+	// Image is a docker-archive path or oci-dir reachable from the
+	// daemon, or a registry reference the daemon can pull; ImageType
+	// says which.
+	Image     string `json:"image,omitempty"`
+	ImageType string `json:"image_type,omitempty"`
+	// Platform selects one image of a multi-arch oci-dir, e.g.
+	// "linux/amd64".
+	Platform string `json:"platform,omitempty"`
+}
+
+// Job tracks one submitted scan through the queue.
+type Job struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	Submitted time.Time `json:"submitted"`
+	// Error is set when Status is "failed".
+	Error string `json:"error,omitempty"`
+	// New lists the finding groups not present in the previous scan of
+	// the same artifact, when a history store is configured.
+	New []string `json:"new_findings,omitempty"`
+	// Results is set when Status is "done".
+	Results *models.VulnerabilityResults `json:"results,omitempty"`
+
+	request *ScanRequest
+}
+
+// Job statuses, in lifecycle order.
+const (
+	StatusQueued  = "queued"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+// Server is the scan daemon. Scan must be set before Run.
+type Server struct {
+	// Addr is the listen address, e.g. ":8080".
+	Addr string
+	// GRPCAddr, when set, additionally serves the gRPC interface on that
+	// address.
+	GRPCAddr string
+	// AuthToken, when set, requires clients to present it as a bearer
+	// token on every request.
+	AuthToken string
+	// Workers is the number of concurrent scans.
+	Workers int
+	// Scan runs one submitted scan. It is called from worker goroutines
+	// and must be safe for concurrent use.
+	Scan func(ctx context.Context, req *ScanRequest) (*models.VulnerabilityResults, error)
+	// History, when set, persists every report keyed by artifact digest
+	// and computes what is new since the artifact's previous scan.
+	History *history.Store
+	// RescanInterval, when positive (and History is set), periodically
+	// re-queries OSV for every tracked artifact from its stored package
+	// inventory, without re-extracting.
+	RescanInterval time.Duration
+	// Notify, when set, is called when a rescan finds something new;
+	// wasClean reports whether the artifact had no findings before. The
+	// default is a log line.
+	Notify func(previous, record *history.Record, wasClean bool)
+
+	mu    sync.Mutex
+	jobs  map[string]*Job
+	queue chan *Job
+}
+
+// Run serves the API until ctx is cancelled, then shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	if s.Scan == nil {
+		return fmt.Errorf("serve: Scan is not set")
+	}
+	if s.Workers <= 0 {
+		s.Workers = 1
+	}
+	s.jobs = make(map[string]*Job)
+	s.queue = make(chan *Job, 64)
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.worker(ctx)
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/scans", s.requireAuth(s.handleSubmit))
+	mux.HandleFunc("GET /v1/scans/{id}", s.requireAuth(s.handleGet))
+	mux.HandleFunc("GET /v1/artifacts", s.requireAuth(s.handleArtifacts))
+	mux.HandleFunc("GET /v1/artifacts/{digest}/history", s.requireAuth(s.handleHistory))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	srv := &http.Server{Addr: s.Addr, Handler: mux}
+	errc := make(chan error, 2)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+	log.Printf("Serving scan API on %s with %d workers", s.Addr, s.Workers)
+
+	if s.RescanInterval > 0 && s.History != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.rescanLoop(ctx)
+		}()
+	}
+
+	var stopGRPC func()
+	if s.GRPCAddr != "" {
+		var err error
+		stopGRPC, err = s.serveGRPC(errc)
+		if err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := srv.Shutdown(shutdownCtx)
+		if stopGRPC != nil {
+			stopGRPC()
+		}
+		close(s.queue)
+		wg.Wait()
+
+		return err
+	case err := <-errc:
+		return err
+	}
+}
+
+// requireAuth wraps an HTTP handler with the bearer token check, when
+// one is configured.
+func (s *Server) requireAuth(h http.HandlerFunc) http.HandlerFunc {
+	if s.AuthToken == "" {
+		return h
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.AuthToken {
+			http.Error(w, "invalid or missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// worker drains the queue, running one scan at a time.
+func (s *Server) worker(ctx context.Context) {
+	for job := range s.queue {
+		if ctx.Err() != nil {
+			s.finish(job, nil, nil, ctx.Err())
+			continue
+		}
+		s.mu.Lock()
+		job.Status = StatusRunning
+		s.mu.Unlock()
+
+		digest, ref := artifactDigest(job.request)
+		results, err := s.Scan(ctx, job.request)
+		var newFindings []string
+		if err == nil && s.History != nil {
+			// History failures are logged rather than failing the scan:
+			// the report is still correct, only the diff is lost.
+			record, recordErr := s.History.Add(digest, ref, results)
+			if recordErr != nil {
+				log.Printf("Failed to record scan of %s in history: %v", ref, recordErr)
+			} else {
+				newFindings = record.New
+			}
+		}
+		s.finish(job, results, newFindings, err)
+	}
+}
+
+// finish records a job's outcome and releases its request so large
+// uploads do not live for the job's whole retention.
+func (s *Server) finish(job *Job, results *models.VulnerabilityResults, newFindings []string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.request = nil
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+
+		return
+	}
+	job.Status = StatusDone
+	job.New = newFindings
+	job.Results = results
+}
+
+// artifactDigest identifies the scanned artifact for history keying:
+// the SHA-256 of an uploaded file's content, or of an image archive's
+// content when it is a regular file, falling back to hashing the
+// reference itself (e.g. for an oci-dir).
+func artifactDigest(req *ScanRequest) (digest, ref string) {
+	if req.Image == "" {
+		sum := sha256.Sum256(req.Content)
+		return hex.EncodeToString(sum[:]), req.Filename
+	}
+	if f, err := os.Open(req.Image); err == nil {
+		defer f.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err == nil {
+			return hex.EncodeToString(h.Sum(nil)), req.Image
+		}
+	}
+	sum := sha256.Sum256([]byte(req.Image))
+
+	return hex.EncodeToString(sum[:]), req.Image
+}
+
+// handleSubmit accepts a scan submission: a multipart upload with a
+// "file" field, or a JSON body naming an image. It responds 202 with the
+// job ID to poll.
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	req, err := parseSubmission(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.submit(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"id": job.ID})
+}
+
+// handleGet reports a job's status, including the report once done.
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.get(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "no such scan", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	json.NewEncoder(w).Encode(job)
+}
+
+// handleArtifacts lists every artifact digest in the history store.
+func (s *Server) handleArtifacts(w http.ResponseWriter, r *http.Request) {
+	if s.History == nil {
+		http.Error(w, "no history store configured", http.StatusNotFound)
+		return
+	}
+	digests, err := s.History.Digests()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"digests": digests})
+}
+
+// handleHistory returns every stored scan of one artifact, oldest
+// first, including what each scan found that its predecessor did not.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if s.History == nil {
+		http.Error(w, "no history store configured", http.StatusNotFound)
+		return
+	}
+	records, err := s.History.History(r.PathValue("digest"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(records) == 0 {
+		http.Error(w, "no such artifact", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+// submit queues a scan, shared by the HTTP and gRPC frontends.
+func (s *Server) submit(req *ScanRequest) (*Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate job ID: %w", err)
+	}
+	job := &Job{
+		ID:        id,
+		Status:    StatusQueued,
+		Submitted: time.Now().UTC(),
+		request:   req,
+	}
+	s.mu.Lock()
+	s.jobs[id] = job
+	s.mu.Unlock()
+
+	select {
+	case s.queue <- job:
+	default:
+		s.mu.Lock()
+		delete(s.jobs, id)
+		s.mu.Unlock()
+
+		return nil, fmt.Errorf("scan queue is full, retry later")
+	}
+
+	return job, nil
+}
+
+// get looks up a job by ID.
+func (s *Server) get(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+
+	return job, ok
+}
+
+// parseSubmission builds a ScanRequest from an incoming submission.
+func parseSubmission(r *http.Request) (*ScanRequest, error) {
+	if mt := r.Header.Get("Content-Type"); len(mt) >= 9 && mt[:9] == "multipart" {
+		if err := r.ParseMultipartForm(maxUploadBytes); err != nil {
+			return nil, fmt.Errorf("invalid upload: %w", err)
+		}
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("missing file field: %w", err)
+		}
+		defer f.Close()
+		content, err := io.ReadAll(io.LimitReader(f, maxUploadBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+
+		return &ScanRequest{Filename: header.Filename, Content: content}, nil
+	}
+
+	var req ScanRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxUploadBytes)).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if req.Image == "" {
+		return nil, fmt.Errorf("request names neither an upload nor an image")
+	}
+	if req.ImageType != "docker-archive" && req.ImageType != "oci-dir" && req.ImageType != "registry" {
+		return nil, fmt.Errorf("image_type must be docker-archive, oci-dir or registry")
+	}
+
+	return &req, nil
+}
+
+// newID allocates a random job ID.
+func newID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b[:]), nil
+}
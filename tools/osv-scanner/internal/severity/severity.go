@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package severity normalizes the severity information of OSV records into
+// a single numeric score and qualitative level, so that filtering and
+// report sorting can treat CVSS v2/v3/v4 vectors and ecosystem-specific
+// ratings (e.g. GHSA "MODERATE") uniformly.
+package severity
+
+import (
+	"fmt"
+	"strings"
+
+	gocvss20 "github.com/pandatix/go-cvss/20"
+	gocvss30 "github.com/pandatix/go-cvss/30"
+	gocvss31 "github.com/pandatix/go-cvss/31"
+	gocvss40 "github.com/pandatix/go-cvss/40"
+
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+)
+
+// Level is a qualitative severity rating on the CVSS v3 scale.
+type Level int
+
+const (
+	LevelUnknown Level = iota
+	LevelLow
+	LevelMedium
+	LevelHigh
+	LevelCritical
+)
+
+// String implements fmt.Stringer.
+func (l Level) String() string {
+	switch l {
+	case LevelLow:
+		return "LOW"
+	case LevelMedium:
+		return "MEDIUM"
+	case LevelHigh:
+		return "HIGH"
+	case LevelCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel parses a qualitative rating, accepting the ecosystem-specific
+// spellings found in database_specific severities (e.g. GHSA's "MODERATE").
+func ParseLevel(s string) Level {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "LOW", "MINOR":
+		return LevelLow
+	case "MEDIUM", "MODERATE":
+		return LevelMedium
+	case "HIGH", "IMPORTANT", "MAJOR":
+		return LevelHigh
+	case "CRITICAL":
+		return LevelCritical
+	default:
+		return LevelUnknown
+	}
+}
+
+// FromScore converts a numeric CVSS score to its qualitative rating using
+// the CVSS v3 bands.
+func FromScore(score float64) Level {
+	switch {
+	case score >= 9.0:
+		return LevelCritical
+	case score >= 7.0:
+		return LevelHigh
+	case score >= 4.0:
+		return LevelMedium
+	case score > 0:
+		return LevelLow
+	default:
+		return LevelUnknown
+	}
+}
+
+// CalculateScore computes the base score for one severity entry of an OSV
+// record.
+func CalculateScore(severity osv.Severity) (float64, error) {
+	switch severity.Type {
+	case "CVSS_V2":
+		vec, err := gocvss20.ParseVector(severity.Score)
+		if err != nil {
+			return 0, err
+		}
+
+		return vec.BaseScore(), nil
+	case "CVSS_V3":
+		// CVSS_V3 covers both v3.0 and v3.1 vectors.
+		if strings.HasPrefix(severity.Score, "CVSS:3.0") {
+			vec, err := gocvss30.ParseVector(severity.Score)
+			if err != nil {
+				return 0, err
+			}
+
+			return vec.BaseScore(), nil
+		}
+		vec, err := gocvss31.ParseVector(severity.Score)
+		if err != nil {
+			return 0, err
+		}
+
+		return vec.BaseScore(), nil
+	case "CVSS_V4":
+		vec, err := gocvss40.ParseVector(severity.Score)
+		if err != nil {
+			return 0, err
+		}
+
+		return vec.Score(), nil
+	default:
+		return 0, fmt.Errorf("unsupported severity type: %q", severity.Type)
+	}
+}
+
+// Max returns the highest score and level across all severity entries of
+// vuln, falling back to any qualitative rating in database_specific when no
+// vector is present.
+func Max(vuln osv.Vulnerability) (float64, Level) {
+	var maxScore float64
+	level := LevelUnknown
+	for _, severity := range vuln.Severity {
+		score, err := CalculateScore(severity)
+		if err != nil {
+			continue
+		}
+		if score > maxScore {
+			maxScore = score
+		}
+	}
+	if maxScore > 0 {
+		return maxScore, FromScore(maxScore)
+	}
+	if s, ok := vuln.DatabaseSpecific["severity"].(string); ok {
+		level = ParseLevel(s)
+	}
+
+	return 0, level
+}
+
+// MaxOf returns the highest score and level across all of vulns.
+func MaxOf(vulns []osv.Vulnerability) (float64, Level) {
+	var maxScore float64
+	maxLevel := LevelUnknown
+	for _, vuln := range vulns {
+		score, level := Max(vuln)
+		if score > maxScore {
+			maxScore = score
+		}
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+
+	return maxScore, maxLevel
+}
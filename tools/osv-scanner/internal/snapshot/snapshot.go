@@ -0,0 +1,168 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot submits the packages a scan discovered to the GitHub
+// dependency submission API, so repositories scanned in CI get their
+// dependency graph — and with it Dependabot alerts — populated
+// alongside the OSV results.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/osv/go/purl"
+
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+	"github.com/google/osv/tools/osv-scanner/internal/version"
+)
+
+// Endpoint is the GitHub API base URL, a variable so tests can point
+// the client at a fake server.
+var Endpoint = "https://api.github.com"
+
+// snapshot is the dependency submission API request body.
+type snapshot struct {
+	Version   int                 `json:"version"`
+	Job       job                 `json:"job"`
+	SHA       string              `json:"sha"`
+	Ref       string              `json:"ref"`
+	Detector  detector            `json:"detector"`
+	Scanned   time.Time           `json:"scanned"`
+	Manifests map[string]manifest `json:"manifests"`
+}
+
+type job struct {
+	ID         string `json:"id"`
+	Correlator string `json:"correlator"`
+}
+
+type detector struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+type manifest struct {
+	Name     string              `json:"name"`
+	File     file                `json:"file"`
+	Resolved map[string]resolved `json:"resolved,omitempty"`
+}
+
+type file struct {
+	SourceLocation string `json:"source_location"`
+}
+
+type resolved struct {
+	PackageURL   string `json:"package_url"`
+	Relationship string `json:"relationship"`
+}
+
+// Submit converts the scanned sources into a dependency snapshot and
+// uploads it for the commit being scanned. The repository, commit and
+// credentials come from the environment GitHub Actions provides:
+// GITHUB_REPOSITORY, GITHUB_SHA, GITHUB_REF, GITHUB_JOB, GITHUB_RUN_ID
+// and GITHUB_TOKEN (the token needs contents: write).
+func Submit(ctx context.Context, sources []scanner.ScannedSource) error {
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	token := os.Getenv("GITHUB_TOKEN")
+	sha := os.Getenv("GITHUB_SHA")
+	if repo == "" || token == "" || sha == "" {
+		return fmt.Errorf("dependency snapshot submission needs GITHUB_REPOSITORY, GITHUB_SHA and GITHUB_TOKEN set")
+	}
+
+	snap := snapshot{
+		Job: job{
+			ID:         os.Getenv("GITHUB_RUN_ID"),
+			Correlator: "osv-scanner " + os.Getenv("GITHUB_JOB"),
+		},
+		SHA: sha,
+		Ref: os.Getenv("GITHUB_REF"),
+		Detector: detector{
+			Name:    "osv-scanner",
+			Version: version.OSVVersion,
+			URL:     "https://github.com/google/osv",
+		},
+		Scanned:   time.Now().UTC(),
+		Manifests: make(map[string]manifest),
+	}
+	for _, source := range sources {
+		m := manifest{
+			Name:     source.Source.Path,
+			File:     file{SourceLocation: source.Source.Path},
+			Resolved: make(map[string]resolved),
+		}
+		for _, pkg := range source.Packages {
+			purlString, ok := packageURL(pkg)
+			if !ok {
+				continue
+			}
+			m.Resolved[pkg.Name] = resolved{
+				PackageURL: purlString,
+				// Extracted lockfiles flatten the dependency tree, so
+				// direct/indirect cannot be told apart.
+				Relationship: "direct",
+			}
+		}
+		if len(m.Resolved) > 0 {
+			snap.Manifests[source.Source.Path] = m
+		}
+	}
+	if len(snap.Manifests) == 0 {
+		return fmt.Errorf("no packages suitable for a dependency snapshot were found")
+	}
+
+	body, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		Endpoint+"/repos/"+repo+"/dependency-graph/snapshots", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("dependency snapshot submission returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// packageURL renders a package as the purl the submission API
+// identifies packages by. Commits and packages with no mappable
+// ecosystem have no purl and are left out of the snapshot.
+func packageURL(pkg models.PackageInfo) (string, bool) {
+	if pkg.PURL != "" {
+		return pkg.PURL, true
+	}
+	if pkg.Commit != "" || pkg.Name == "" || pkg.Ecosystem == "" {
+		return "", false
+	}
+
+	return purl.FromOSV(pkg.Ecosystem, pkg.Name, pkg.Version).String(), true
+}
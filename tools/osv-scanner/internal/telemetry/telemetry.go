@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry provides optional OpenTelemetry instrumentation for
+// the scanner: one span per scan phase and counters for scanned packages
+// and findings, exported over OTLP. The exporter endpoint and headers are
+// taken from the standard OTEL_EXPORTER_OTLP_* environment variables.
+//
+// When Init has not been called all helpers are no-ops, so instrumented
+// code paths cost nothing for users who do not opt in.
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/google/osv/tools/osv-scanner/internal/version"
+)
+
+const scopeName = "github.com/google/osv/tools/osv-scanner"
+
+var (
+	// PackagesScanned counts packages extracted from all sources.
+	PackagesScanned metric.Int64Counter
+	// Findings counts vulnerability groups reported, after config filtering.
+	Findings metric.Int64Counter
+	// ScanFailures counts scans that ended with an error.
+	ScanFailures metric.Int64Counter
+)
+
+func init() {
+	// Bind the counters to the global (initially no-op) meter provider so
+	// they are always safe to use.
+	meter := otel.Meter(scopeName)
+	PackagesScanned, _ = meter.Int64Counter("osv_scanner.packages_scanned")
+	Findings, _ = meter.Int64Counter("osv_scanner.findings")
+	ScanFailures, _ = meter.Int64Counter("osv_scanner.scan_failures")
+}
+
+// Init configures OTLP trace and metric export and returns a shutdown
+// function that flushes both pipelines.
+func Init(ctx context.Context) (func(context.Context) error, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName("osv-scanner"),
+			semconv.ServiceVersion(version.OSVVersion),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	metricExporter, err := otlpmetricgrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(meterProvider)
+
+	// Re-bind the counters against the real provider.
+	meter := otel.Meter(scopeName)
+	PackagesScanned, _ = meter.Int64Counter("osv_scanner.packages_scanned")
+	Findings, _ = meter.Int64Counter("osv_scanner.findings")
+	ScanFailures, _ = meter.Int64Counter("osv_scanner.scan_failures")
+
+	return func(ctx context.Context) error {
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			meterProvider.Shutdown(ctx)
+			return err
+		}
+
+		return meterProvider.Shutdown(ctx)
+	}, nil
+}
+
+// StartSpan starts a span for one scan phase.
+func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(scopeName).Start(ctx, name)
+}
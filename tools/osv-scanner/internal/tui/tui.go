@@ -0,0 +1,304 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tui implements an interactive terminal explorer for scan
+// results: a findings list, a detail pane with affected ranges and
+// references, and keybindings to ignore or export findings while
+// triaging locally.
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/google/osv/tools/osv-scanner/internal/config"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+)
+
+// exportName is the file the export keybinding writes the JSON report to.
+const exportName = "osv-scanner-results.json"
+
+// finding is one row of the list: a group of aliased records affecting
+// one package in one source.
+type finding struct {
+	source  string
+	pkg     models.PackageInfo
+	group   models.GroupInfo
+	vulns   []osv.Vulnerability
+	ignored bool
+}
+
+// explorer holds the UI state.
+type explorer struct {
+	results  *models.VulnerabilityResults
+	findings []finding
+	selected int
+	scroll   int
+	status   string
+	width    int
+	height   int
+}
+
+// Run launches the explorer on the controlling terminal and blocks until
+// the user quits.
+func Run(results *models.VulnerabilityResults) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("the tui format requires an interactive terminal")
+	}
+	e := &explorer{results: results, findings: flatten(results)}
+	if len(e.findings) == 0 {
+		fmt.Println("No vulnerabilities found.")
+		return nil
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		term.Restore(fd, oldState)
+		// Leave the screen clean for the shell prompt.
+		fmt.Print("\x1b[2J\x1b[H")
+	}()
+
+	buf := make([]byte, 3)
+	for {
+		e.draw()
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return err
+		}
+		switch key(buf[:n]) {
+		case "q", "\x03":
+			return nil
+		case "j", "down":
+			if e.selected < len(e.findings)-1 {
+				e.selected++
+				e.scroll = 0
+			}
+		case "k", "up":
+			if e.selected > 0 {
+				e.selected--
+				e.scroll = 0
+			}
+		case "d":
+			e.scroll++
+		case "u":
+			if e.scroll > 0 {
+				e.scroll--
+			}
+		case "i":
+			e.ignoreSelected()
+		case "e":
+			e.export()
+		}
+	}
+}
+
+// key decodes one read from the terminal, mapping arrow key escape
+// sequences to names.
+func key(b []byte) string {
+	if len(b) == 3 && b[0] == 0x1b && b[1] == '[' {
+		switch b[2] {
+		case 'A':
+			return "up"
+		case 'B':
+			return "down"
+		}
+	}
+
+	return string(b)
+}
+
+// flatten turns the nested result model into one row per finding group.
+func flatten(results *models.VulnerabilityResults) []finding {
+	var findings []finding
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			byID := make(map[string]osv.Vulnerability, len(pkg.Vulnerabilities))
+			for _, vuln := range pkg.Vulnerabilities {
+				byID[vuln.ID] = vuln
+			}
+			for _, group := range pkg.Groups {
+				f := finding{source: source.Source.Path, pkg: pkg.Package, group: group}
+				for _, id := range group.IDs {
+					if vuln, ok := byID[id]; ok {
+						f.vulns = append(f.vulns, vuln)
+					}
+				}
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	return findings
+}
+
+// draw repaints the whole screen: findings list on top, detail pane
+// below, status bar last.
+func (e *explorer) draw() {
+	e.width, e.height, _ = term.GetSize(int(os.Stdout.Fd()))
+	if e.width <= 0 || e.height <= 0 {
+		e.width, e.height = 80, 24
+	}
+	listHeight := e.height / 2
+	detailHeight := e.height - listHeight - 1
+
+	var b strings.Builder
+	b.WriteString("\x1b[2J\x1b[H")
+
+	// Keep the selection visible within the list window.
+	top := 0
+	if e.selected >= listHeight {
+		top = e.selected - listHeight + 1
+	}
+	for i := top; i < top+listHeight && i < len(e.findings); i++ {
+		f := e.findings[i]
+		_, level := severity.MaxOf(f.vulns)
+		marker := " "
+		if f.ignored {
+			marker = "I"
+		}
+		line := fmt.Sprintf("%s %-8s %-20s %-30s %s", marker, level, f.group.IDs[0], f.pkg.Name+"@"+f.pkg.Version, f.source)
+		if i == e.selected {
+			b.WriteString("\x1b[7m" + clip(line, e.width) + "\x1b[0m\r\n")
+			continue
+		}
+		b.WriteString(clip(line, e.width) + "\r\n")
+	}
+	for i := len(e.findings); i < top+listHeight; i++ {
+		b.WriteString("\r\n")
+	}
+
+	lines := e.detailLines()
+	if e.scroll > len(lines)-1 {
+		e.scroll = len(lines) - 1
+	}
+	for i := e.scroll; i < e.scroll+detailHeight; i++ {
+		if i >= 0 && i < len(lines) {
+			b.WriteString(clip(lines[i], e.width))
+		}
+		b.WriteString("\r\n")
+	}
+
+	help := fmt.Sprintf("[%d/%d] j/k move  d/u scroll  i ignore  e export  q quit  %s", e.selected+1, len(e.findings), e.status)
+	b.WriteString("\x1b[7m" + clip(help, e.width) + "\x1b[0m")
+	os.Stdout.WriteString(b.String())
+}
+
+// detailLines renders the selected finding: summary, severity, affected
+// ranges and references.
+func (e *explorer) detailLines() []string {
+	f := e.findings[e.selected]
+	var lines []string
+	lines = append(lines, strings.Repeat("─", e.width))
+	lines = append(lines, strings.Join(f.group.IDs, ", "))
+	for _, vuln := range f.vulns {
+		if vuln.Summary != "" {
+			lines = append(lines, vuln.Summary)
+			break
+		}
+	}
+	score, level := severity.MaxOf(f.vulns)
+	if level != severity.LevelUnknown {
+		lines = append(lines, fmt.Sprintf("Severity: %s (%.1f)", level, score))
+	}
+	for _, vuln := range f.vulns {
+		for _, affected := range vuln.Affected {
+			for _, r := range affected.Ranges {
+				for _, event := range r.Events {
+					switch {
+					case event.Introduced != "":
+						lines = append(lines, fmt.Sprintf("  %s introduced %s", r.Type, event.Introduced))
+					case event.Fixed != "":
+						lines = append(lines, fmt.Sprintf("  %s fixed %s", r.Type, event.Fixed))
+					case event.LastAffected != "":
+						lines = append(lines, fmt.Sprintf("  %s last affected %s", r.Type, event.LastAffected))
+					}
+				}
+			}
+		}
+	}
+	lines = append(lines, "References:")
+	seen := make(map[string]bool)
+	for _, vuln := range f.vulns {
+		for _, ref := range vuln.References {
+			if seen[ref.URL] {
+				continue
+			}
+			seen[ref.URL] = true
+			lines = append(lines, fmt.Sprintf("  [%s] %s", ref.Type, ref.URL))
+		}
+	}
+
+	return lines
+}
+
+// ignoreSelected appends an [[IgnoredVulns]] entry for the selected
+// finding to the osv-scanner.toml in the working directory.
+func (e *explorer) ignoreSelected() {
+	f := &e.findings[e.selected]
+	if f.ignored {
+		return
+	}
+	file, err := os.OpenFile(config.ConfigName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+	defer file.Close()
+	_, err = fmt.Fprintf(file, "\n[[IgnoredVulns]]\nid = %q\nreason = \"ignored during interactive triage\"\n", f.group.IDs[0])
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+	f.ignored = true
+	e.status = fmt.Sprintf("added %s to %s", f.group.IDs[0], config.ConfigName)
+}
+
+// export writes the full JSON report next to the working directory so a
+// triage session can end in a shareable artifact.
+func (e *explorer) export() {
+	file, err := os.Create(exportName)
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(e.results); err != nil {
+		e.status = err.Error()
+		return
+	}
+	e.status = "exported to " + exportName
+}
+
+// clip truncates line to width terminal cells.
+func clip(line string, width int) string {
+	runes := []rune(line)
+	if len(runes) <= width {
+		return line
+	}
+
+	return string(runes[:width])
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package update implements the scanner's self-update command and the
+// passive new-version notice.
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/google/osv/tools/osv-scanner/internal/version"
+)
+
+const (
+	latestReleaseURL = "https://api.github.com/repos/google/osv/releases/latest"
+	downloadURL      = "https://github.com/google/osv/releases/download"
+
+	// noticeInterval limits how often normal scans check for a new
+	// release.
+	noticeInterval = 24 * time.Hour
+
+	// SkipEnv suppresses both the passive notice and the pre-update
+	// version check when set, for air-gapped or pinned environments.
+	SkipEnv = "OSV_SCANNER_SKIP_UPDATE_CHECK"
+)
+
+// LatestVersion returns the tag of the latest release, without a leading
+// "v".
+func LatestVersion() (string, error) {
+	resp, err := http.Get(latestReleaseURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("release check failed: %s", resp.Status)
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(release.TagName, "v"), nil
+}
+
+// Notify prints a passive "new version available" notice to w if a newer
+// release exists. It checks at most once per noticeInterval and never
+// returns an error: the notice is best effort and must not fail a scan.
+func Notify(w io.Writer) {
+	if os.Getenv(SkipEnv) != "" {
+		return
+	}
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return
+	}
+	stamp := filepath.Join(userCache, "osv-scanner", "last_update_check")
+	if info, err := os.Stat(stamp); err == nil && time.Since(info.ModTime()) < noticeInterval {
+		return
+	}
+	os.MkdirAll(filepath.Dir(stamp), 0755)
+	os.WriteFile(stamp, nil, 0644)
+
+	latest, err := LatestVersion()
+	if err != nil || latest == "" || latest == version.OSVVersion {
+		return
+	}
+	fmt.Fprintf(w, "A new version of osv-scanner is available: %s (running %s). Run `osv-scanner update` to update.\n",
+		latest, version.OSVVersion)
+}
+
+// SelfUpdate replaces the running binary with the latest release after
+// verifying its SHA-256 against the release's checksums.txt.
+func SelfUpdate(w io.Writer) error {
+	latest, err := LatestVersion()
+	if err != nil {
+		return err
+	}
+	if latest == version.OSVVersion {
+		fmt.Fprintf(w, "osv-scanner is up to date (%s)\n", version.OSVVersion)
+		return nil
+	}
+
+	asset := fmt.Sprintf("osv-scanner_%s_%s_%s", latest, runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		asset += ".exe"
+	}
+	base := downloadURL + "/v" + latest + "/"
+
+	fmt.Fprintf(w, "Downloading osv-scanner %s...\n", latest)
+	binary, err := fetch(base + asset)
+	if err != nil {
+		return err
+	}
+	checksums, err := fetch(base + "checksums.txt")
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(binary, asset, checksums); err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return err
+	}
+	// Write next to the target and rename so the replacement is atomic and
+	// never crosses filesystems.
+	tmp, err := os.CreateTemp(filepath.Dir(executable), ".osv-scanner-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0755); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), executable); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Updated %s to %s\n", executable, latest)
+
+	return nil
+}
+
+func fetch(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// verifyChecksum checks data against the entry for name in a
+// sha256sum-format checksums file.
+func verifyChecksum(data []byte, name string, checksums []byte) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		if fields[0] != got {
+			return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, fields[0])
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no checksum entry for %s", name)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wordpress extracts installed WordPress plugins and themes from
+// their standard header comments, so hosting providers can scan customer
+// sites. Plugins are identified by their directory slug, the identifier
+// used by the WordPress ecosystem in OSV.
+package wordpress
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/tools/osv-scanner/internal/extractor"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+)
+
+// headerLimit bounds how much of a file is searched for the header
+// comment; WordPress itself reads the first 8 KiB.
+const headerLimit = 8 << 10
+
+type wordpressExtractor struct{}
+
+func (wordpressExtractor) Name() string {
+	return "wordpress/plugin"
+}
+
+// Detect matches the files that can carry a plugin or theme header:
+// top-level .php files of a wp-content/plugins subdirectory and the
+// style.css of a wp-content/themes subdirectory.
+func (wordpressExtractor) Detect(path string) bool {
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if strings.HasSuffix(filepath.Dir(dir), "wp-content/plugins") {
+		return filepath.Ext(path) == ".php"
+	}
+	if strings.HasSuffix(filepath.Dir(dir), "wp-content/themes") {
+		return filepath.Base(path) == "style.css"
+	}
+
+	return false
+}
+
+func (wordpressExtractor) Extract(path string, r io.Reader) ([]models.PackageInfo, error) {
+	headers := parseHeaders(io.LimitReader(r, headerLimit))
+	version := headers["Version"]
+	if version == "" {
+		return nil, nil
+	}
+	if headers["Plugin Name"] == "" && headers["Theme Name"] == "" {
+		return nil, nil
+	}
+
+	// The directory slug identifies the plugin/theme in vulnerability
+	// databases, not the display name.
+	slug := filepath.Base(filepath.Dir(path))
+
+	return []models.PackageInfo{{
+		Name:      slug,
+		Version:   version,
+		Ecosystem: "WordPress",
+	}}, nil
+}
+
+// parseHeaders reads "Key: Value" pairs from a WordPress file header
+// comment.
+func parseHeaders(r io.Reader) map[string]string {
+	headers := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimLeft(scanner.Text(), " \t*/#"))
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		switch key {
+		case "Plugin Name", "Theme Name", "Version":
+			if _, exists := headers[key]; !exists {
+				headers[key] = strings.TrimSpace(strings.TrimSuffix(value, "*/"))
+			}
+		}
+	}
+
+	return headers
+}
+
+func init() {
+	extractor.Register(wordpressExtractor{})
+}
@@ -0,0 +1,299 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workspace detects monorepo workspace layouts — npm/yarn and
+// pnpm workspaces, Go workspaces (go.work) and Cargo workspaces — so a
+// scan can attribute findings to the member project they belong to and
+// be scoped to a subset of members.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Member is one project inside a workspace.
+type Member struct {
+	// Name is the member's declared name (package.json name, Cargo
+	// package name), falling back to its directory relative to the
+	// workspace root.
+	Name string
+	// Dir is the member's directory, relative to the workspace root.
+	Dir string
+}
+
+// Workspace is a detected monorepo layout.
+type Workspace struct {
+	// Root is the directory the workspace manifest lives in.
+	Root string
+	// Type is "npm", "pnpm", "go" or "cargo".
+	Type    string
+	Members []Member
+}
+
+// Detect looks for a workspace manifest in dir. The first recognized
+// manifest wins; mixed-toolchain monorepos are rare enough that the
+// simple rule beats merging.
+func Detect(dir string) (*Workspace, bool) {
+	for _, probe := range []func(string) (*Workspace, bool){
+		detectNpm, detectPnpm, detectGo, detectCargo,
+	} {
+		if ws, ok := probe(dir); ok {
+			sort.Slice(ws.Members, func(i, j int) bool { return ws.Members[i].Dir < ws.Members[j].Dir })
+			return ws, true
+		}
+	}
+
+	return nil, false
+}
+
+// MemberFor returns the member whose directory contains path, matching
+// the longest member directory so nested members attribute correctly.
+func (ws *Workspace) MemberFor(path string) (Member, bool) {
+	rel, err := filepath.Rel(ws.Root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return Member{}, false
+	}
+	var best Member
+	found := false
+	for _, member := range ws.Members {
+		if rel == member.Dir || strings.HasPrefix(rel, member.Dir+string(filepath.Separator)) {
+			if !found || len(member.Dir) > len(best.Dir) {
+				best = member
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// Select returns the members matching the requested names, erroring on
+// names that match nothing so typos fail loudly instead of silently
+// scanning nothing.
+func (ws *Workspace) Select(names []string) ([]Member, error) {
+	byName := make(map[string]Member, len(ws.Members))
+	for _, member := range ws.Members {
+		byName[member.Name] = member
+		byName[member.Dir] = member
+	}
+	var selected []Member
+	for _, name := range names {
+		member, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("workspace %s has no member %q", ws.Root, name)
+		}
+		selected = append(selected, member)
+	}
+
+	return selected, nil
+}
+
+// expand resolves member patterns (which may contain globs like
+// "packages/*") to existing directories relative to root.
+func expand(root string, patterns []string) []string {
+	var dirs []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			if rel, err := filepath.Rel(root, match); err == nil {
+				dirs = append(dirs, rel)
+			}
+		}
+	}
+
+	return dirs
+}
+
+// detectNpm reads the workspaces field of package.json, covering both
+// npm and yarn (an array, or an object with a packages array).
+func detectNpm(dir string) (*Workspace, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, false
+	}
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil || len(manifest.Workspaces) == 0 {
+		return nil, false
+	}
+	var patterns []string
+	if err := json.Unmarshal(manifest.Workspaces, &patterns); err != nil {
+		var object struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(manifest.Workspaces, &object); err != nil {
+			return nil, false
+		}
+		patterns = object.Packages
+	}
+
+	ws := &Workspace{Root: dir, Type: "npm"}
+	for _, memberDir := range expand(dir, patterns) {
+		ws.Members = append(ws.Members, Member{
+			Name: npmName(filepath.Join(dir, memberDir, "package.json"), memberDir),
+			Dir:  memberDir,
+		})
+	}
+
+	return ws, len(ws.Members) > 0
+}
+
+// npmName reads a member's declared package name, falling back to its
+// directory.
+func npmName(manifestPath, fallback string) string {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fallback
+	}
+	var manifest struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Name == "" {
+		return fallback
+	}
+
+	return manifest.Name
+}
+
+// detectPnpm reads pnpm-workspace.yaml. The file is a flat list under a
+// "packages:" key; rather than pulling in a YAML dependency for that,
+// the entries are read line by line.
+func detectPnpm(dir string) (*Workspace, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "pnpm-workspace.yaml"))
+	if err != nil {
+		return nil, false
+	}
+	var patterns []string
+	inPackages := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "packages:":
+			inPackages = true
+		case inPackages && strings.HasPrefix(trimmed, "- "):
+			pattern := strings.Trim(strings.TrimPrefix(trimmed, "- "), `"'`)
+			if pattern != "" && !strings.HasPrefix(pattern, "!") {
+				patterns = append(patterns, pattern)
+			}
+		case trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(line, " "):
+			inPackages = false
+		}
+	}
+
+	ws := &Workspace{Root: dir, Type: "pnpm"}
+	for _, memberDir := range expand(dir, patterns) {
+		ws.Members = append(ws.Members, Member{
+			Name: npmName(filepath.Join(dir, memberDir, "package.json"), memberDir),
+			Dir:  memberDir,
+		})
+	}
+
+	return ws, len(ws.Members) > 0
+}
+
+// detectGo reads the use directives of go.work.
+func detectGo(dir string) (*Workspace, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.work"))
+	if err != nil {
+		return nil, false
+	}
+	var dirs []string
+	inUse := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if i := strings.Index(trimmed, "//"); i >= 0 {
+			trimmed = strings.TrimSpace(trimmed[:i])
+		}
+		switch {
+		case trimmed == "use (":
+			inUse = true
+		case inUse && trimmed == ")":
+			inUse = false
+		case inUse && trimmed != "":
+			dirs = append(dirs, trimmed)
+		case strings.HasPrefix(trimmed, "use "):
+			dirs = append(dirs, strings.TrimSpace(strings.TrimPrefix(trimmed, "use ")))
+		}
+	}
+
+	ws := &Workspace{Root: dir, Type: "go"}
+	for _, use := range dirs {
+		memberDir := filepath.Clean(filepath.FromSlash(use))
+		ws.Members = append(ws.Members, Member{Name: filepath.ToSlash(memberDir), Dir: memberDir})
+	}
+
+	return ws, len(ws.Members) > 0
+}
+
+// detectCargo reads the [workspace] members of Cargo.toml.
+func detectCargo(dir string) (*Workspace, bool) {
+	var manifest struct {
+		Workspace struct {
+			Members []string `toml:"members"`
+			Exclude []string `toml:"exclude"`
+		} `toml:"workspace"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(dir, "Cargo.toml"), &manifest); err != nil {
+		return nil, false
+	}
+	if len(manifest.Workspace.Members) == 0 {
+		return nil, false
+	}
+	excluded := make(map[string]bool)
+	for _, dir := range expand(dir, manifest.Workspace.Exclude) {
+		excluded[dir] = true
+	}
+
+	ws := &Workspace{Root: dir, Type: "cargo"}
+	for _, memberDir := range expand(dir, manifest.Workspace.Members) {
+		if excluded[memberDir] {
+			continue
+		}
+		ws.Members = append(ws.Members, Member{
+			Name: cargoName(filepath.Join(dir, memberDir, "Cargo.toml"), memberDir),
+			Dir:  memberDir,
+		})
+	}
+
+	return ws, len(ws.Members) > 0
+}
+
+// cargoName reads a member's declared package name, falling back to its
+// directory.
+func cargoName(manifestPath, fallback string) string {
+	var manifest struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+	}
+	if _, err := toml.DecodeFile(manifestPath, &manifest); err != nil || manifest.Package.Name == "" {
+		return fallback
+	}
+
+	return manifest.Package.Name
+}
@@ -0,0 +1,1457 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// osv-scanner scans dependencies and checks them against the OSV database
+// for known vulnerabilities.
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/google/osv/go/purl"
+
+	"github.com/google/osv/tools/osv-scanner/internal/attest"
+	"github.com/google/osv/tools/osv-scanner/internal/badge"
+	"github.com/google/osv/tools/osv-scanner/internal/bench"
+	"github.com/google/osv/tools/osv-scanner/internal/config"
+	"github.com/google/osv/tools/osv-scanner/internal/depsdev"
+	"github.com/google/osv/tools/osv-scanner/internal/enrich"
+	"github.com/google/osv/tools/osv-scanner/internal/extractor"
+	"github.com/google/osv/tools/osv-scanner/internal/gitrange"
+	"github.com/google/osv/tools/osv-scanner/internal/gobinary"
+	"github.com/google/osv/tools/osv-scanner/internal/grouper"
+	"github.com/google/osv/tools/osv-scanner/internal/history"
+	"github.com/google/osv/tools/osv-scanner/internal/image"
+	// Register the built-in extractors.
+	_ "github.com/google/osv/tools/osv-scanner/internal/javaarchive"
+	_ "github.com/google/osv/tools/osv-scanner/internal/lockfile"
+	_ "github.com/google/osv/tools/osv-scanner/internal/wordpress"
+
+	"github.com/google/osv/tools/osv-scanner/internal/localmatcher"
+	"github.com/google/osv/tools/osv-scanner/internal/models"
+	"github.com/google/osv/tools/osv-scanner/internal/notify"
+	"github.com/google/osv/tools/osv-scanner/internal/osv"
+	"github.com/google/osv/tools/osv-scanner/internal/output"
+	"github.com/google/osv/tools/osv-scanner/internal/policy"
+	"github.com/google/osv/tools/osv-scanner/internal/provenance"
+	"github.com/google/osv/tools/osv-scanner/internal/reconcile"
+	"github.com/google/osv/tools/osv-scanner/internal/resolve"
+	"github.com/google/osv/tools/osv-scanner/internal/resultstore"
+	"github.com/google/osv/tools/osv-scanner/internal/rustbinary"
+	"github.com/google/osv/tools/osv-scanner/internal/scanner"
+	"github.com/google/osv/tools/osv-scanner/internal/serve"
+	"github.com/google/osv/tools/osv-scanner/internal/severity"
+	"github.com/google/osv/tools/osv-scanner/internal/snapshot"
+	"github.com/google/osv/tools/osv-scanner/internal/telemetry"
+	"github.com/google/osv/tools/osv-scanner/internal/update"
+	"github.com/google/osv/tools/osv-scanner/internal/version"
+	"github.com/google/osv/tools/osv-scanner/internal/workspace"
+)
+
+func main() {
+	app := &cli.App{
+		Name:    "osv-scanner",
+		Usage:   "scans dependencies for known vulnerabilities using the OSV database",
+		Version: version.OSVVersion,
+		Commands: []*cli.Command{
+			{
+				Name:  "update",
+				Usage: "update osv-scanner to the latest release",
+				Action: func(c *cli.Context) error {
+					return update.SelfUpdate(os.Stdout)
+				},
+			},
+			{
+				Name:  "query",
+				Usage: "look up advisories for a single package version or commit, without building a lockfile",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "package",
+						Usage: "name of the package to look up (requires --version)",
+					},
+					&cli.StringFlag{
+						Name:  "version",
+						Usage: "version of the package to look up",
+					},
+					&cli.StringFlag{
+						Name:  "ecosystem",
+						Usage: "ecosystem of the package (e.g. npm, PyPI, Go)",
+					},
+					&cli.StringFlag{
+						Name:  "commit",
+						Usage: "git commit hash to look up instead of a package",
+					},
+				},
+				Action: runQuery,
+			},
+			{
+				Name:  "serve",
+				Usage: "run as a daemon exposing an HTTP API: submit a lockfile, SBOM or image reference and poll for the JSON report",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "address",
+						Usage: "listen `ADDRESS` for the HTTP API",
+						Value: ":8080",
+					},
+					&cli.StringFlag{
+						Name:  "grpc-address",
+						Usage: "additionally listen on `ADDRESS` for the gRPC API",
+					},
+					&cli.StringFlag{
+						Name:  "auth-token",
+						Usage: "require clients to present `TOKEN` as a bearer token",
+					},
+					&cli.IntFlag{
+						Name:  "workers",
+						Usage: "number of scans to run concurrently",
+						Value: 2,
+					},
+					&cli.StringFlag{
+						Name:  "history",
+						Usage: "persist every report in the history database at `PATH`, keyed by artifact digest, and report what is new since each artifact's previous scan",
+					},
+					&cli.DurationFlag{
+						Name:  "rescan-interval",
+						Usage: "re-query OSV for every tracked artifact every `DURATION` (e.g. 6h) and flag newly affected artifacts; requires --history",
+					},
+					&cli.StringFlag{
+						Name:  "notify",
+						Usage: "deliver new-finding alerts to the sinks (Slack, webhook, email) declared in the TOML file at `PATH`",
+					},
+				},
+				Action: runServe,
+			},
+			{
+				Name:  "internal",
+				Usage: "commands for working on the scanner itself",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "bench",
+						Usage: "time extraction and querying over a synthetic workload against an in-process fake API, with optional pprof profiles",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "lockfiles",
+								Usage: "number of synthetic lockfiles to generate",
+								Value: 100,
+							},
+							&cli.IntFlag{
+								Name:  "packages",
+								Usage: "number of packages per lockfile",
+								Value: 200,
+							},
+							&cli.StringFlag{
+								Name:  "cpuprofile",
+								Usage: "write a CPU profile to `PATH`",
+							},
+							&cli.StringFlag{
+								Name:  "memprofile",
+								Usage: "write a heap profile to `PATH`",
+							},
+						},
+						Action: runBench,
+					},
+				},
+			},
+			{
+				Name:      "coverage",
+				Usage:     "extract packages without querying and report which of them OSV has no coverage for, so an empty scan result can be told apart from a blind spot",
+				ArgsUsage: "[directory...]",
+				Action:    runCoverage,
+			},
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "recursive",
+				Usage: "check subdirectories",
+			},
+			&cli.IntFlag{
+				Name:  "max-depth",
+				Usage: "descend at most this many directory levels when scanning recursively (0 for no limit)",
+			},
+			&cli.BoolFlag{
+				Name:  "one-filesystem",
+				Usage: "don't cross filesystem boundaries when scanning recursively",
+			},
+			&cli.BoolFlag{
+				Name:  "follow-symlinks",
+				Usage: "follow symbolic links when scanning directories",
+			},
+			&cli.StringSliceFlag{
+				Name:  "workspace",
+				Usage: "scope the scan to the workspace member named `MEMBER` (npm/yarn/pnpm, go.work and Cargo workspaces are detected); repeatable",
+			},
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "print details of applied config files and ignored findings",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "abort the scan after `DURATION` (e.g. 10m); 0 means no timeout",
+			},
+			&cli.BoolFlag{
+				Name:  "telemetry",
+				Usage: "export OpenTelemetry traces and metrics via OTLP (configured through OTEL_EXPORTER_OTLP_* environment variables)",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "output results in JSON format (shorthand for --output -:json)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "output",
+				Usage: "write results to `PATH[:FORMAT]` (\"-\" for stdout); repeatable to produce several formats from one scan",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "print results to stdout in `FORMAT` (shorthand for --output -:FORMAT); \"tui\" opens an interactive explorer",
+			},
+			&cli.StringFlag{
+				Name:  "template",
+				Usage: "render results through the Go template file at `PATH` (used with --format template)",
+			},
+			&cli.StringFlag{
+				Name:  "store",
+				Usage: "write results to the result store database at `PATH` instead of holding them in memory",
+			},
+			&cli.StringSliceFlag{
+				Name:  "binary",
+				Usage: "scan the module dependencies embedded in the compiled Go binary at `PATH`",
+			},
+			&cli.StringSliceFlag{
+				Name:  "provenance",
+				Usage: "query the resolved dependencies listed in the SLSA provenance attestation at `PATH` (DSSE envelope or bare in-toto statement)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "hash-file",
+				Usage: "hash the arbitrary file at `PATH` (e.g. a downloaded .so or .tar.gz) and match it against artifact hashes listed in advisories; requires --local-db",
+			},
+			&cli.StringSliceFlag{
+				Name:  "dir-version",
+				Usage: "identify the vendored C/C++ library at `PATH` via the experimental determineversion API and query the best match",
+			},
+			&cli.StringSliceFlag{
+				Name:  "docker-archive",
+				Usage: "scan the image in a docker-save tarball at `PATH`",
+			},
+			&cli.StringSliceFlag{
+				Name:  "oci-dir",
+				Usage: "scan the image in an OCI image layout directory at `PATH`",
+			},
+			&cli.StringSliceFlag{
+				Name:  "image",
+				Usage: "pull and scan the image at `REF` (e.g. ghcr.io/org/app:v1) directly from its registry; credentials come from --registry-username, docker config.json or its credential helpers",
+			},
+			&cli.StringFlag{
+				Name:  "registry-username",
+				Usage: "username presented to registries when pulling --image (requires --registry-password)",
+			},
+			&cli.StringFlag{
+				Name:  "registry-password",
+				Usage: "password or token for --registry-username",
+			},
+			&cli.StringFlag{
+				Name:  "platform",
+				Usage: "scan only the `OS/ARCH` (e.g. linux/amd64) image of a multi-arch OCI layout; without it, every platform is scanned and reported separately",
+			},
+			&cli.StringSliceFlag{
+				Name:  "rootfs",
+				Usage: "scan the mounted or exported root filesystem at `PATH` (e.g. /mnt/image) with the same OS-package and language-package detection as an image scan",
+			},
+			&cli.BoolFlag{
+				Name:  "experimental-resolve",
+				Usage: "resolve transitive dependencies of manifests without lockfiles (pom.xml, package.json) via the deps.dev API",
+			},
+			&cli.BoolFlag{
+				Name:  "enrich",
+				Usage: "annotate findings with FIRST EPSS scores and CISA KEV catalog membership",
+			},
+			&cli.BoolFlag{
+				Name:  "depsdev",
+				Usage: "annotate vulnerable packages with deps.dev data: dependent counts, OpenSSF Scorecard scores and latest versions",
+			},
+			&cli.StringFlag{
+				Name:  "fail-on",
+				Usage: "exit non-zero if any finding matches: \"kev\" or a severity level (LOW, MEDIUM, HIGH, CRITICAL)",
+			},
+			&cli.StringFlag{
+				Name:  "policy",
+				Usage: "evaluate the CEL gating rules in the policy file at `PATH` against the report and exit non-zero on any violation",
+			},
+			&cli.StringFlag{
+				Name:  "attest",
+				Usage: "wrap the JSON report in an in-toto statement, sign it keylessly via Sigstore and write the bundle to `PATH`",
+			},
+			&cli.BoolFlag{
+				Name:  "github-snapshot",
+				Usage: "submit the discovered packages to the GitHub dependency submission API (reads GITHUB_REPOSITORY, GITHUB_SHA and GITHUB_TOKEN), populating the repository's dependency graph and Dependabot alerts",
+			},
+			&cli.StringFlag{
+				Name:  "emit-badge",
+				Usage: "write a status badge summarizing findings by severity to `PATH` (.svg, or .json for the shields.io endpoint schema)",
+			},
+			&cli.StringFlag{
+				Name:  "local-db",
+				Usage: "answer queries from the OSV advisories at `PATH` (a directory or .zip export) instead of the OSV API",
+			},
+			&cli.StringSliceFlag{
+				Name:  "header",
+				Usage: "add `\"NAME: VALUE\"` as a header on every OSV API request (e.g. auth tokens for corporate gateways); repeatable",
+			},
+			&cli.StringFlag{
+				Name:  "client-cert",
+				Usage: "present the PEM-encoded mTLS client certificate at `PATH` on OSV API requests (requires --client-key)",
+			},
+			&cli.StringFlag{
+				Name:  "client-key",
+				Usage: "PEM-encoded private key at `PATH` for --client-cert",
+			},
+			&cli.IntFlag{
+				Name:  "max-conns-per-host",
+				Usage: "cap concurrent connections to each OSV API host (0 for no cap)",
+			},
+			&cli.IntFlag{
+				Name:  "max-idle-conns",
+				Usage: "size of the connection pool kept for reuse across OSV API requests",
+			},
+			&cli.Float64Flag{
+				Name:  "requests-per-second",
+				Usage: "per-host rate limit on OSV API requests, to stay under API quotas (0 for unlimited)",
+			},
+			&cli.IntFlag{
+				Name:  "hydration-workers",
+				Usage: "number of record fetches issued concurrently when hydrating results",
+				Value: 4,
+			},
+			&cli.BoolFlag{
+				Name:  "layer-cache",
+				Usage: "keep extracted image layers on disk (under the user cache directory) keyed by digest, so images sharing base layers are not re-extracted on every scan",
+			},
+			&cli.BoolFlag{
+				Name:  "cache",
+				Usage: "cache individual record fetches on disk (under the user cache directory) and revalidate them with conditional requests, making hydration nearly free on repeat runs",
+			},
+			&cli.StringFlag{
+				Name:  "timezone",
+				Usage: "IANA timezone name (e.g. \"America/New_York\") used for timestamps in human-readable output; defaults to the local timezone",
+			},
+		},
+		ArgsUsage: "[directory...]",
+		Action:    run,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(c *cli.Context) error {
+	// Interrupt signals and --timeout cancel the whole scan: directory
+	// walks, extraction and all in-flight API requests.
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := configureTransport(c); err != nil {
+		return err
+	}
+	if err := configureCaches(c); err != nil {
+		return err
+	}
+
+	if c.Bool("telemetry") {
+		shutdown, err := telemetry.Init(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to initialize telemetry: %w", err)
+		}
+		defer shutdown(context.Background())
+	}
+
+	if err := scan(ctx, c); err != nil {
+		telemetry.ScanFailures.Add(ctx, 1)
+		return err
+	}
+
+	return nil
+}
+
+// configureTransport applies the flags shaping OSV API traffic —
+// headers, the mTLS client certificate, connection pool limits, the
+// rate limit and hydration concurrency — to the API client.
+func configureTransport(c *cli.Context) error {
+	for _, header := range c.StringSlice("header") {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return fmt.Errorf("invalid --header value: %q", header)
+		}
+		osv.AddHeader(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	if cert := c.String("client-cert"); cert != "" {
+		key := c.String("client-key")
+		if key == "" {
+			return errors.New("--client-cert requires --client-key")
+		}
+		if err := osv.UseClientCertificate(cert, key); err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+	}
+	if c.Int("max-conns-per-host") > 0 || c.Int("max-idle-conns") > 0 {
+		osv.SetConnectionLimits(c.Int("max-conns-per-host"), c.Int("max-idle-conns"))
+	}
+	if rps := c.Float64("requests-per-second"); rps > 0 {
+		osv.SetRateLimit(rps)
+	}
+	if workers := c.Int("hydration-workers"); workers > 0 {
+		osv.HydrationWorkers = workers
+	}
+	// Enabled last so the cache wraps whatever transport the flags above
+	// configured.
+	if c.Bool("cache") {
+		if err := osv.EnableCache(""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configureCaches applies the image acquisition flags — the layer cache
+// and explicit registry credentials — for the commands that extract
+// images.
+func configureCaches(c *cli.Context) error {
+	if c.Bool("layer-cache") {
+		if err := image.EnableLayerCache(""); err != nil {
+			return err
+		}
+	}
+	if username := c.String("registry-username"); username != "" {
+		if c.String("registry-password") == "" {
+			return errors.New("--registry-username requires --registry-password")
+		}
+		image.RegistryUsername = username
+		image.RegistryPassword = c.String("registry-password")
+	}
+
+	return nil
+}
+
+// walkOptions collects the directory-walk flags into the options
+// scanner.ScanDirWithOptions takes.
+func walkOptions(c *cli.Context) scanner.WalkOptions {
+	return scanner.WalkOptions{
+		Recursive:      c.Bool("recursive"),
+		MaxDepth:       c.Int("max-depth"),
+		OneFilesystem:  c.Bool("one-filesystem"),
+		FollowSymlinks: c.Bool("follow-symlinks"),
+	}
+}
+
+// runQuery handles the query subcommand: one ad-hoc lookup of a package
+// version or commit, reported through the same output pipeline as a
+// scan.
+func runQuery(c *cli.Context) error {
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := configureTransport(c); err != nil {
+		return err
+	}
+
+	commit := c.String("commit")
+	name := c.String("package")
+	switch {
+	case commit != "" && name != "":
+		return errors.New("--commit and --package are mutually exclusive")
+	case commit == "" && name == "":
+		return errors.New("query requires --package or --commit")
+	case name != "" && c.String("version") == "":
+		return errors.New("--package requires --version")
+	}
+	query := &osv.Query{Commit: commit}
+	info := models.PackageInfo{Commit: commit}
+	source := commit
+	if name != "" {
+		query.Package = osv.Package{Name: name, Ecosystem: c.String("ecosystem")}
+		query.Version = c.String("version")
+		info = models.PackageInfo{Name: name, Version: query.Version, Ecosystem: c.String("ecosystem")}
+		source = name + "@" + query.Version
+	}
+
+	resp, err := osv.QueryAffected(ctx, query)
+	if err != nil {
+		return err
+	}
+	results := &models.VulnerabilityResults{Date: time.Now().UTC()}
+	if len(resp.Vulns) > 0 {
+		results.Results = []models.PackageSource{{
+			Source: models.SourceInfo{Path: source, Type: "query"},
+			Packages: []models.PackageVulns{{
+				Package:         info,
+				Vulnerabilities: resp.Vulns,
+				Groups:          grouper.Group(resp.Vulns),
+			}},
+		}}
+	}
+	failOn := c.String("fail-on")
+	if c.Bool("enrich") || failOn == "kev" {
+		if err := enrich.Enrich(ctx, results, ""); err != nil {
+			return err
+		}
+	}
+	if c.Bool("depsdev") {
+		if err := depsdev.Annotate(ctx, results); err != nil {
+			return err
+		}
+	}
+	results.Manifest = buildManifest(c, nil, results)
+	if err := writeResults(c, results); err != nil {
+		return err
+	}
+
+	return failIfMatched(results, failOn)
+}
+
+// runBench handles the internal bench subcommand: a reproducible
+// performance measurement of the extraction and query phases, so
+// regressions are caught release to release rather than reported from
+// the field.
+func runBench(c *cli.Context) error {
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return bench.Run(ctx, bench.Options{
+		Lockfiles:  c.Int("lockfiles"),
+		Packages:   c.Int("packages"),
+		CPUProfile: c.String("cpuprofile"),
+		MemProfile: c.String("memprofile"),
+	}, os.Stdout)
+}
+
+// runCoverage handles the coverage subcommand: the extraction half of a
+// scan, followed by a report of which discovered packages belong to
+// ecosystems OSV has records for. Packages in uncovered ecosystems, or
+// that no ecosystem could be determined for, would silently come back
+// with no results from a scan.
+func runCoverage(c *cli.Context) error {
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := configureCaches(c); err != nil {
+		return err
+	}
+
+	dirs := c.Args().Slice()
+	if len(dirs) == 0 && len(c.StringSlice("binary")) == 0 &&
+		len(c.StringSlice("docker-archive")) == 0 && len(c.StringSlice("oci-dir")) == 0 &&
+		len(c.StringSlice("image")) == 0 {
+		dirs = []string{"."}
+	}
+	var sources []scanner.ScannedSource
+	for _, dir := range dirs {
+		// Coverage only reports on what was extracted; unreadable paths
+		// are already logged by the walk.
+		dirSources, _, err := scanner.ScanDirWithOptions(ctx, dir, walkOptions(c))
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+		sources = append(sources, dirSources...)
+	}
+	for _, binary := range c.StringSlice("binary") {
+		source, err := gobinary.Scan(binary)
+		if err != nil {
+			var rustErr error
+			source, rustErr = rustbinary.Scan(binary)
+			if rustErr != nil {
+				return fmt.Errorf("%s: %w", binary, errors.Join(err, rustErr))
+			}
+		}
+		sources = append(sources, source)
+	}
+	for _, archive := range c.StringSlice("docker-archive") {
+		imageSources, _, err := scanImage(ctx, archive, archive, "docker-archive", image.ExtractDockerArchive)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, imageSources...)
+	}
+	for _, layout := range c.StringSlice("oci-dir") {
+		extracts, err := ociExtracts(c.String("platform"), layout)
+		if err != nil {
+			return err
+		}
+		for _, e := range extracts {
+			imageSources, _, err := scanImage(ctx, layout, e.label, "oci-dir", e.extract)
+			if err != nil {
+				return err
+			}
+			sources = append(sources, imageSources...)
+		}
+	}
+	for _, ref := range c.StringSlice("image") {
+		imageSources, _, err := scanImage(ctx, ref, ref, "image", image.ExtractRegistry(ctx, c.String("platform")))
+		if err != nil {
+			return err
+		}
+		sources = append(sources, imageSources...)
+	}
+
+	return printCoverage(os.Stdout, sources)
+}
+
+// printCoverage tallies the extracted packages by the ecosystem they
+// would be queried in and reports which of those ecosystems OSV covers.
+// Packages whose ecosystem could not be determined are listed
+// individually, since they are the ones a scan silently skips.
+func printCoverage(w io.Writer, sources []scanner.ScannedSource) error {
+	counts := make(map[string]int)
+	var unmapped []string
+	for _, source := range sources {
+		for _, pkg := range source.Packages {
+			switch {
+			case pkg.Commit != "":
+				// Commit queries match GIT ranges regardless of ecosystem.
+				counts["GIT"]++
+			case pkg.Ecosystem != "":
+				counts[pkg.Ecosystem]++
+			case pkg.PURL != "":
+				if p, err := purl.Parse(pkg.PURL); err == nil {
+					if ecosystem, ok := purl.OSVEcosystem(p); ok {
+						counts[ecosystem]++
+						continue
+					}
+				}
+				unmapped = append(unmapped, fmt.Sprintf("%s: %s (no OSV ecosystem for this package URL)", source.Source.Path, pkg.PURL))
+			default:
+				name := pkg.Name
+				if pkg.Version != "" {
+					name += "@" + pkg.Version
+				}
+				unmapped = append(unmapped, fmt.Sprintf("%s: %s (no ecosystem)", source.Source.Path, name))
+			}
+		}
+	}
+
+	ecosystems := make([]string, 0, len(counts))
+	for ecosystem := range counts {
+		ecosystems = append(ecosystems, ecosystem)
+	}
+	sort.Strings(ecosystems)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ECOSYSTEM\tPACKAGES\tCOVERED BY OSV")
+	uncovered := 0
+	for _, ecosystem := range ecosystems {
+		covered := "yes"
+		if !osv.CoveredEcosystem(ecosystem) {
+			covered = "NO"
+			uncovered += counts[ecosystem]
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", ecosystem, counts[ecosystem], covered)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, entry := range unmapped {
+		fmt.Fprintf(w, "Not queryable: %s\n", entry)
+	}
+	if uncovered > 0 || len(unmapped) > 0 {
+		fmt.Fprintf(w, "%d package(s) cannot be checked against OSV; no findings for them does not mean no vulnerabilities\n", uncovered+len(unmapped))
+	}
+
+	return nil
+}
+
+// runServe handles the serve subcommand: the scanner as a long-lived
+// HTTP service for in-cluster use, scanning submitted lockfiles, SBOMs
+// and image references through the same extraction and query pipeline as
+// a CLI scan.
+func runServe(c *cli.Context) error {
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := configureTransport(c); err != nil {
+		return err
+	}
+	if err := configureCaches(c); err != nil {
+		return err
+	}
+
+	server := &serve.Server{
+		Addr:      c.String("address"),
+		GRPCAddr:  c.String("grpc-address"),
+		AuthToken: c.String("auth-token"),
+		Workers:   c.Int("workers"),
+		Scan:      daemonScan,
+	}
+	if path := c.String("history"); path != "" {
+		store, err := history.Open(path)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		server.History = store
+		server.RescanInterval = c.Duration("rescan-interval")
+	} else if c.Duration("rescan-interval") > 0 {
+		return errors.New("--rescan-interval requires --history")
+	}
+	if path := c.String("notify"); path != "" {
+		sinks, err := notify.Load(path)
+		if err != nil {
+			return err
+		}
+		server.Notify = func(previous, record *history.Record, wasClean bool) {
+			notify.Dispatch(ctx, sinks, notify.Event{
+				Digest:   record.Digest,
+				Ref:      record.Ref,
+				Time:     record.Time,
+				New:      record.New,
+				WasClean: wasClean,
+			})
+		}
+	}
+	if err := server.Run(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// daemonScan runs one submitted scan for the daemon: an uploaded file
+// through the extractor its filename selects, or an image reference
+// through the image pipeline.
+func daemonScan(ctx context.Context, req *serve.ScanRequest) (*models.VulnerabilityResults, error) {
+	var sources []scanner.ScannedSource
+	var skipped []models.SkippedSource
+	switch {
+	case req.Image != "":
+		extract := image.ExtractDockerArchive
+		switch req.ImageType {
+		case "oci-dir":
+			extract = image.ExtractOCILayout
+			if req.Platform != "" {
+				extract = image.ExtractOCIPlatform(req.Platform)
+			}
+		case "registry":
+			extract = image.ExtractRegistry(ctx, req.Platform)
+		}
+		var err error
+		sources, skipped, err = scanImage(ctx, req.Image, req.Image, req.ImageType, extract)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		// The upload is staged under its client-side name so the
+		// extractor registry can recognize it.
+		dir, err := os.MkdirTemp("", "osv-scanner-serve-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, filepath.Base(req.Filename))
+		if err := os.WriteFile(path, req.Content, 0600); err != nil {
+			return nil, err
+		}
+		ext, ok := extractor.ForPath(path)
+		if !ok {
+			return nil, fmt.Errorf("no extractor handles %s", req.Filename)
+		}
+		source, err := scanner.ScanFile(path, ext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", req.Filename, err)
+		}
+		source.Source.Path = req.Filename
+		sources = append(sources, source)
+	}
+
+	results, err := scanner.QuerySources(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	results.Skipped = skipped
+	results.ComputeCounts()
+
+	return results, nil
+}
+
+// scan runs one scan end to end: extraction, querying, config filtering,
+// enrichment and reporting.
+func scan(ctx context.Context, c *cli.Context) error {
+	dirs := c.Args().Slice()
+	if len(dirs) == 0 && len(c.StringSlice("binary")) == 0 &&
+		len(c.StringSlice("docker-archive")) == 0 && len(c.StringSlice("oci-dir")) == 0 &&
+		len(c.StringSlice("image")) == 0 && len(c.StringSlice("rootfs")) == 0 &&
+		len(c.StringSlice("dir-version")) == 0 && len(c.StringSlice("provenance")) == 0 &&
+		len(c.StringSlice("hash-file")) == 0 {
+		dirs = []string{"."}
+	}
+
+	ctx, extractSpan := telemetry.StartSpan(ctx, "extract")
+	var sources []scanner.ScannedSource
+	var skipped []models.SkippedSource
+	members := c.StringSlice("workspace")
+	workspaceFound := false
+	for _, dir := range dirs {
+		scanDirs := []string{dir}
+		ws, isWorkspace := workspace.Detect(dir)
+		if isWorkspace {
+			workspaceFound = true
+			log.Printf("Detected %s workspace at %s with %d members", ws.Type, dir, len(ws.Members))
+			if len(members) > 0 {
+				selected, err := ws.Select(members)
+				if err != nil {
+					return err
+				}
+				scanDirs = nil
+				for _, member := range selected {
+					scanDirs = append(scanDirs, filepath.Join(dir, member.Dir))
+				}
+			}
+		}
+		first := len(sources)
+		for _, scanDir := range scanDirs {
+			dirSources, dirSkipped, err := scanner.ScanDirWithOptions(ctx, scanDir, walkOptions(c))
+			if err != nil {
+				return fmt.Errorf("failed to scan %s: %w", scanDir, err)
+			}
+			sources = append(sources, dirSources...)
+			skipped = append(skipped, dirSkipped...)
+			if c.Bool("experimental-resolve") {
+				manifestSources, err := resolve.ScanDir(ctx, scanDir, c.Bool("recursive"))
+				if err != nil {
+					return fmt.Errorf("failed to resolve manifests in %s: %w", scanDir, err)
+				}
+				sources = append(sources, manifestSources...)
+			}
+		}
+		if isWorkspace {
+			for i := first; i < len(sources); i++ {
+				if member, ok := ws.MemberFor(sources[i].Source.Path); ok {
+					sources[i].Source.Member = member.Name
+				}
+			}
+		}
+	}
+	if len(members) > 0 && !workspaceFound {
+		return errors.New("--workspace was given but no workspace manifest was found in the scan roots")
+	}
+
+	for _, binary := range c.StringSlice("binary") {
+		source, err := gobinary.Scan(binary)
+		if err != nil {
+			// Not a Go binary; it may be a Rust binary built with
+			// `cargo auditable`.
+			var rustErr error
+			source, rustErr = rustbinary.Scan(binary)
+			if rustErr != nil {
+				return fmt.Errorf("%s: %w", binary, errors.Join(err, rustErr))
+			}
+		}
+		sources = append(sources, source)
+	}
+	for _, dir := range c.StringSlice("dir-version") {
+		source, err := scanner.ScanDirVersion(ctx, dir)
+		if err != nil {
+			return fmt.Errorf("failed to determine the version of %s: %w", dir, err)
+		}
+		sources = append(sources, source)
+	}
+	for _, path := range c.StringSlice("provenance") {
+		source, err := provenance.Parse(path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source)
+	}
+	for _, archive := range c.StringSlice("docker-archive") {
+		imageSources, imageSkipped, err := scanImage(ctx, archive, archive, "docker-archive", image.ExtractDockerArchive)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, imageSources...)
+		skipped = append(skipped, imageSkipped...)
+	}
+	for _, layout := range c.StringSlice("oci-dir") {
+		extracts, err := ociExtracts(c.String("platform"), layout)
+		if err != nil {
+			return err
+		}
+		for _, e := range extracts {
+			imageSources, imageSkipped, err := scanImage(ctx, layout, e.label, "oci-dir", e.extract)
+			if err != nil {
+				return err
+			}
+			sources = append(sources, imageSources...)
+			skipped = append(skipped, imageSkipped...)
+		}
+	}
+	for _, ref := range c.StringSlice("image") {
+		imageSources, imageSkipped, err := scanImage(ctx, ref, ref, "image", image.ExtractRegistry(ctx, c.String("platform")))
+		if err != nil {
+			return err
+		}
+		sources = append(sources, imageSources...)
+		skipped = append(skipped, imageSkipped...)
+	}
+	for _, rootfs := range c.StringSlice("rootfs") {
+		rootSources, rootSkipped, err := scanRootFS(ctx, rootfs, rootfs, "rootfs")
+		if err != nil {
+			return err
+		}
+		sources = append(sources, rootSources...)
+		skipped = append(skipped, rootSkipped...)
+	}
+
+	extractSpan.End()
+	var warnings []models.Warning
+	for _, source := range sources {
+		telemetry.PackagesScanned.Add(ctx, int64(len(source.Packages)))
+		if source.Source.Type == "lockfile" {
+			warnings = append(warnings, reconcile.Check(source.Source.Path)...)
+		}
+	}
+
+	ctx, querySpan := telemetry.StartSpan(ctx, "query")
+	var results *models.VulnerabilityResults
+	var err error
+	if dbPath := c.String("local-db"); dbPath != "" {
+		var db *localmatcher.DB
+		if strings.HasSuffix(dbPath, ".zip") {
+			db, err = localmatcher.LoadZip(dbPath)
+		} else {
+			db, err = localmatcher.LoadDir(dbPath)
+		}
+		if err != nil {
+			querySpan.End()
+			return fmt.Errorf("failed to load local database: %w", err)
+		}
+		// Commit queries resolve GIT ranges against the repository's real
+		// ancestry; if no clone cache can be set up, literal event
+		// matching remains in place.
+		if resolver, resolverErr := gitrange.NewResolver(""); resolverErr == nil {
+			localmatcher.CommitAffected = resolver.Affected
+		}
+		results = scanner.QuerySourcesLocal(sources, db)
+		for _, path := range c.StringSlice("hash-file") {
+			source, hashErr := queryFileHashes(db, path)
+			if hashErr != nil {
+				querySpan.End()
+				return hashErr
+			}
+			results.Results = append(results.Results, source)
+		}
+	} else {
+		if len(c.StringSlice("hash-file")) > 0 {
+			querySpan.End()
+			return errors.New("--hash-file requires --local-db: the OSV API has no artifact hash lookup")
+		}
+		results, err = scanner.QuerySources(ctx, sources)
+	}
+	querySpan.End()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := applyConfig(results, &config.Manager{Root: cwd}, c.Bool("verbose")); err != nil {
+		return err
+	}
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			telemetry.Findings.Add(ctx, int64(len(pkg.Groups)))
+		}
+	}
+
+	failOn := c.String("fail-on")
+	if c.Bool("enrich") || failOn == "kev" {
+		if err := enrich.Enrich(ctx, results, ""); err != nil {
+			return err
+		}
+	}
+	if c.Bool("depsdev") {
+		if err := depsdev.Annotate(ctx, results); err != nil {
+			return err
+		}
+	}
+
+	if storePath := c.String("store"); storePath != "" {
+		store, err := resultstore.Open(storePath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		if err := store.AddAll(results); err != nil {
+			return err
+		}
+		// Reports are generated by streaming back out of the store so that
+		// the in-memory results can be released for very large scans.
+		streamed := &models.VulnerabilityResults{}
+		results = nil
+		err = store.Walk(func(source models.PackageSource) error {
+			streamed.Results = append(streamed.Results, source)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		results = streamed
+	}
+
+	results.Skipped = skipped
+	results.Warnings = warnings
+	results.Manifest = buildManifest(c, sources, results)
+
+	ctx, reportSpan := telemetry.StartSpan(ctx, "report")
+	err = writeResults(c, results)
+	reportSpan.End()
+	if err != nil {
+		return err
+	}
+	if badgePath := c.String("emit-badge"); badgePath != "" {
+		var data []byte
+		if strings.HasSuffix(badgePath, ".json") {
+			data, err = badge.GenerateJSON(results)
+			if err != nil {
+				return err
+			}
+		} else {
+			data = badge.GenerateSVG(results)
+		}
+		if err := os.WriteFile(badgePath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write badge: %w", err)
+		}
+	}
+	if c.Bool("github-snapshot") {
+		if err := snapshot.Submit(ctx, sources); err != nil {
+			return fmt.Errorf("failed to submit dependency snapshot: %w", err)
+		}
+	}
+	if attestPath := c.String("attest"); attestPath != "" {
+		var report bytes.Buffer
+		if err := output.PrintJSONResults(results, &report); err != nil {
+			return err
+		}
+		bundle, err := attest.Attest(ctx, report.Bytes(), "osv-scanner-report")
+		if err != nil {
+			return fmt.Errorf("failed to attest results: %w", err)
+		}
+		if err := os.WriteFile(attestPath, bundle, 0644); err != nil {
+			return fmt.Errorf("failed to write attestation: %w", err)
+		}
+	}
+	update.Notify(os.Stderr)
+
+	if err := applyPolicy(c, results); err != nil {
+		return err
+	}
+
+	return failIfMatched(results, failOn)
+}
+
+// applyPolicy evaluates the --policy rules, if any, against the report
+// and turns violations into a non-zero exit.
+func applyPolicy(c *cli.Context, results *models.VulnerabilityResults) error {
+	policyPath := c.String("policy")
+	if policyPath == "" {
+		return nil
+	}
+	rules, err := policy.Load(policyPath)
+	if err != nil {
+		return err
+	}
+	violations, err := policy.Evaluate(rules, results)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	for _, violation := range violations {
+		fmt.Fprintf(os.Stderr, "policy violation: %s\n", violation.Message)
+	}
+
+	return cli.Exit(fmt.Sprintf("%d policy rule(s) violated", len(violations)), 1)
+}
+
+// applyConfig removes findings ignored by the effective osv-scanner.toml
+// configuration of each source. Configs merge hierarchically from the scan
+// root down; with verbose set, the provenance of each applied config and
+// ignore is printed.
+func applyConfig(results *models.VulnerabilityResults, manager *config.Manager, verbose bool) error {
+	now := time.Now()
+	var sources []models.PackageSource
+	for _, source := range results.Results {
+		cfg, err := manager.EffectiveConfig(source.Source.Path)
+		if err != nil {
+			return err
+		}
+		if verbose && len(cfg.Paths) > 0 {
+			log.Printf("Config for %s: %s", source.Source.Path, strings.Join(cfg.Paths, " overridden by "))
+		}
+		var pkgs []models.PackageVulns
+		for _, pkg := range source.Packages {
+			var kept []osv.Vulnerability
+			for _, vuln := range pkg.Vulnerabilities {
+				entry, ignored := cfg.ShouldIgnore(vuln.ID, now)
+				for _, alias := range vuln.Aliases {
+					if ignored {
+						break
+					}
+					entry, ignored = cfg.ShouldIgnore(alias, now)
+				}
+				if ignored {
+					if verbose {
+						log.Printf("Ignoring %s in %s (%s): %s", vuln.ID, pkg.Package.Name, entry.ConfigPath, entry.Reason)
+					}
+					continue
+				}
+				kept = append(kept, vuln)
+			}
+			if len(kept) == 0 {
+				continue
+			}
+			pkg.Vulnerabilities = kept
+			pkg.Groups = grouper.Group(kept)
+			for i := range pkg.Groups {
+				annotateGroup(&pkg.Groups[i], cfg)
+			}
+			pkgs = append(pkgs, pkg)
+		}
+		if len(pkgs) == 0 {
+			continue
+		}
+		source.Packages = pkgs
+		sources = append(sources, source)
+	}
+	results.Results = sources
+
+	return nil
+}
+
+// annotateGroup attaches the tracking metadata the effective config
+// declares for any of the group's IDs, so reports carry triage state
+// (ticket, owner, due date) next to the finding.
+func annotateGroup(group *models.GroupInfo, cfg *config.Config) {
+	for _, id := range group.IDs {
+		entry, ok := cfg.Annotation(id)
+		if !ok {
+			continue
+		}
+		group.Annotation = &models.Annotation{
+			Ticket: entry.Ticket,
+			Owner:  entry.Owner,
+			Due:    entry.Due,
+		}
+
+		return
+	}
+}
+
+// scanImage extracts a container image into a temporary directory using
+// extract and scans the flattened filesystem. Source paths are reported
+// relative to label — usually the image reference, suffixed with the
+// platform for one arch of a multi-arch layout — rather than the
+// temporary directory. Content that cannot be inventoried
+// (MSI-installed software in Windows images) is returned as skipped
+// sources so it shows up in the report.
+func scanImage(ctx context.Context, ref, label, sourceType string, extract func(src, destDir string) error) ([]scanner.ScannedSource, []models.SkippedSource, error) {
+	destDir, err := os.MkdirTemp("", "osv-scanner-image-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(destDir)
+	if err := extract(ref, destDir); err != nil {
+		return nil, nil, fmt.Errorf("failed to extract %s: %w", ref, err)
+	}
+
+	return scanRootFS(ctx, destDir, label, sourceType)
+}
+
+// scanRootFS scans a root filesystem laid out on disk — an extracted
+// image or an already-mounted/exported rootfs — with the full image
+// treatment: the directory walk plus the OS-package, language-package
+// and Windows inventories. Source paths are reported relative to label.
+func scanRootFS(ctx context.Context, dir, label, sourceType string) ([]scanner.ScannedSource, []models.SkippedSource, error) {
+	sources, walkSkipped, err := scanner.ScanDirWithOptions(ctx, dir, scanner.WalkOptions{Recursive: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan %s: %w", label, err)
+	}
+	// Also pick up the OS packages and the language packages installed
+	// into the filesystem, which have no lockfile for the directory scan
+	// to find.
+	sources = append(sources, image.ScanOSPackages(dir)...)
+	sources = append(sources, image.ScanLanguagePackages(dir)...)
+	var skipped []models.SkippedSource
+	for _, s := range walkSkipped {
+		if rel, err := filepath.Rel(dir, s.Path); err == nil {
+			s.Path = label + ":" + rel
+		}
+		skipped = append(skipped, s)
+	}
+	if image.IsWindows(dir) {
+		sources = append(sources, image.ScanWindowsPackages(dir)...)
+		skipped = append(skipped, models.SkippedSource{
+			Path:   label,
+			Type:   "windows-image",
+			Reason: "MSI-installed software leaves no package manifest and cannot be inventoried; only Chocolatey and NuGet packages were scanned",
+		})
+	}
+	for i := range sources {
+		rel, err := filepath.Rel(dir, sources[i].Source.Path)
+		if err == nil {
+			sources[i].Source.Path = label + ":" + rel
+		}
+		sources[i].Source.Type = sourceType
+	}
+
+	return sources, skipped, nil
+}
+
+// ociExtract is one platform's extraction of an OCI layout: the label
+// findings are attributed to, and how to flatten that platform's image.
+type ociExtract struct {
+	label   string
+	extract func(src, destDir string) error
+}
+
+// ociExtracts decides what to extract from one OCI layout: the platform
+// selected by --platform, the single image of a single-platform layout,
+// or — for a multi-arch layout with no selection — every declared
+// platform, labeled per arch so findings stay attributable.
+func ociExtracts(platform, layout string) ([]ociExtract, error) {
+	if platform != "" {
+		return []ociExtract{{layout, image.ExtractOCIPlatform(platform)}}, nil
+	}
+	platforms, err := image.Platforms(layout)
+	if err != nil {
+		return nil, err
+	}
+	if len(platforms) <= 1 {
+		return []ociExtract{{layout, image.ExtractOCILayout}}, nil
+	}
+	extracts := make([]ociExtract, 0, len(platforms))
+	for _, p := range platforms {
+		extracts = append(extracts, ociExtract{layout + "@" + p, image.ExtractOCIPlatform(p)})
+	}
+
+	return extracts, nil
+}
+
+// buildManifest records how this scan was produced: tool version, what
+// answered the queries and how fresh it was, the invocation flags and a
+// digest of every scanned input file. Embedded in the report, it lets a
+// result be audited and reproduced later.
+func buildManifest(c *cli.Context, sources []scanner.ScannedSource, results *models.VulnerabilityResults) *models.ScanManifest {
+	manifest := &models.ScanManifest{ToolVersion: version.OSVVersion, Database: "api"}
+	if dbPath := c.String("local-db"); dbPath != "" {
+		manifest.Database = dbPath
+	}
+	for _, name := range c.FlagNames() {
+		if c.IsSet(name) {
+			manifest.Flags = append(manifest.Flags, fmt.Sprintf("--%s=%v", name, c.Value(name)))
+		}
+	}
+	sort.Strings(manifest.Flags)
+
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		path := source.Source.Path
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		// Image references and resolved manifests have no file to hash.
+		digest, err := hashFile(path)
+		if err != nil {
+			continue
+		}
+		manifest.Inputs = append(manifest.Inputs, models.InputDigest{Path: path, SHA256: digest})
+	}
+	sort.Slice(manifest.Inputs, func(i, j int) bool {
+		return manifest.Inputs[i].Path < manifest.Inputs[j].Path
+	})
+
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			for _, vuln := range pkg.Vulnerabilities {
+				if vuln.Modified.After(manifest.DatabaseTimestamp) {
+					manifest.DatabaseTimestamp = vuln.Modified
+				}
+			}
+		}
+	}
+
+	return manifest
+}
+
+// hashFile returns the hex SHA-256 digest of the regular file at path.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", path)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// queryFileHashes digests the file at path with every algorithm
+// advisories list artifact hashes in (MD5, SHA-1, SHA-256) and matches
+// the digests against the local database, reporting the file as its own
+// source.
+func queryFileHashes(db *localmatcher.DB, path string) (models.PackageSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return models.PackageSource{}, err
+	}
+	defer f.Close()
+	md5Hash, sha1Hash, sha256Hash := md5.New(), sha1.New(), sha256.New()
+	if _, err := io.Copy(io.MultiWriter(md5Hash, sha1Hash, sha256Hash), f); err != nil {
+		return models.PackageSource{}, fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	var vulns []osv.Vulnerability
+	seen := make(map[string]bool)
+	for _, h := range []hash.Hash{sha256Hash, sha1Hash, md5Hash} {
+		for _, vuln := range db.QueryHash(hex.EncodeToString(h.Sum(nil))) {
+			if !seen[vuln.ID] {
+				seen[vuln.ID] = true
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+
+	return models.PackageSource{
+		Source: models.SourceInfo{Path: path, Type: "file-hash"},
+		Packages: []models.PackageVulns{{
+			Package:         models.PackageInfo{Name: filepath.Base(path)},
+			Vulnerabilities: vulns,
+			Groups:          grouper.Group(vulns),
+		}},
+	}, nil
+}
+
+// writeResults renders results to the destinations selected by the
+// --output, --json and --timezone flags.
+func writeResults(c *cli.Context, results *models.VulnerabilityResults) error {
+	results.ComputeCounts()
+	output.TemplatePath = c.String("template")
+	loc := time.Local
+	if tz := c.String("timezone"); tz != "" {
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
+	}
+
+	outputs := c.StringSlice("output")
+	if len(outputs) == 0 {
+		switch {
+		case c.String("format") != "":
+			outputs = []string{"-:" + c.String("format")}
+		case c.Bool("json"):
+			outputs = []string{"-:json"}
+		default:
+			outputs = []string{"-:table"}
+		}
+	}
+	dests := make([]output.Destination, 0, len(outputs))
+	for _, o := range outputs {
+		dest, err := output.ParseDestination(o)
+		if err != nil {
+			return err
+		}
+		dests = append(dests, dest)
+	}
+
+	return output.Write(dests, results, loc)
+}
+
+// failIfMatched returns a non-zero exit error if any finding matches the
+// --fail-on policy.
+func failIfMatched(results *models.VulnerabilityResults, failOn string) error {
+	if failOn == "" {
+		return nil
+	}
+	if failOn == "kev" {
+		for _, source := range results.Results {
+			for _, pkg := range source.Packages {
+				for _, group := range pkg.Groups {
+					if group.KEV {
+						return cli.Exit(fmt.Sprintf("%s in %s is in the CISA KEV catalog", group.IDs[0], pkg.Package.Name), 1)
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+	threshold := severity.ParseLevel(failOn)
+	if threshold == severity.LevelUnknown {
+		return fmt.Errorf("invalid --fail-on value: %q", failOn)
+	}
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			if _, level := severity.MaxOf(pkg.Vulnerabilities); level >= threshold {
+				return cli.Exit(fmt.Sprintf("%s has a %s severity finding", pkg.Package.Name, level), 1)
+			}
+		}
+	}
+
+	return nil
+}
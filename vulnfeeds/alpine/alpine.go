@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alpine translates the Alpine secdb JSON feeds
+// (https://secdb.alpinelinux.org/) into OSV records. secfixes map fixed
+// package versions to the CVEs they address, so each CVE becomes a
+// range from the beginning of time to its fix.
+package alpine
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// secdb is one secdb file (e.g. v3.19/main.json).
+type secdb struct {
+	DistroVersion string `json:"distroversion"`
+	Packages      []struct {
+		Pkg struct {
+			Name     string              `json:"name"`
+			Secfixes map[string][]string `json:"secfixes"`
+		} `json:"pkg"`
+	} `json:"packages"`
+}
+
+// Import reads one secdb feed and produces one OSV record per CVE
+// mentioned in a secfix. Non-CVE identifiers (XSA, botan-sa, ...) are
+// kept as-is; "0" entries, which mark unresolved issues, yield a range
+// with no fixed event.
+func Import(r io.Reader) ([]osvschema.Vulnerability, error) {
+	var db secdb
+	if err := json.NewDecoder(r).Decode(&db); err != nil {
+		return nil, err
+	}
+	ecosystem := "Alpine:" + db.DistroVersion
+	// The secdb feeds carry no timestamps, so records are stamped with
+	// the conversion time; modified is required by the schema.
+	now := time.Now().UTC()
+
+	byID := map[string]*osvschema.Vulnerability{}
+	for _, entry := range db.Packages {
+		for fixedVersion, ids := range entry.Pkg.Secfixes {
+			for _, id := range ids {
+				// Entries may carry annotations after the identifier, and
+				// malformed secdb files contain blank entries.
+				fields := strings.Fields(id)
+				if len(fields) == 0 {
+					continue
+				}
+				id = fields[0]
+				vuln := byID[id]
+				if vuln == nil {
+					vuln = &osvschema.Vulnerability{
+						SchemaVersion: osvschema.SchemaVersion,
+						ID:            id,
+						Modified:      now,
+					}
+					byID[id] = vuln
+				}
+				events := []osvschema.Event{{Introduced: "0"}}
+				if fixedVersion != "0" {
+					events = append(events, osvschema.Event{Fixed: fixedVersion})
+				}
+				vuln.Affected = append(vuln.Affected, osvschema.Affected{
+					Package: osvschema.Package{
+						Ecosystem: ecosystem,
+						Name:      entry.Pkg.Name,
+					},
+					Ranges: []osvschema.Range{{
+						Type:   osvschema.RangeEcosystem,
+						Events: events,
+					}},
+				})
+			}
+		}
+	}
+
+	var vulns []osvschema.Vulnerability
+	for _, vuln := range byID {
+		sort.Slice(vuln.Affected, func(i, j int) bool {
+			return vuln.Affected[i].Package.Name < vuln.Affected[j].Package.Name
+		})
+		vulns = append(vulns, *vuln)
+	}
+	sort.Slice(vulns, func(i, j int) bool { return vulns[i].ID < vulns[j].ID })
+
+	return vulns, nil
+}
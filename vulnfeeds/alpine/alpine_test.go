@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	secdb := `{
+		"distroversion": "v3.19",
+		"packages": [
+			{"pkg": {"name": "curl", "secfixes": {
+				"8.5.0-r0": ["CVE-2023-46218 (annotated entry)", "CVE-2023-46219"],
+				"0": ["CVE-2023-9999", "   ", ""]
+			}}},
+			{"pkg": {"name": "nghttp2", "secfixes": {
+				"1.57.0-r0": ["CVE-2023-44487"]
+			}}}
+		]
+	}`
+	vulns, err := Import(strings.NewReader(secdb))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	var ids []string
+	for _, vuln := range vulns {
+		ids = append(ids, vuln.ID)
+		if err := vuln.Validate(); err != nil {
+			t.Errorf("%s does not validate: %v", vuln.ID, err)
+		}
+		if vuln.Modified.IsZero() {
+			t.Errorf("%s has no modified timestamp", vuln.ID)
+		}
+	}
+	// Blank secfix entries are skipped, annotations are stripped, and
+	// records come out sorted by ID.
+	want := []string{"CVE-2023-44487", "CVE-2023-46218", "CVE-2023-46219", "CVE-2023-9999"}
+	if strings.Join(ids, " ") != strings.Join(want, " ") {
+		t.Fatalf("Import produced %v, want %v", ids, want)
+	}
+
+	fixed := vulns[1].Affected[0].Ranges[0].Events
+	if len(fixed) != 2 || fixed[1].Fixed != "8.5.0-r0" {
+		t.Errorf("CVE-2023-46218 events = %+v, want introduced 0 and fixed 8.5.0-r0", fixed)
+	}
+	if got := vulns[1].Affected[0].Package.Ecosystem; got != "Alpine:v3.19" {
+		t.Errorf("ecosystem = %q, want Alpine:v3.19", got)
+	}
+	// "0" marks an unresolved issue: affected from the beginning of
+	// time, no fixed event.
+	unresolved := vulns[3].Affected[0].Ranges[0].Events
+	if len(unresolved) != 1 || unresolved[0].Introduced != "0" {
+		t.Errorf("CVE-2023-9999 events = %+v, want only introduced 0", unresolved)
+	}
+}
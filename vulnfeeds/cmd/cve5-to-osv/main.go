@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// cve5-to-osv converts a directory of CVE Record Format 5.x JSON files
+// (as published in the cvelistV5 repository) into OSV records, writing
+// one <id>.json per convertible record and a report of everything that
+// could not be converted.
+//
+// Usage:
+//
+//	cve5-to-osv -in cvelistV5/cves -out osv/ [-report report.txt]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv/vulnfeeds/cve5"
+)
+
+var (
+	inDir      = flag.String("in", "", "directory of CVE 5.x JSON files to convert")
+	outDir     = flag.String("out", "", "directory to write OSV records to")
+	reportPath = flag.String("report", "", "write the unconvertible-entry report to this file instead of stderr")
+)
+
+func main() {
+	flag.Parse()
+	if *inDir == "" || *outDir == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("Failed to create %s: %v", *outDir, err)
+	}
+
+	report := os.Stderr
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			log.Fatalf("Failed to create report: %v", err)
+		}
+		defer f.Close()
+		report = f
+	}
+
+	converted, skipped := 0, 0
+	err := filepath.WalkDir(*inDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" || !strings.HasPrefix(d.Name(), "CVE-") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var record cve5.Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			fmt.Fprintf(report, "%s: invalid record: %v\n", path, err)
+			skipped++
+
+			return nil
+		}
+		vuln, notes := cve5.Convert(&record)
+		for _, note := range notes {
+			fmt.Fprintf(report, "%s: %s\n", record.CVEMetadata.CVEID, note)
+		}
+		if vuln == nil {
+			skipped++
+
+			return nil
+		}
+		out, err := json.MarshalIndent(vuln, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(*outDir, vuln.ID+".json"), append(out, '\n'), 0644); err != nil {
+			return err
+		}
+		converted++
+
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Conversion failed: %v", err)
+	}
+	log.Printf("Converted %d records, skipped %d", converted, skipped)
+}
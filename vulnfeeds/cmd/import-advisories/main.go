@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// import-advisories pulls advisories from GitHub (GraphQL) and/or a
+// GitLab advisory database checkout, converts them to OSV and writes
+// them into an output directory, skipping records already present there
+// under the same ID or a shared alias.
+//
+// Usage:
+//
+//	import-advisories -out osv/ -github-token $TOKEN
+//	import-advisories -out osv/ -gitlab-dir gemnasium-db/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv/go/osvschema"
+
+	"github.com/google/osv/vulnfeeds/dedup"
+	"github.com/google/osv/vulnfeeds/ghsa"
+	"github.com/google/osv/vulnfeeds/gitlab"
+)
+
+var (
+	outDir      = flag.String("out", "", "directory of existing OSV records; new records are written here")
+	githubToken = flag.String("github-token", "", "import GitHub Security Advisories using this API token")
+	gitlabDir   = flag.String("gitlab-dir", "", "import from the GitLab advisory database checkout at this path")
+)
+
+func main() {
+	flag.Parse()
+	if *outDir == "" || (*githubToken == "" && *gitlabDir == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("Failed to create %s: %v", *outDir, err)
+	}
+	index, err := dedup.LoadDir(*outDir)
+	if err != nil {
+		log.Fatalf("Failed to index existing records: %v", err)
+	}
+
+	var imported []osvschema.Vulnerability
+	if *githubToken != "" {
+		client := &ghsa.Client{Token: *githubToken}
+		vulns, err := client.Import(context.Background())
+		if err != nil {
+			log.Fatalf("GitHub import failed: %v", err)
+		}
+		imported = append(imported, vulns...)
+	}
+	if *gitlabDir != "" {
+		vulns, notes, err := gitlab.ImportDir(*gitlabDir)
+		if err != nil {
+			log.Fatalf("GitLab import failed: %v", err)
+		}
+		for _, note := range notes {
+			log.Print(note)
+		}
+		imported = append(imported, vulns...)
+	}
+
+	written, skipped := 0, 0
+	for i := range imported {
+		vuln := &imported[i]
+		if existing := index.Existing(vuln); existing != "" {
+			log.Printf("Skipping %s: already present as %s", vuln.ID, existing)
+			skipped++
+
+			continue
+		}
+		data, err := json.MarshalIndent(vuln, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode %s: %v", vuln.ID, err)
+		}
+		path := filepath.Join(*outDir, vuln.ID+".json")
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		index.Add(vuln)
+		written++
+	}
+	log.Printf("Imported %d records, skipped %d duplicates", written, skipped)
+}
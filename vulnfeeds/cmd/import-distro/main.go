@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// import-distro converts Linux distribution security feeds into OSV
+// records: the Debian security tracker JSON, the Ubuntu USN database
+// JSON and Alpine secdb files. It is the entry point of the docker-based
+// distro import workers, which download the feeds and run it on a
+// schedule.
+//
+// Usage:
+//
+//	import-distro -out osv/ -debian debian.json -ubuntu usn.json \
+//	    -alpine v3.19/main.json -alpine v3.19/community.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv/go/osvschema"
+
+	"github.com/google/osv/vulnfeeds/alpine"
+	"github.com/google/osv/vulnfeeds/debian"
+	"github.com/google/osv/vulnfeeds/ubuntu"
+)
+
+type alpineFlags []string
+
+func (f *alpineFlags) String() string { return fmt.Sprint(*f) }
+func (f *alpineFlags) Set(v string) error {
+	*f = append(*f, v)
+
+	return nil
+}
+
+var (
+	outDir      = flag.String("out", "", "directory to write OSV records to")
+	debianPath  = flag.String("debian", "", "Debian security tracker JSON export to convert")
+	ubuntuPath  = flag.String("ubuntu", "", "Ubuntu USN database JSON to convert")
+	alpinePaths alpineFlags
+)
+
+func main() {
+	flag.Var(&alpinePaths, "alpine", "Alpine secdb file to convert; repeatable")
+	flag.Parse()
+	if *outDir == "" || (*debianPath == "" && *ubuntuPath == "" && len(alpinePaths) == 0) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("Failed to create %s: %v", *outDir, err)
+	}
+
+	var vulns []osvschema.Vulnerability
+	if *debianPath != "" {
+		vulns = append(vulns, importFile(*debianPath, debian.Import)...)
+	}
+	if *ubuntuPath != "" {
+		vulns = append(vulns, importFile(*ubuntuPath, ubuntu.Import)...)
+	}
+	for _, path := range alpinePaths {
+		vulns = append(vulns, importFile(path, alpine.Import)...)
+	}
+
+	merged := mergeByID(vulns)
+	for _, vuln := range merged {
+		data, err := json.MarshalIndent(vuln, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode %s: %v", vuln.ID, err)
+		}
+		path := filepath.Join(*outDir, vuln.ID+".json")
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	log.Printf("Wrote %d records", len(merged))
+}
+
+// mergeByID combines records sharing an ID into one. The same CVE can
+// appear in several feeds — Debian and Alpine both track CVEs — and in
+// several Alpine release files; writing them as separate <ID>.json
+// files would let the last one win and drop the others' affected
+// entries. The first record seen for an ID keeps its descriptive
+// fields; later ones contribute their affected entries.
+func mergeByID(vulns []osvschema.Vulnerability) []*osvschema.Vulnerability {
+	byID := map[string]*osvschema.Vulnerability{}
+	var merged []*osvschema.Vulnerability
+	for i := range vulns {
+		vuln := &vulns[i]
+		if existing, ok := byID[vuln.ID]; ok {
+			existing.Affected = append(existing.Affected, vuln.Affected...)
+			continue
+		}
+		byID[vuln.ID] = vuln
+		merged = append(merged, vuln)
+	}
+
+	return merged
+}
+
+func importFile(path string, convert func(r io.Reader) ([]osvschema.Vulnerability, error)) []osvschema.Vulnerability {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	vulns, err := convert(f)
+	if err != nil {
+		log.Fatalf("Failed to convert %s: %v", path, err)
+	}
+
+	return vulns
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+func TestMergeByID(t *testing.T) {
+	affected := func(ecosystem, name string) osvschema.Affected {
+		return osvschema.Affected{
+			Package: osvschema.Package{Ecosystem: ecosystem, Name: name},
+		}
+	}
+	// The same CVE from the Debian feed and two Alpine release files,
+	// plus a record appearing only once.
+	vulns := []osvschema.Vulnerability{
+		{ID: "CVE-2023-38545", Details: "from debian", Affected: []osvschema.Affected{affected("Debian:12", "curl")}},
+		{ID: "USN-6237-1", Affected: []osvschema.Affected{affected("Ubuntu:22.04", "curl")}},
+		{ID: "CVE-2023-38545", Affected: []osvschema.Affected{affected("Alpine:v3.18", "curl")}},
+		{ID: "CVE-2023-38545", Affected: []osvschema.Affected{affected("Alpine:v3.19", "curl")}},
+	}
+	merged := mergeByID(vulns)
+	if len(merged) != 2 {
+		t.Fatalf("mergeByID produced %d records, want 2", len(merged))
+	}
+	cve := merged[0]
+	if cve.ID != "CVE-2023-38545" || cve.Details != "from debian" {
+		t.Errorf("first record = %s %q, want the Debian CVE's fields", cve.ID, cve.Details)
+	}
+	if len(cve.Affected) != 3 {
+		t.Fatalf("merged record has %d affected entries, want 3: %+v", len(cve.Affected), cve.Affected)
+	}
+	ecosystems := []string{}
+	for _, entry := range cve.Affected {
+		ecosystems = append(ecosystems, entry.Package.Ecosystem)
+	}
+	want := []string{"Debian:12", "Alpine:v3.18", "Alpine:v3.19"}
+	for i := range want {
+		if ecosystems[i] != want[i] {
+			t.Fatalf("merged ecosystems = %v, want %v", ecosystems, want)
+		}
+	}
+	if merged[1].ID != "USN-6237-1" || len(merged[1].Affected) != 1 {
+		t.Errorf("second record = %+v, want the untouched USN", merged[1])
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// osv-to-csaf bundles a set of OSV records into one CSAF 2.0 advisory
+// document.
+//
+// Usage:
+//
+//	osv-to-csaf -id ACME-2026-001 -publisher "ACME Corp" \
+//	    -namespace https://acme.example -out advisory.json osv/*.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/google/osv/go/osvschema"
+
+	"github.com/google/osv/vulnfeeds/csaf"
+)
+
+var (
+	id        = flag.String("id", "", "tracking ID of the generated document")
+	title     = flag.String("title", "Security advisory", "document title")
+	publisher = flag.String("publisher", "", "publisher name")
+	namespace = flag.String("namespace", "", "publisher namespace URL")
+	out       = flag.String("out", "-", "output file (\"-\" for stdout)")
+)
+
+func main() {
+	flag.Parse()
+	if *id == "" || *publisher == "" || *namespace == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var vulns []osvschema.Vulnerability
+	for _, path := range flag.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+		var vuln osvschema.Vulnerability
+		if err := json.Unmarshal(data, &vuln); err != nil {
+			log.Fatalf("Invalid OSV record %s: %v", path, err)
+		}
+		vulns = append(vulns, vuln)
+	}
+
+	doc := csaf.Export(*id, *title, csaf.Publisher{
+		Category:  "vendor",
+		Name:      *publisher,
+		Namespace: *namespace,
+	}, vulns)
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode document: %v", err)
+	}
+	data = append(data, '\n')
+	if *out == "-" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
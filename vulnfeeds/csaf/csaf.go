@@ -0,0 +1,261 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package csaf exports OSV records as a CSAF 2.0 advisory document
+// (https://docs.oasis-open.org/csaf/csaf/v2.0/csaf-v2.0.html), for
+// vendors republishing osv.dev data to consumers that require CSAF.
+package csaf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// Document is a CSAF 2.0 advisory document.
+type Document struct {
+	Document        DocumentMeta    `json:"document"`
+	ProductTree     ProductTree     `json:"product_tree"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// DocumentMeta is the /document object.
+type DocumentMeta struct {
+	Category    string    `json:"category"`
+	CSAFVersion string    `json:"csaf_version"`
+	Title       string    `json:"title"`
+	Publisher   Publisher `json:"publisher"`
+	Tracking    Tracking  `json:"tracking"`
+}
+
+// Publisher identifies the party issuing the document.
+type Publisher struct {
+	Category  string `json:"category"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// Tracking carries the document's identity and release history.
+type Tracking struct {
+	ID                 string     `json:"id"`
+	Status             string     `json:"status"`
+	Version            string     `json:"version"`
+	InitialReleaseDate time.Time  `json:"initial_release_date"`
+	CurrentReleaseDate time.Time  `json:"current_release_date"`
+	RevisionHistory    []Revision `json:"revision_history"`
+}
+
+// Revision is one entry of the revision history.
+type Revision struct {
+	Number  string    `json:"number"`
+	Date    time.Time `json:"date"`
+	Summary string    `json:"summary"`
+}
+
+// ProductTree lists the products the document refers to.
+type ProductTree struct {
+	FullProductNames []FullProductName `json:"full_product_names"`
+}
+
+// FullProductName names one product version.
+type FullProductName struct {
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+}
+
+// Vulnerability is one /vulnerabilities entry.
+type Vulnerability struct {
+	CVE           string        `json:"cve,omitempty"`
+	IDs           []ID          `json:"ids,omitempty"`
+	Notes         []Note        `json:"notes,omitempty"`
+	ProductStatus ProductStatus `json:"product_status"`
+	References    []Reference   `json:"references,omitempty"`
+	Scores        []Score       `json:"scores,omitempty"`
+}
+
+// ID is a non-CVE identifier of a vulnerability.
+type ID struct {
+	SystemName string `json:"system_name"`
+	Text       string `json:"text"`
+}
+
+// Note is a categorized free-text note.
+type Note struct {
+	Category string `json:"category"`
+	Text     string `json:"text"`
+}
+
+// ProductStatus groups products by their relationship to the
+// vulnerability.
+type ProductStatus struct {
+	KnownAffected []string `json:"known_affected,omitempty"`
+	Fixed         []string `json:"fixed,omitempty"`
+}
+
+// Reference is a reference URL with a summary.
+type Reference struct {
+	Summary string `json:"summary"`
+	URL     string `json:"url"`
+}
+
+// Score attaches a CVSS vector to products.
+type Score struct {
+	Products []string `json:"products"`
+	CVSSV3   *CVSS    `json:"cvss_v3,omitempty"`
+	CVSSV4   *CVSS    `json:"cvss_v4,omitempty"`
+}
+
+// CVSS is a CVSS score object; only the vector is carried over.
+type CVSS struct {
+	VectorString string `json:"vectorString"`
+}
+
+// Export builds a CSAF security advisory document covering the given
+// OSV records. The tracking ID and publisher describe the republishing
+// vendor, not osv.dev.
+func Export(id, title string, publisher Publisher, vulns []osvschema.Vulnerability) *Document {
+	doc := &Document{
+		Document: DocumentMeta{
+			Category:    "csaf_security_advisory",
+			CSAFVersion: "2.0",
+			Title:       title,
+			Publisher:   publisher,
+			Tracking: Tracking{
+				ID:      id,
+				Status:  "final",
+				Version: "1",
+			},
+		},
+	}
+	now := time.Now().UTC()
+	doc.Document.Tracking.InitialReleaseDate = now
+	doc.Document.Tracking.CurrentReleaseDate = now
+	doc.Document.Tracking.RevisionHistory = []Revision{{
+		Number:  "1",
+		Date:    now,
+		Summary: "Initial release.",
+	}}
+
+	products := map[string]string{}
+	for _, vuln := range vulns {
+		doc.Vulnerabilities = append(doc.Vulnerabilities, convertVulnerability(vuln, products, &doc.ProductTree))
+	}
+
+	return doc
+}
+
+func convertVulnerability(vuln osvschema.Vulnerability, products map[string]string, tree *ProductTree) Vulnerability {
+	out := Vulnerability{}
+	if isCVE(vuln.ID) {
+		out.CVE = vuln.ID
+	} else {
+		out.IDs = append(out.IDs, ID{SystemName: "OSV", Text: vuln.ID})
+		for _, alias := range vuln.Aliases {
+			if isCVE(alias) && out.CVE == "" {
+				out.CVE = alias
+			}
+		}
+	}
+	if vuln.Summary != "" {
+		out.Notes = append(out.Notes, Note{Category: "summary", Text: vuln.Summary})
+	}
+	if vuln.Details != "" {
+		out.Notes = append(out.Notes, Note{Category: "description", Text: vuln.Details})
+	}
+
+	var affectedIDs []string
+	for _, affected := range vuln.Affected {
+		for _, version := range affectedVersions(affected) {
+			pid := productID(affected.Package, version, products, tree)
+			affectedIDs = append(affectedIDs, pid)
+			out.ProductStatus.KnownAffected = append(out.ProductStatus.KnownAffected, pid)
+		}
+		for _, version := range fixedVersions(affected) {
+			pid := productID(affected.Package, version, products, tree)
+			out.ProductStatus.Fixed = append(out.ProductStatus.Fixed, pid)
+		}
+	}
+	for _, reference := range vuln.References {
+		out.References = append(out.References, Reference{
+			Summary: string(reference.Type),
+			URL:     reference.URL,
+		})
+	}
+	for _, severity := range vuln.Severity {
+		score := Score{Products: affectedIDs}
+		switch severity.Type {
+		case osvschema.SeverityCVSSV3:
+			score.CVSSV3 = &CVSS{VectorString: severity.Score}
+		case osvschema.SeverityCVSSV4:
+			score.CVSSV4 = &CVSS{VectorString: severity.Score}
+		default:
+			continue
+		}
+		out.Scores = append(out.Scores, score)
+	}
+
+	return out
+}
+
+// productID interns a (package, version) pair into the product tree.
+func productID(pkg osvschema.Package, version string, products map[string]string, tree *ProductTree) string {
+	name := fmt.Sprintf("%s/%s %s", pkg.Ecosystem, pkg.Name, version)
+	if pid, ok := products[name]; ok {
+		return pid
+	}
+	pid := fmt.Sprintf("OSV-PKG-%04d", len(products)+1)
+	products[name] = pid
+	tree.FullProductNames = append(tree.FullProductNames, FullProductName{
+		ProductID: pid,
+		Name:      name,
+	})
+
+	return pid
+}
+
+// affectedVersions lists the versions to mark known_affected: explicit
+// versions, plus range starts as "<introduced> and later" spans.
+func affectedVersions(affected osvschema.Affected) []string {
+	versions := append([]string(nil), affected.Versions...)
+	for _, rng := range affected.Ranges {
+		for _, event := range rng.Events {
+			if event.Introduced != "" && event.Introduced != "0" {
+				versions = append(versions, ">= "+event.Introduced)
+			}
+		}
+	}
+	if len(versions) == 0 {
+		versions = []string{"all versions"}
+	}
+
+	return versions
+}
+
+func fixedVersions(affected osvschema.Affected) []string {
+	var versions []string
+	for _, rng := range affected.Ranges {
+		for _, event := range rng.Events {
+			if event.Fixed != "" {
+				versions = append(versions, event.Fixed)
+			}
+		}
+	}
+
+	return versions
+}
+
+func isCVE(id string) bool {
+	return len(id) > 4 && id[:4] == "CVE-"
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cve5 converts CVE Record Format 5.x JSON into OSV records.
+// Package ecosystems are inferred from each affected entry's collection
+// URL or vendor/product pair; entries that cannot be mapped are reported
+// rather than silently dropped.
+package cve5
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// Record is the subset of the CVE 5.x record format the converter reads.
+type Record struct {
+	CVEMetadata struct {
+		CVEID         string `json:"cveId"`
+		State         string `json:"state"`
+		DatePublished string `json:"datePublished"`
+		DateUpdated   string `json:"dateUpdated"`
+	} `json:"cveMetadata"`
+	Containers struct {
+		CNA struct {
+			Title        string        `json:"title"`
+			Descriptions []Description `json:"descriptions"`
+			Affected     []Affected    `json:"affected"`
+			References   []Reference   `json:"references"`
+			Metrics      []Metric      `json:"metrics"`
+		} `json:"cna"`
+	} `json:"containers"`
+}
+
+// Description is a localized description of a record.
+type Description struct {
+	Lang  string `json:"lang"`
+	Value string `json:"value"`
+}
+
+// Affected is one affected product of a record.
+type Affected struct {
+	Vendor        string    `json:"vendor"`
+	Product       string    `json:"product"`
+	PackageName   string    `json:"packageName"`
+	CollectionURL string    `json:"collectionURL"`
+	Repo          string    `json:"repo"`
+	CPEs          []string  `json:"cpes"`
+	Versions      []Version `json:"versions"`
+}
+
+// Version is one version status entry of an affected product.
+type Version struct {
+	Version         string `json:"version"`
+	Status          string `json:"status"`
+	LessThan        string `json:"lessThan"`
+	LessThanOrEqual string `json:"lessThanOrEqual"`
+	VersionType     string `json:"versionType"`
+}
+
+// Reference is a tagged reference URL.
+type Reference struct {
+	URL  string   `json:"url"`
+	Tags []string `json:"tags"`
+}
+
+// Metric carries the CVSS vectors of a record.
+type Metric struct {
+	CVSSV4  *CVSS `json:"cvssV4_0"`
+	CVSSV31 *CVSS `json:"cvssV3_1"`
+	CVSSV30 *CVSS `json:"cvssV3_0"`
+}
+
+// CVSS is one CVSS score entry.
+type CVSS struct {
+	VectorString string `json:"vectorString"`
+}
+
+// collectionEcosystems maps registry hosts appearing in collection URLs
+// to OSV ecosystems.
+var collectionEcosystems = map[string]string{
+	"registry.npmjs.org":    "npm",
+	"www.npmjs.com":         "npm",
+	"pypi.org":              "PyPI",
+	"crates.io":             "crates.io",
+	"rubygems.org":          "RubyGems",
+	"proxy.golang.org":      "Go",
+	"pkg.go.dev":            "Go",
+	"repo.maven.apache.org": "Maven",
+	"search.maven.org":      "Maven",
+	"packagist.org":         "Packagist",
+	"www.nuget.org":         "NuGet",
+	"hex.pm":                "Hex",
+}
+
+// vendorEcosystems maps well-known vendor names to ecosystems, used when
+// no collection URL is present.
+var vendorEcosystems = map[string]string{
+	"npm":       "npm",
+	"pypi":      "PyPI",
+	"rubygems":  "RubyGems",
+	"packagist": "Packagist",
+	"crates.io": "crates.io",
+	"golang":    "Go",
+	"maven":     "Maven",
+	"nuget":     "NuGet",
+}
+
+// Convert transforms one CVE 5.x record into an OSV record. The second
+// return lists affected entries that could not be converted, with
+// reasons; a record is unconvertible if none of its entries map to a
+// package or repository, in which case the returned vulnerability is
+// nil.
+func Convert(record *Record) (*osvschema.Vulnerability, []string) {
+	var skipped []string
+	if record.CVEMetadata.State == "REJECTED" {
+		return nil, []string{"record is rejected"}
+	}
+	vuln := &osvschema.Vulnerability{
+		SchemaVersion: osvschema.SchemaVersion,
+		ID:            record.CVEMetadata.CVEID,
+		Summary:       record.Containers.CNA.Title,
+		Modified:      parseTime(record.CVEMetadata.DateUpdated),
+		Published:     osvschema.Timestamp(parseTime(record.CVEMetadata.DatePublished)),
+	}
+	for _, description := range record.Containers.CNA.Descriptions {
+		if description.Lang == "en" || vuln.Details == "" {
+			vuln.Details = description.Value
+		}
+	}
+	for i, affected := range record.Containers.CNA.Affected {
+		entry, err := convertAffected(affected)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("affected[%d] (%s %s): %v", i, affected.Vendor, affected.Product, err))
+			continue
+		}
+		vuln.Affected = append(vuln.Affected, entry)
+	}
+	for _, reference := range record.Containers.CNA.References {
+		vuln.References = append(vuln.References, osvschema.Reference{
+			Type: referenceType(reference.Tags),
+			URL:  reference.URL,
+		})
+	}
+	for _, metric := range record.Containers.CNA.Metrics {
+		switch {
+		case metric.CVSSV4 != nil:
+			vuln.Severity = append(vuln.Severity, osvschema.Severity{Type: osvschema.SeverityCVSSV4, Score: metric.CVSSV4.VectorString})
+		case metric.CVSSV31 != nil:
+			vuln.Severity = append(vuln.Severity, osvschema.Severity{Type: osvschema.SeverityCVSSV3, Score: metric.CVSSV31.VectorString})
+		case metric.CVSSV30 != nil:
+			vuln.Severity = append(vuln.Severity, osvschema.Severity{Type: osvschema.SeverityCVSSV3, Score: metric.CVSSV30.VectorString})
+		}
+	}
+	if len(vuln.Affected) == 0 {
+		return nil, append(skipped, "no affected entry maps to a package or repository")
+	}
+
+	return vuln, skipped
+}
+
+func convertAffected(affected Affected) (osvschema.Affected, error) {
+	entry := osvschema.Affected{}
+	if len(affected.CPEs) > 0 {
+		entry.DatabaseSpecific = map[string]interface{}{"cpes": affected.CPEs}
+	}
+
+	// Git-versioned entries become a GIT range against the repo.
+	if affected.Repo != "" && versionType(affected) == "git" {
+		rng := osvschema.Range{Type: osvschema.RangeGit, Repo: affected.Repo}
+		rng.Events = convertEvents(affected.Versions)
+		entry.Ranges = []osvschema.Range{rng}
+
+		return entry, nil
+	}
+
+	ecosystem, err := inferEcosystem(affected)
+	if err != nil {
+		return osvschema.Affected{}, err
+	}
+	name := affected.PackageName
+	if name == "" {
+		name = affected.Product
+	}
+	entry.Package = osvschema.Package{Ecosystem: ecosystem, Name: name}
+
+	rangeType := osvschema.RangeEcosystem
+	if versionType(affected) == "semver" {
+		rangeType = osvschema.RangeSemVer
+	}
+	var events []osvschema.Event
+	for _, version := range affected.Versions {
+		if version.Status != "affected" {
+			continue
+		}
+		switch {
+		case version.LessThan != "":
+			events = append(events, osvschema.Event{Introduced: introducedOf(version)})
+			events = append(events, osvschema.Event{Fixed: version.LessThan})
+		case version.LessThanOrEqual != "":
+			events = append(events, osvschema.Event{Introduced: introducedOf(version)})
+			if version.LessThanOrEqual != "*" {
+				events = append(events, osvschema.Event{LastAffected: version.LessThanOrEqual})
+			}
+		case version.Version == "*" || version.Version == "":
+			events = append(events, osvschema.Event{Introduced: "0"})
+		default:
+			entry.Versions = append(entry.Versions, version.Version)
+		}
+	}
+	if len(events) > 0 {
+		entry.Ranges = []osvschema.Range{{Type: rangeType, Events: events}}
+	}
+	if len(events) == 0 && len(entry.Versions) == 0 {
+		return osvschema.Affected{}, fmt.Errorf("no affected versions")
+	}
+
+	return entry, nil
+}
+
+func convertEvents(versions []Version) []osvschema.Event {
+	var events []osvschema.Event
+	for _, version := range versions {
+		if version.Status != "affected" {
+			continue
+		}
+		events = append(events, osvschema.Event{Introduced: introducedOf(version)})
+		if version.LessThan != "" {
+			events = append(events, osvschema.Event{Fixed: version.LessThan})
+		} else if version.LessThanOrEqual != "" {
+			events = append(events, osvschema.Event{LastAffected: version.LessThanOrEqual})
+		}
+	}
+
+	return events
+}
+
+// introducedOf maps the CVE "version" field to an introduced event,
+// treating the wildcard and empty forms as the beginning of time.
+func introducedOf(version Version) string {
+	if version.Version == "" || version.Version == "*" || version.Version == "0" {
+		return "0"
+	}
+
+	return version.Version
+}
+
+func versionType(affected Affected) string {
+	for _, version := range affected.Versions {
+		if version.VersionType != "" {
+			return version.VersionType
+		}
+	}
+
+	return ""
+}
+
+// inferEcosystem decides the OSV ecosystem of an affected entry: the
+// collection URL's registry host if recognized, otherwise the vendor
+// name.
+func inferEcosystem(affected Affected) (string, error) {
+	collection := strings.TrimPrefix(strings.TrimPrefix(affected.CollectionURL, "https://"), "http://")
+	collection, _, _ = strings.Cut(collection, "/")
+	if ecosystem, ok := collectionEcosystems[collection]; ok {
+		return ecosystem, nil
+	}
+	if ecosystem, ok := vendorEcosystems[strings.ToLower(affected.Vendor)]; ok {
+		return ecosystem, nil
+	}
+
+	return "", fmt.Errorf("no ecosystem for collection %q / vendor %q", affected.CollectionURL, affected.Vendor)
+}
+
+func referenceType(tags []string) osvschema.ReferenceType {
+	for _, tag := range tags {
+		switch tag {
+		case "patch":
+			return osvschema.ReferenceFix
+		case "exploit":
+			return osvschema.ReferenceEvidence
+		case "vendor-advisory", "third-party-advisory", "mitigation":
+			return osvschema.ReferenceAdvisory
+		case "issue-tracking":
+			return osvschema.ReferenceReport
+		case "technical-description":
+			return osvschema.ReferenceArticle
+		}
+	}
+
+	return osvschema.ReferenceWeb
+}
+
+func parseTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04:05.000Z"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debian translates the Debian security tracker JSON export
+// (https://security-tracker.debian.org/tracker/data/json) into OSV
+// records with EVR version ranges.
+package debian
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// releaseVersions maps Debian release codenames to the version used in
+// the OSV ecosystem suffix.
+var releaseVersions = map[string]string{
+	"buster":   "10",
+	"bullseye": "11",
+	"bookworm": "12",
+	"trixie":   "13",
+}
+
+// tracker mirrors the security tracker export: package name → CVE ID →
+// per-release status.
+type tracker map[string]map[string]struct {
+	Description string `json:"description"`
+	Scope       string `json:"scope"`
+	Releases    map[string]struct {
+		Status       string `json:"status"`
+		FixedVersion string `json:"fixed_version"`
+		Urgency      string `json:"urgency"`
+	} `json:"releases"`
+}
+
+// Import reads the tracker export and produces one OSV record per CVE,
+// with one affected entry per (package, release) pair. Releases not in
+// the codename map (sid, end-of-life releases) are skipped.
+func Import(r io.Reader) ([]osvschema.Vulnerability, error) {
+	var data tracker
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	// The tracker export carries no timestamps, so records are stamped
+	// with the conversion time; modified is required by the schema.
+	now := time.Now().UTC()
+
+	byID := map[string]*osvschema.Vulnerability{}
+	for pkg, cves := range data {
+		for id, entry := range cves {
+			for release, status := range entry.Releases {
+				version, ok := releaseVersions[release]
+				if !ok {
+					continue
+				}
+				vuln := byID[id]
+				if vuln == nil {
+					vuln = &osvschema.Vulnerability{
+						SchemaVersion: osvschema.SchemaVersion,
+						ID:            id,
+						Modified:      now,
+						Details:       entry.Description,
+					}
+					byID[id] = vuln
+				}
+				events := []osvschema.Event{{Introduced: "0"}}
+				if status.Status == "resolved" && status.FixedVersion != "" {
+					events = append(events, osvschema.Event{Fixed: status.FixedVersion})
+				}
+				vuln.Affected = append(vuln.Affected, osvschema.Affected{
+					Package: osvschema.Package{
+						Ecosystem: "Debian:" + version,
+						Name:      pkg,
+					},
+					Ranges: []osvschema.Range{{
+						Type:   osvschema.RangeEcosystem,
+						Events: events,
+					}},
+					EcosystemSpecific: map[string]interface{}{"urgency": status.Urgency},
+				})
+			}
+		}
+	}
+
+	return sortedRecords(byID), nil
+}
+
+// sortedRecords flattens the record map deterministically: records by
+// ID, affected entries by ecosystem then package name.
+func sortedRecords(byID map[string]*osvschema.Vulnerability) []osvschema.Vulnerability {
+	var vulns []osvschema.Vulnerability
+	for _, vuln := range byID {
+		sort.Slice(vuln.Affected, func(i, j int) bool {
+			a, b := vuln.Affected[i].Package, vuln.Affected[j].Package
+			if a.Ecosystem != b.Ecosystem {
+				return a.Ecosystem < b.Ecosystem
+			}
+
+			return a.Name < b.Name
+		})
+		vulns = append(vulns, *vuln)
+	}
+	sort.Slice(vulns, func(i, j int) bool { return vulns[i].ID < vulns[j].ID })
+
+	return vulns
+}
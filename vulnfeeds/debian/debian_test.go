@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	tracker := `{
+		"curl": {
+			"CVE-2023-38545": {
+				"description": "SOCKS5 heap buffer overflow",
+				"releases": {
+					"bookworm": {"status": "resolved", "fixed_version": "7.88.1-10+deb12u4", "urgency": "high"},
+					"bullseye": {"status": "open", "urgency": "not yet assigned"},
+					"sid": {"status": "resolved", "fixed_version": "8.3.0-1"}
+				}
+			}
+		},
+		"openssl": {
+			"CVE-2023-38545": {
+				"description": "SOCKS5 heap buffer overflow",
+				"releases": {
+					"bookworm": {"status": "open", "urgency": "low"}
+				}
+			}
+		}
+	}`
+	vulns, err := Import(strings.NewReader(tracker))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(vulns) != 1 {
+		t.Fatalf("Import produced %d records, want 1", len(vulns))
+	}
+	vuln := vulns[0]
+	if err := vuln.Validate(); err != nil {
+		t.Errorf("%s does not validate: %v", vuln.ID, err)
+	}
+	if vuln.Modified.IsZero() {
+		t.Errorf("record has no modified timestamp")
+	}
+	// One affected entry per (package, release) pair; sid is not in the
+	// codename map and is skipped.
+	if len(vuln.Affected) != 3 {
+		t.Fatalf("got %d affected entries, want 3: %+v", len(vuln.Affected), vuln.Affected)
+	}
+	// Entries are sorted by ecosystem then package name.
+	resolved := vuln.Affected[1]
+	if resolved.Package.Ecosystem != "Debian:12" || resolved.Package.Name != "curl" {
+		t.Errorf("affected[1] package = %+v, want curl in Debian:12", resolved.Package)
+	}
+	events := resolved.Ranges[0].Events
+	if len(events) != 2 || events[1].Fixed != "7.88.1-10+deb12u4" {
+		t.Errorf("resolved events = %+v, want a fixed event", events)
+	}
+	if got := resolved.EcosystemSpecific["urgency"]; got != "high" {
+		t.Errorf("urgency = %v, want high", got)
+	}
+	// Open issues have no fixed event.
+	open := vuln.Affected[0]
+	if open.Package.Ecosystem != "Debian:11" {
+		t.Errorf("affected[0] package = %+v, want curl in Debian:11", open.Package)
+	}
+	if len(open.Ranges[0].Events) != 1 {
+		t.Errorf("open events = %+v, want only introduced 0", open.Ranges[0].Events)
+	}
+}
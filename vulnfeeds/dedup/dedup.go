@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dedup indexes the IDs and aliases of an existing OSV record
+// set, so importers can skip advisories that are already present under
+// another identifier.
+package dedup
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// Index maps every known ID and alias to the record ID carrying it.
+type Index struct {
+	ids map[string]string
+}
+
+// LoadDir indexes all .json OSV records under dir.
+func LoadDir(dir string) (*Index, error) {
+	idx := &Index{ids: map[string]string{}}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var vuln osvschema.Vulnerability
+		if err := json.Unmarshal(data, &vuln); err != nil {
+			// Skip files that are not OSV records rather than failing the
+			// whole import.
+			return nil
+		}
+		idx.Add(&vuln)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// Add records a vulnerability's ID and aliases in the index.
+func (idx *Index) Add(vuln *osvschema.Vulnerability) {
+	idx.ids[vuln.ID] = vuln.ID
+	for _, alias := range vuln.Aliases {
+		if _, exists := idx.ids[alias]; !exists {
+			idx.ids[alias] = vuln.ID
+		}
+	}
+}
+
+// Existing returns the ID of a different already-indexed record sharing
+// the vulnerability's ID or any of its aliases, or "" if the
+// vulnerability is new. Re-importing a record under its own ID is an
+// update, not a duplicate.
+func (idx *Index) Existing(vuln *osvschema.Vulnerability) string {
+	if id, ok := idx.ids[vuln.ID]; ok && id != vuln.ID {
+		return id
+	}
+	for _, alias := range vuln.Aliases {
+		if id, ok := idx.ids[alias]; ok && id != vuln.ID {
+			return id
+		}
+	}
+
+	return ""
+}
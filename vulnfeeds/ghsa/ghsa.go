@@ -0,0 +1,274 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ghsa imports GitHub Security Advisories over the GraphQL API
+// and normalizes them into OSV records.
+package ghsa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+const graphqlEndpoint = "https://api.github.com/graphql"
+
+const advisoriesQuery = `
+query($cursor: String) {
+  securityAdvisories(first: 100, after: $cursor) {
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ghsaId
+      summary
+      description
+      publishedAt
+      updatedAt
+      withdrawnAt
+      identifiers { type value }
+      references { url }
+      cvss { vectorString }
+      vulnerabilities(first: 50) {
+        nodes {
+          package { ecosystem name }
+          vulnerableVersionRange
+          firstPatchedVersion { identifier }
+        }
+      }
+    }
+  }
+}`
+
+// ecosystems maps GHSA ecosystem enums to OSV ecosystem names.
+var ecosystems = map[string]string{
+	"NPM":      "npm",
+	"PIP":      "PyPI",
+	"RUBYGEMS": "RubyGems",
+	"MAVEN":    "Maven",
+	"NUGET":    "NuGet",
+	"COMPOSER": "Packagist",
+	"GO":       "Go",
+	"RUST":     "crates.io",
+	"ERLANG":   "Hex",
+	"PUB":      "Pub",
+	"SWIFT":    "SwiftURL",
+	"ACTIONS":  "GitHub Actions",
+}
+
+// Client fetches advisories from the GitHub GraphQL API.
+type Client struct {
+	// Token is a GitHub API token with public read access.
+	Token string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type advisory struct {
+	GHSAID      string    `json:"ghsaId"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	WithdrawnAt time.Time `json:"withdrawnAt"`
+	Identifiers []struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"identifiers"`
+	References []struct {
+		URL string `json:"url"`
+	} `json:"references"`
+	CVSS struct {
+		VectorString string `json:"vectorString"`
+	} `json:"cvss"`
+	Vulnerabilities struct {
+		Nodes []struct {
+			Package struct {
+				Ecosystem string `json:"ecosystem"`
+				Name      string `json:"name"`
+			} `json:"package"`
+			VulnerableVersionRange string `json:"vulnerableVersionRange"`
+			FirstPatchedVersion    *struct {
+				Identifier string `json:"identifier"`
+			} `json:"firstPatchedVersion"`
+		} `json:"nodes"`
+	} `json:"vulnerabilities"`
+}
+
+// Import fetches all advisories, page by page, converted to OSV.
+func (c *Client) Import(ctx context.Context) ([]osvschema.Vulnerability, error) {
+	var vulns []osvschema.Vulnerability
+	cursor := ""
+	for {
+		page, next, err := c.fetchPage(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, adv := range page {
+			vulns = append(vulns, convert(adv))
+		}
+		if next == "" {
+			return vulns, nil
+		}
+		cursor = next
+	}
+}
+
+func (c *Client) fetchPage(ctx context.Context, cursor string) ([]advisory, string, error) {
+	variables := map[string]interface{}{}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     advisoriesQuery,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphqlEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json")
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("advisory query failed: %s", resp.Status)
+	}
+
+	var result struct {
+		Data struct {
+			SecurityAdvisories struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []advisory `json:"nodes"`
+			} `json:"securityAdvisories"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, "", err
+	}
+	if len(result.Errors) > 0 {
+		return nil, "", fmt.Errorf("advisory query failed: %s", result.Errors[0].Message)
+	}
+	advisories := result.Data.SecurityAdvisories
+	next := ""
+	if advisories.PageInfo.HasNextPage {
+		next = advisories.PageInfo.EndCursor
+	}
+
+	return advisories.Nodes, next, nil
+}
+
+func convert(adv advisory) osvschema.Vulnerability {
+	vuln := osvschema.Vulnerability{
+		SchemaVersion: osvschema.SchemaVersion,
+		ID:            adv.GHSAID,
+		Summary:       adv.Summary,
+		Details:       adv.Description,
+		Published:     osvschema.Timestamp(adv.PublishedAt),
+		Modified:      adv.UpdatedAt,
+		Withdrawn:     osvschema.Timestamp(adv.WithdrawnAt),
+	}
+	for _, identifier := range adv.Identifiers {
+		if identifier.Type != "GHSA" {
+			vuln.Aliases = append(vuln.Aliases, identifier.Value)
+		}
+	}
+	for _, reference := range adv.References {
+		vuln.References = append(vuln.References, osvschema.Reference{
+			Type: osvschema.ReferenceWeb,
+			URL:  reference.URL,
+		})
+	}
+	if adv.CVSS.VectorString != "" {
+		vuln.Severity = append(vuln.Severity, osvschema.Severity{
+			Type:  osvschema.SeverityCVSSV3,
+			Score: adv.CVSS.VectorString,
+		})
+	}
+	for _, node := range adv.Vulnerabilities.Nodes {
+		ecosystem, ok := ecosystems[node.Package.Ecosystem]
+		if !ok {
+			continue
+		}
+		affected := osvschema.Affected{
+			Package: osvschema.Package{Ecosystem: ecosystem, Name: node.Package.Name},
+		}
+		fixed := ""
+		if node.FirstPatchedVersion != nil {
+			fixed = node.FirstPatchedVersion.Identifier
+		}
+		affected.Ranges = []osvschema.Range{{
+			Type:   osvschema.RangeEcosystem,
+			Events: rangeEvents(node.VulnerableVersionRange, fixed),
+		}}
+		vuln.Affected = append(vuln.Affected, affected)
+	}
+
+	return vuln
+}
+
+// rangeEvents turns a GHSA version range expression (">= 1.0, < 1.4",
+// "< 2.0.1", "= 1.5.0") into OSV events, with the first patched version
+// as the fixed event when the range itself has no upper bound.
+func rangeEvents(expr, fixed string) []osvschema.Event {
+	events := []osvschema.Event{}
+	introduced := "0"
+	hasUpper := false
+	for _, part := range strings.Split(expr, ",") {
+		op, version, ok := strings.Cut(strings.TrimSpace(part), " ")
+		if !ok {
+			continue
+		}
+		version = strings.TrimSpace(version)
+		switch op {
+		case ">=", ">":
+			introduced = version
+		case "<":
+			events = append(events, osvschema.Event{Fixed: version})
+			hasUpper = true
+		case "<=":
+			events = append(events, osvschema.Event{LastAffected: version})
+			hasUpper = true
+		case "=":
+			introduced = version
+			events = append(events, osvschema.Event{LastAffected: version})
+			hasUpper = true
+		}
+	}
+	if !hasUpper && fixed != "" {
+		events = append(events, osvschema.Event{Fixed: fixed})
+	}
+
+	return append([]osvschema.Event{{Introduced: introduced}}, events...)
+}
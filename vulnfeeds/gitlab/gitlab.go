@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitlab imports the GitLab advisory database (the gemnasium-db
+// YAML tree) and normalizes it into OSV records.
+package gitlab
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// advisory is one gemnasium-db YAML file.
+type advisory struct {
+	Identifier    string   `yaml:"identifier"`
+	Identifiers   []string `yaml:"identifiers"`
+	PackageSlug   string   `yaml:"package_slug"`
+	Title         string   `yaml:"title"`
+	Description   string   `yaml:"description"`
+	AffectedRange string   `yaml:"affected_range"`
+	FixedVersions []string `yaml:"fixed_versions"`
+	PubDate       string   `yaml:"pubdate"`
+	Date          string   `yaml:"date"`
+	URLs          []string `yaml:"urls"`
+	CVSSV3        string   `yaml:"cvss_v3"`
+}
+
+// ecosystems maps the package_slug type prefix to OSV ecosystem names.
+var ecosystems = map[string]string{
+	"npm":       "npm",
+	"pypi":      "PyPI",
+	"gem":       "RubyGems",
+	"maven":     "Maven",
+	"nuget":     "NuGet",
+	"packagist": "Packagist",
+	"go":        "Go",
+	"conan":     "ConanCenter",
+}
+
+// ImportDir converts every advisory in a checkout of the GitLab advisory
+// database. Advisories for unsupported package types are skipped and
+// reported in the returned notes.
+func ImportDir(dir string) ([]osvschema.Vulnerability, []string, error) {
+	var vulns []osvschema.Vulnerability
+	var notes []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if filepath.Ext(path) != ".yml" && filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var adv advisory
+		if err := yaml.Unmarshal(data, &adv); err != nil {
+			notes = append(notes, fmt.Sprintf("%s: invalid advisory: %v", path, err))
+			return nil
+		}
+		vuln, err := convert(adv)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+		vulns = append(vulns, vuln)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vulns, notes, nil
+}
+
+func convert(adv advisory) (osvschema.Vulnerability, error) {
+	slugType, name, ok := strings.Cut(adv.PackageSlug, "/")
+	if !ok {
+		return osvschema.Vulnerability{}, fmt.Errorf("invalid package_slug %q", adv.PackageSlug)
+	}
+	ecosystem, ok := ecosystems[slugType]
+	if !ok {
+		return osvschema.Vulnerability{}, fmt.Errorf("unsupported package type %q", slugType)
+	}
+
+	vuln := osvschema.Vulnerability{
+		SchemaVersion: osvschema.SchemaVersion,
+		ID:            canonicalID(adv),
+		Summary:       adv.Title,
+		Details:       adv.Description,
+		Published:     osvschema.Timestamp(parseDate(adv.PubDate)),
+		Modified:      parseDate(adv.Date),
+	}
+	for _, identifier := range adv.Identifiers {
+		if identifier != vuln.ID {
+			vuln.Aliases = append(vuln.Aliases, identifier)
+		}
+	}
+	for _, u := range adv.URLs {
+		vuln.References = append(vuln.References, osvschema.Reference{
+			Type: osvschema.ReferenceWeb,
+			URL:  u,
+		})
+	}
+	if adv.CVSSV3 != "" {
+		vuln.Severity = append(vuln.Severity, osvschema.Severity{
+			Type:  osvschema.SeverityCVSSV3,
+			Score: adv.CVSSV3,
+		})
+	}
+
+	affected := osvschema.Affected{
+		Package: osvschema.Package{Ecosystem: ecosystem, Name: name},
+		Ranges: []osvschema.Range{{
+			Type:   osvschema.RangeEcosystem,
+			Events: rangeEvents(adv.AffectedRange, adv.FixedVersions),
+		}},
+	}
+	vuln.Affected = []osvschema.Affected{affected}
+
+	return vuln, nil
+}
+
+// canonicalID prefers a CVE or GHSA identifier; GitLab-internal
+// identifiers only appear when nothing better exists.
+func canonicalID(adv advisory) string {
+	for _, prefix := range []string{"CVE-", "GHSA-"} {
+		for _, identifier := range adv.Identifiers {
+			if strings.HasPrefix(identifier, prefix) {
+				return identifier
+			}
+		}
+	}
+	if adv.Identifier != "" {
+		return adv.Identifier
+	}
+	if len(adv.Identifiers) > 0 {
+		return adv.Identifiers[0]
+	}
+
+	return ""
+}
+
+// rangeEvents converts the affected_range expression, which uses the
+// same comma/double-pipe operator syntax as gemnasium (">=1.0 <1.4" or
+// "<2.0.1||>=3.0 <3.2"), into OSV events; the fixed versions close any
+// span without an upper bound.
+func rangeEvents(expr string, fixedVersions []string) []osvschema.Event {
+	var events []osvschema.Event
+	fixedIdx := 0
+	for _, span := range strings.Split(expr, "||") {
+		introduced := "0"
+		upper := osvschema.Event{}
+		for _, part := range strings.Fields(span) {
+			switch {
+			case strings.HasPrefix(part, ">="):
+				introduced = strings.TrimPrefix(part, ">=")
+			case strings.HasPrefix(part, ">"):
+				introduced = strings.TrimPrefix(part, ">")
+			case strings.HasPrefix(part, "<="):
+				upper = osvschema.Event{LastAffected: strings.TrimPrefix(part, "<=")}
+			case strings.HasPrefix(part, "<"):
+				upper = osvschema.Event{Fixed: strings.TrimPrefix(part, "<")}
+			case strings.HasPrefix(part, "="):
+				version := strings.TrimPrefix(part, "=")
+				introduced = version
+				upper = osvschema.Event{LastAffected: version}
+			}
+		}
+		if upper == (osvschema.Event{}) && fixedIdx < len(fixedVersions) {
+			upper = osvschema.Event{Fixed: fixedVersions[fixedIdx]}
+			fixedIdx++
+		}
+		events = append(events, osvschema.Event{Introduced: introduced})
+		if upper != (osvschema.Event{}) {
+			events = append(events, upper)
+		}
+	}
+
+	return events
+}
+
+func parseDate(s string) time.Time {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ubuntu translates the Ubuntu Security Notices database JSON
+// (as published at usn.ubuntu.com) into OSV records with EVR version
+// ranges.
+package ubuntu
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/osv/go/osvschema"
+)
+
+// releaseVersions maps Ubuntu release codenames to version numbers.
+var releaseVersions = map[string]string{
+	"xenial": "16.04",
+	"bionic": "18.04",
+	"focal":  "20.04",
+	"jammy":  "22.04",
+	"noble":  "24.04",
+}
+
+// notice is one USN entry of the database export.
+type notice struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	CVEs        []string `json:"cves"`
+	Timestamp   float64  `json:"timestamp"`
+	Releases    map[string]struct {
+		Sources map[string]struct {
+			Version string `json:"version"`
+		} `json:"sources"`
+	} `json:"releases"`
+}
+
+// Import reads a USN database export (USN ID → notice) and produces one
+// OSV record per notice, with the patched source package versions as
+// fixed events.
+func Import(r io.Reader) ([]osvschema.Vulnerability, error) {
+	var notices map[string]notice
+	if err := json.NewDecoder(r).Decode(&notices); err != nil {
+		return nil, err
+	}
+
+	var vulns []osvschema.Vulnerability
+	for id, n := range notices {
+		usnID := n.ID
+		if usnID == "" {
+			usnID = id
+		}
+		vuln := osvschema.Vulnerability{
+			SchemaVersion: osvschema.SchemaVersion,
+			ID:            "USN-" + usnID,
+			Summary:       n.Title,
+			Details:       n.Description,
+			Modified:      time.Unix(int64(n.Timestamp), 0).UTC(),
+			Published:     osvschema.Timestamp(time.Unix(int64(n.Timestamp), 0).UTC()),
+		}
+		for _, cve := range n.CVEs {
+			vuln.Aliases = append(vuln.Aliases, cve)
+		}
+		for release, data := range n.Releases {
+			version, ok := releaseVersions[release]
+			if !ok {
+				continue
+			}
+			for pkg, source := range data.Sources {
+				vuln.Affected = append(vuln.Affected, osvschema.Affected{
+					Package: osvschema.Package{
+						Ecosystem: "Ubuntu:" + version,
+						Name:      pkg,
+					},
+					Ranges: []osvschema.Range{{
+						Type: osvschema.RangeEcosystem,
+						Events: []osvschema.Event{
+							{Introduced: "0"},
+							{Fixed: source.Version},
+						},
+					}},
+				})
+			}
+		}
+		if len(vuln.Affected) == 0 {
+			continue
+		}
+		sort.Slice(vuln.Affected, func(i, j int) bool {
+			a, b := vuln.Affected[i].Package, vuln.Affected[j].Package
+			if a.Ecosystem != b.Ecosystem {
+				return a.Ecosystem < b.Ecosystem
+			}
+
+			return a.Name < b.Name
+		})
+		vulns = append(vulns, vuln)
+	}
+	sort.Slice(vulns, func(i, j int) bool { return vulns[i].ID < vulns[j].ID })
+
+	return vulns, nil
+}
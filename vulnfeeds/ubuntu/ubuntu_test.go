@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ubuntu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	db := `{
+		"6237-1": {
+			"id": "6237-1",
+			"title": "curl vulnerability",
+			"description": "curl could be made to crash.",
+			"cves": ["CVE-2023-38545"],
+			"timestamp": 1696946400,
+			"releases": {
+				"jammy": {"sources": {"curl": {"version": "7.81.0-1ubuntu1.14"}}},
+				"focal": {"sources": {"curl": {"version": "7.68.0-1ubuntu2.20"}}},
+				"trusty": {"sources": {"curl": {"version": "7.35.0-1ubuntu2.20"}}}
+			}
+		},
+		"9999-1": {
+			"id": "9999-1",
+			"title": "no supported releases",
+			"timestamp": 1696946400,
+			"releases": {
+				"trusty": {"sources": {"bash": {"version": "4.3-7ubuntu1.8"}}}
+			}
+		}
+	}`
+	vulns, err := Import(strings.NewReader(db))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	// The notice with only out-of-map releases is dropped entirely.
+	if len(vulns) != 1 {
+		t.Fatalf("Import produced %d records, want 1: %+v", len(vulns), vulns)
+	}
+	vuln := vulns[0]
+	if err := vuln.Validate(); err != nil {
+		t.Errorf("%s does not validate: %v", vuln.ID, err)
+	}
+	if vuln.ID != "USN-6237-1" {
+		t.Errorf("ID = %q, want USN-6237-1", vuln.ID)
+	}
+	if vuln.Modified.IsZero() || vuln.Published == nil {
+		t.Errorf("timestamps = %v / %v, want both set from the notice", vuln.Modified, vuln.Published)
+	}
+	if len(vuln.Aliases) != 1 || vuln.Aliases[0] != "CVE-2023-38545" {
+		t.Errorf("aliases = %v, want the CVE", vuln.Aliases)
+	}
+	// One affected entry per supported release, sorted by ecosystem;
+	// trusty is not in the codename map and is skipped.
+	if len(vuln.Affected) != 2 {
+		t.Fatalf("got %d affected entries, want 2: %+v", len(vuln.Affected), vuln.Affected)
+	}
+	focal := vuln.Affected[0]
+	if focal.Package.Ecosystem != "Ubuntu:20.04" || focal.Package.Name != "curl" {
+		t.Errorf("affected[0] package = %+v, want curl in Ubuntu:20.04", focal.Package)
+	}
+	events := focal.Ranges[0].Events
+	if len(events) != 2 || events[1].Fixed != "7.68.0-1ubuntu2.20" {
+		t.Errorf("focal events = %+v, want a fixed event", events)
+	}
+	if vuln.Affected[1].Package.Ecosystem != "Ubuntu:22.04" {
+		t.Errorf("affected[1] = %+v, want the jammy entry", vuln.Affected[1].Package)
+	}
+}